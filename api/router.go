@@ -38,19 +38,14 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	invoiceReturnRepo := repository.InitInvoiceReturnRepository(db)
 	invoiceMaterialRepo := repository.InitInvoiceMaterialsRepository(db)
 	invoiceOutputOutOfProjectRepo := repository.InitInvoiceOutputOutOfProjectRepository(db)
-	kl04kvObjectRepo := repository.InitKL04KVObjectRepository(db)
 	materialCostRepo := repository.InitMaterialCostRepository(db)
 	materialLocationRepo := repository.InitMaterialLocationRepository(db)
 	materialRepo := repository.InitMaterialRepository(db)
-	mjdObjectRepo := repository.InitMJDObjectRepository(db)
 	// objectOperationRepo := repository.InitObjectOperationRepository(db)
 	objectRepo := repository.InitObjectRepository(db)
 	// operationRepo := repository.InitOperationRepository(db)
 	projectRepo := repository.InitProjectRepository(db)
-	sipObjectRepo := repository.InitSIPObjectRepository(db)
-	stvtObjectRepo := repository.InitSTVTObjectRepository(db)
 	teamRepo := repository.InitTeamRepostory(db)
-	tpObjectRepo := repository.InitTPObjectRepository(db)
 	userRepo := repository.InitUserRepository(db)
 	userInProjects := repository.InitUserInProjectRepository(db)
 	workerRepo := repository.InitWorkerRepository(db)
@@ -66,16 +61,38 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	resourceRepo := repository.InitResourceRepository(db)
 	invoiceObjectRepo := repository.InitInvoiceObjectRepository(db)
 	invoiceCorrectionRepo := repository.InitInvoiceCorrectionRepository(db)
-	substationObjectRepo := repository.InitSubstationObjectRepository(db)
-	tpNourashesObjectsRepo := repository.InitTPNourashesObjectsRepository(db)
 	invoiceCountRepo := repository.InitInvoiceCountRepository(db)
 	operationRepo := repository.InitOperationRepository(db)
 	operationMaterialRepo := repository.InitOperationMaterialRepository(db)
 	invoiceWriteOffRepo := repository.InitInvoiceWriteOffRepository(db)
 	workerAttendanceRepo := repository.InitWorkerAttendanceRepository(db)
+	attendanceImportFormatRepo := repository.NewAttendanceImportFormatRepository(db)
 	mainReportRepository := repository.InitMainReportRepository(db)
-	substationCellRepository := repository.NewSubstationCellObjectRepository(db)
 	statisticsRepository := repository.NewStatisticsRepository(db)
+	operatorErrorFoundRepository := repository.NewOperatorErrorFoundRepository(db)
+	unitRepo := repository.NewUnitRepository(db)
+	materialUnitRepo := repository.NewMaterialUnitRepository(db)
+	materialLotRepo := repository.NewMaterialLotRepository(db)
+	operationBOMRepo := repository.NewOperationBOMRepository(db)
+	objectStatusRepo := repository.NewObjectStatusRepository(db)
+	networkTopologyRepo := repository.NewNetworkTopologyRepository(db)
+	objectGeolocationRepo := repository.NewObjectGeolocationRepository(db)
+	objectTypeRepo := repository.NewObjectTypeRepository(db)
+	objectPlanRepo := repository.NewObjectPlanRepository(db)
+	objectProfitabilityRepo := repository.NewObjectProfitabilityRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	handoverActRepo := repository.NewHandoverActRepository(db)
+	completedWorksRepo := repository.NewCompletedWorksRepository(db)
+	materialConsumptionRepo := repository.NewMaterialConsumptionRepository(db)
+	projectCalendarRepo := repository.NewProjectCalendarRepository(db)
+	teamEarningsRepo := repository.NewTeamEarningsRepository(db)
+	teamMaterialAgingRepo := repository.NewTeamMaterialAgingRepository(db)
+	qualificationRepo := repository.NewQualificationRepository(db)
+	teamMemberRepo := repository.NewTeamMemberRepository(db)
+	kpiRepo := repository.NewKPIRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	selfServiceRepo := repository.NewSelfServiceRepository(db)
+	workerMergeRepo := repository.NewWorkerMergeRepository(db)
 
 	//Initialization of Services
 	auctionService := service.InitAuctionService(auctionRepository)
@@ -89,6 +106,7 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		serialNumberRepo,
 		serialNumberMovementRepo,
 		invoiceCountRepo,
+		materialUnitRepo,
 	)
 	invoiceOutputService := service.InitInvoiceOutputService(
 		invoiceOutputRepo,
@@ -103,6 +121,8 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		serialNumberRepo,
 		invoiceCountRepo,
 		projectRepo,
+		materialUnitRepo,
+		materialLotRepo,
 	)
 	invoiceOutputOutOfProjectService := service.InitInvoiceOutputOutOfProjectService(
 		invoiceOutputOutOfProjectRepo,
@@ -113,6 +133,7 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		materialRepo,
 		workerRepo,
 		materialCostRepo,
+		projectRepo,
 	)
 	invoiceReturnService := service.InitInvoiceReturnService(
 		invoiceReturnRepo,
@@ -129,6 +150,8 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		districtRepo,
 		objectSupervisorsRepo,
 		invoiceCountRepo,
+		materialUnitRepo,
+		materialLotRepo,
 	)
 	invoiceObjectService := service.InitInvoiceObjectService(
 		invoiceObjectRepo,
@@ -143,25 +166,22 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		operationMaterialRepo,
 		operationRepo,
 		materialRepo,
+		materialLotRepo,
+		projectRepo,
+		operationBOMRepo,
+		teamMemberRepo,
 	)
 	invoiceCorrectionService := service.InitInvoiceCorrectionService(
 		invoiceCorrectionRepo,
 		invoiceObjectRepo,
 		invoiceMaterialRepo,
 		materialLocationRepo,
+		materialRepo,
+		materialLotRepo,
+		projectRepo,
 	)
 
 	// invoiceMaterialsService := service.InitInvoiceMaterialsService(invoiceMaterialRepo)
-	kl04kvObjectService := service.InitKL04KVObjectService(
-		kl04kvObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		tpNourashesObjectsRepo,
-		teamRepo,
-		tpObjectRepo,
-		objectRepo,
-	)
 	materialCostService := service.InitMaterialCostService(
 		materialCostRepo,
 		materialRepo,
@@ -178,25 +198,10 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	)
 
 	materialService := service.InitMaterialService(materialRepo)
-	mjdObjectService := service.InitMJDObjectService(
-		mjdObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		tpNourashesObjectsRepo,
-		teamRepo,
-		tpObjectRepo,
-		objectRepo,
-	)
 	// objectOperationService := service.InitObjectOperationService(objectOperationRepo)
 	objectService := service.InitObjectService(
 		objectRepo,
 		objectSupervisorsRepo,
-		kl04kvObjectRepo,
-		mjdObjectRepo,
-		sipObjectRepo,
-		stvtObjectRepo,
-		tpObjectRepo,
 		objectTeamsRepo,
 	)
 	operationService := service.InitOperationService(
@@ -204,36 +209,12 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		materialRepo,
 	)
 	projectService := service.InitProjectService(projectRepo)
-	sipObjectService := service.InitSIPObjectService(
-		sipObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		teamRepo,
-		tpObjectRepo,
-		tpNourashesObjectsRepo,
-		objectRepo,
-	)
-	stvtObjectService := service.InitSTVTObjectService(
-		stvtObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		teamRepo,
-	)
 	teamService := service.InitTeamService(
 		teamRepo,
+		teamMemberRepo,
 		workerRepo,
 		objectRepo,
 	)
-	tpObjctService := service.InitTPObjectService(
-		tpObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		objectRepo,
-		teamRepo,
-	)
 	userService := service.InitUserService(
 		userRepo,
 		userInProjects,
@@ -252,13 +233,6 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	roleService := service.InitRoleService(roleRepo)
 	userActionService := service.InitUserActionService(userActionRepo, userRepo)
 	resourceService := service.InitResourceService(resourceRepo)
-	substationObjectService := service.InitSubstationObjectService(
-		substationObjectRepo,
-		workerRepo,
-		objectSupervisorsRepo,
-		objectTeamsRepo,
-		teamRepo,
-	)
 	invoiceWriteOffService := service.InitInvoiceWriteOffService(
 		invoiceWriteOffRepo,
 		workerRepo,
@@ -269,21 +243,114 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		materialRepo,
 		materialCostRepo,
 		invoiceCountRepo,
+		projectRepo,
 	)
 	workerAttendanceService := service.InitWorkerAttendanceService(
 		workerAttendanceRepo,
 		workerRepo,
+		attendanceImportFormatRepo,
 	)
 	mainReportService := service.InitMainReportService(mainReportRepository)
-	substationCellObjectService := service.InitSubstationCellObjectService(
-		substationCellRepository,
+	statisticsService := service.NewStatisticsService(statisticsRepository, workerRepo)
+	operatorErrorFoundService := service.NewOperatorErrorFoundService(operatorErrorFoundRepository)
+	unitService := service.NewUnitService(unitRepo, materialUnitRepo, materialRepo)
+	materialLotService := service.NewMaterialLotService(materialLotRepo)
+	costAllocationService := service.NewCostAllocationService(materialLocationRepo, projectRepo)
+	operationBOMService := service.NewOperationBOMService(operationBOMRepo, operationRepo, materialRepo, materialLocationRepo)
+	objectStatusService := service.NewObjectStatusService(objectStatusRepo, objectRepo, permissionRepo)
+	networkTopologyService := service.NewNetworkTopologyService(networkTopologyRepo, objectRepo, objectTypeRepo)
+	objectGeolocationService := service.NewObjectGeolocationService(objectGeolocationRepo)
+	objectTypeService := service.NewObjectTypeService(
+		objectTypeRepo,
+		objectRepo,
+		workerRepo,
+		teamRepo,
 		objectSupervisorsRepo,
 		objectTeamsRepo,
+		permissionRepo,
+	)
+	objectPlanService := service.NewObjectPlanService(
+		objectPlanRepo,
+		objectRepo,
+		materialRepo,
+		operationRepo,
+		objectTypeRepo,
+	)
+	objectProfitabilityService := service.NewObjectProfitabilityService(
+		objectProfitabilityRepo,
+		objectRepo,
+		objectTypeRepo,
+	)
+	attachmentService := service.NewAttachmentService(
+		attachmentRepo,
+		objectRepo,
+		invoiceObjectRepo,
+		userInProjects,
+	)
+	handoverActService := service.NewHandoverActService(
+		handoverActRepo,
+		objectRepo,
+		projectRepo,
+		objectTypeRepo,
+	)
+	completedWorksService := service.NewCompletedWorksService(
+		completedWorksRepo,
+		projectRepo,
+		objectTypeRepo,
+	)
+	materialConsumptionService := service.NewMaterialConsumptionService(
+		materialConsumptionRepo,
+		projectRepo,
+		objectRepo,
+		districtRepo,
+		objectTypeRepo,
+	)
+	timesheetService := service.NewTimesheetService(
+		workerAttendanceRepo,
+		projectCalendarRepo,
+		projectRepo,
+	)
+	teamEarningsService := service.NewTeamEarningsService(
+		teamEarningsRepo,
+		projectRepo,
+		teamRepo,
 		workerRepo,
+	)
+	teamMaterialAgingService := service.NewTeamMaterialAgingService(
+		teamMaterialAgingRepo,
 		teamRepo,
-		substationObjectRepo,
+		projectRepo,
 	)
-	statisticsService := service.NewStatisticsService(statisticsRepository, workerRepo)
+	qualificationService := service.NewQualificationService(
+		qualificationRepo,
+		workerRepo,
+	)
+	teamMemberService := service.NewTeamMemberService(
+		teamMemberRepo,
+		teamRepo,
+		workerRepo,
+	)
+	kpiService := service.NewKPIService(
+		kpiRepo,
+		teamRepo,
+		workerRepo,
+	)
+	scheduleService := service.NewScheduleService(
+		scheduleRepo,
+		teamRepo,
+		objectRepo,
+		districtRepo,
+		operationRepo,
+		teamMemberRepo,
+		projectRepo,
+	)
+	selfServiceService := service.NewSelfServiceService(
+		selfServiceRepo,
+		workerRepo,
+		teamMaterialAgingRepo,
+		qualificationRepo,
+	)
+	workerMergeService := service.NewWorkerMergeService(workerMergeRepo, workerRepo, permissionRepo)
 
 	//Initialization of Controllers
 	auctionController := controller.InitAuctionController(auctionService)
@@ -308,18 +375,35 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	resourceController := controller.InitResourceController(resourceService)
 	invoiceObjectController := controller.InitInvoiceObjectController(invoiceObjectService)
 	invoiceCorrectionController := controller.InitInvoiceCorrectionController(invoiceCorrectionService)
-	kl04kvObjectController := controller.InitKl04KVObjectController(kl04kvObjectService)
-	mjdObjectController := controller.InitMJDObjectController(mjdObjectService)
-	sipObjectController := controller.InitSIPObjectController(sipObjectService)
-	stvtObjectController := controller.InitSTVTObjectController(stvtObjectService)
-	tpObjectController := controller.InitTPObjectController(tpObjctService)
-	substationObjectController := controller.InitSubstationObjectController(substationObjectService)
 	invoiceOutputOutOfProjectController := controller.InitInvoiceOutputOutOfProjectController(invoiceOutputOutOfProjectService)
 	invoiceWriteOffController := controller.InitInvoiceWriteOffController(invoiceWriteOffService)
 	workerAttendanceController := controller.InitWorkerAttendanceController(workerAttendanceService)
 	mainReportController := controller.InitMainReportController(mainReportService)
-	substationCellController := controller.InitSubstationCellObjectController(substationCellObjectService)
 	statisticsController := controller.NewStatisticsController(statisticsService)
+	operatorErrorFoundController := controller.NewOperatorErrorFoundController(operatorErrorFoundService)
+	unitController := controller.NewUnitController(unitService)
+	materialLotController := controller.NewMaterialLotController(materialLotService)
+	costAllocationController := controller.NewCostAllocationController(costAllocationService)
+	operationBOMController := controller.NewOperationBOMController(operationBOMService)
+	objectStatusController := controller.NewObjectStatusController(objectStatusService)
+	networkTopologyController := controller.NewNetworkTopologyController(networkTopologyService)
+	objectGeolocationController := controller.NewObjectGeolocationController(objectGeolocationService)
+	objectTypeController := controller.NewObjectTypeController(objectTypeService)
+	objectPlanController := controller.NewObjectPlanController(objectPlanService)
+	objectProfitabilityController := controller.NewObjectProfitabilityController(objectProfitabilityService)
+	attachmentController := controller.NewAttachmentController(attachmentService)
+	handoverActController := controller.NewHandoverActController(handoverActService)
+	completedWorksController := controller.NewCompletedWorksController(completedWorksService)
+	materialConsumptionController := controller.NewMaterialConsumptionController(materialConsumptionService)
+	timesheetController := controller.NewTimesheetController(timesheetService)
+	teamEarningsController := controller.NewTeamEarningsController(teamEarningsService)
+	teamMaterialAgingController := controller.NewTeamMaterialAgingController(teamMaterialAgingService)
+	qualificationController := controller.NewQualificationController(qualificationService)
+	teamMemberController := controller.NewTeamMemberController(teamMemberService)
+	kpiController := controller.NewKPIController(kpiService)
+	scheduleController := controller.NewScheduleController(scheduleService)
+	selfServiceController := controller.NewSelfServiceController(selfServiceService)
+	workerMergeController := controller.NewWorkerMergeController(workerMergeService)
 
 	//Initialization of Routes
 	InitAuctionRoutes(router, auctionController)
@@ -340,19 +424,36 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	InitResourceRoutes(router, resourceController, db)
 	InitInvoiceObjectRoutes(router, invoiceObjectController, db)
 	InitInvoiceCorrectionRoutes(router, invoiceCorrectionController)
-	InitKL04KVObjectRoutes(router, kl04kvObjectController)
-	InitMJDObjectRoutes(router, mjdObjectController)
-	InitSIPObjectRoutes(router, sipObjectController)
-	InitSTVTObjectRoutes(router, stvtObjectController)
-	InitTPObjectRoutes(router, tpObjectController)
-	InitSubstationObjectRoutes(router, substationObjectController)
 	InitInvoiceOutputOutOfProjectRoutes(router, invoiceOutputOutOfProjectController)
 	InitOperationRoutes(router, operationController)
 	InitInvoiceWriteOffRoutes(router, invoiceWriteOffController)
 	InitWorkerAttendanceRoutes(router, workerAttendanceController)
 	InitMainReports(router, mainReportController)
-	InitSubstationCellRoutes(router, substationCellController)
 	InitStatisticsRoutes(router, statisticsController)
+	InitOperatorErrorFoundRoutes(router, operatorErrorFoundController)
+	InitUnitRoutes(router, unitController)
+	InitMaterialLotRoutes(router, materialLotController)
+	InitCostAllocationRoutes(router, costAllocationController)
+	InitOperationBOMRoutes(router, operationBOMController)
+	InitObjectStatusRoutes(router, objectStatusController)
+	InitNetworkTopologyRoutes(router, networkTopologyController)
+	InitObjectGeolocationRoutes(router, objectGeolocationController)
+	InitObjectTypeRoutes(router, objectTypeController)
+	InitObjectPlanRoutes(router, objectPlanController)
+	InitObjectProfitabilityRoutes(router, objectProfitabilityController)
+	InitAttachmentRoutes(router, attachmentController)
+	InitHandoverActRoutes(router, handoverActController)
+	InitCompletedWorksRoutes(router, completedWorksController)
+	InitMaterialConsumptionRoutes(router, materialConsumptionController)
+	InitTimesheetRoutes(router, timesheetController)
+	InitTeamEarningsRoutes(router, teamEarningsController)
+	InitTeamMaterialAgingRoutes(router, teamMaterialAgingController)
+	InitQualificationRoutes(router, qualificationController)
+	InitTeamMemberRoutes(router, teamMemberController)
+	InitKPIRoutes(router, kpiController)
+	InitScheduleRoutes(router, scheduleController)
+	InitSelfServiceRoutes(router, selfServiceController)
+	InitWorkerMergeRoutes(router, workerMergeController)
 
 	return mainRouter
 }
@@ -393,6 +494,9 @@ func InitWorkerAttendanceRoutes(router *gin.RouterGroup, controller controller.I
 
 	workerAttendanceRoutes.GET("/paginated", controller.GetPaginated)
 	workerAttendanceRoutes.POST("/", controller.Import)
+	workerAttendanceRoutes.GET("/import-format/all", controller.GetFormats)
+	workerAttendanceRoutes.POST("/import-format", controller.CreateFormat)
+	workerAttendanceRoutes.DELETE("/import-format/:code", controller.DeleteFormat)
 }
 
 func InitInvoiceWriteOffRoutes(router *gin.RouterGroup, controller controller.IInvoiceWriteOffController) {
@@ -645,115 +749,6 @@ func InitObjectRoutes(router *gin.RouterGroup, controller controller.IObjectCont
 	objectRoutes.DELETE("/:id", controller.Delete)
 }
 
-func InitTPObjectRoutes(router *gin.RouterGroup, controller controller.ITPObjectController) {
-	tpObjectRoutes := router.Group("/tp")
-	tpObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	tpObjectRoutes.GET("/paginated", controller.GetPaginated)
-	tpObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	tpObjectRoutes.GET("/all", controller.GetAll)
-	tpObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	tpObjectRoutes.GET("/document/export", controller.Export)
-	tpObjectRoutes.POST("/", controller.Create)
-	tpObjectRoutes.POST("/document/import", controller.Import)
-	tpObjectRoutes.PATCH("/", controller.Update)
-	tpObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
-func InitSubstationCellRoutes(router *gin.RouterGroup, controller controller.ISubstationCellObjectController) {
-	substationCellObjectRoutes := router.Group("/cell-substation")
-	substationCellObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	substationCellObjectRoutes.GET("/paginated", controller.GetPaginated)
-	substationCellObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	substationCellObjectRoutes.GET("/document/export", controller.Export)
-	substationCellObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	substationCellObjectRoutes.POST("/document/import", controller.Import)
-	substationCellObjectRoutes.POST("/", controller.Create)
-	substationCellObjectRoutes.PATCH("/", controller.Update)
-	substationCellObjectRoutes.DELETE("/:id", controller.Delete)
-
-}
-
-func InitSubstationObjectRoutes(router *gin.RouterGroup, controller controller.ISubstationObjectController) {
-	substationObjectRoutes := router.Group("/substation")
-	substationObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	substationObjectRoutes.GET("/all", controller.GetAll)
-	substationObjectRoutes.GET("/paginated", controller.GetPaginated)
-	substationObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	substationObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	substationObjectRoutes.GET("/document/export", controller.Export)
-	substationObjectRoutes.POST("/", controller.Create)
-	substationObjectRoutes.POST("/document/import", controller.Import)
-	substationObjectRoutes.PATCH("/", controller.Update)
-	substationObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
-func InitSTVTObjectRoutes(router *gin.RouterGroup, controller controller.ISTVTObjectController) {
-	stvtObjectRoutes := router.Group("/stvt")
-	stvtObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	stvtObjectRoutes.GET("/paginated", controller.GetPaginated)
-	stvtObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	stvtObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	stvtObjectRoutes.GET("/document/export", controller.Export)
-	stvtObjectRoutes.POST("/", controller.Create)
-	stvtObjectRoutes.POST("/document/import", controller.Import)
-	stvtObjectRoutes.PATCH("/", controller.Update)
-	stvtObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
-func InitSIPObjectRoutes(router *gin.RouterGroup, controller controller.ISIPObjectController) {
-	sipObjectRoutes := router.Group("/sip")
-	sipObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	sipObjectRoutes.GET("/paginated", controller.GetPaginated)
-	sipObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	sipObjectRoutes.GET("/tp-names", controller.GetTPNames)
-	sipObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	sipObjectRoutes.GET("/document/export", controller.Export)
-	sipObjectRoutes.POST("/", controller.Create)
-	sipObjectRoutes.POST("/document/import", controller.Import)
-	sipObjectRoutes.PATCH("/", controller.Update)
-	sipObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
-func InitMJDObjectRoutes(router *gin.RouterGroup, controller controller.IMJDObjectController) {
-	mjdObjectRoutes := router.Group("/mjd")
-	mjdObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	mjdObjectRoutes.GET("/paginated", controller.GetPaginated)
-	mjdObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	mjdObjectRoutes.GET("/document/export", controller.Export)
-	mjdObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	mjdObjectRoutes.POST("/", controller.Create)
-	mjdObjectRoutes.POST("/document/import", controller.Import)
-	mjdObjectRoutes.PATCH("/", controller.Update)
-	mjdObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
-func InitKL04KVObjectRoutes(router *gin.RouterGroup, controller controller.IKL04KVObjectController) {
-	kl04kvObjectRoutes := router.Group("/kl04kv")
-	kl04kvObjectRoutes.Use(
-		middleware.Authentication(),
-	)
-	kl04kvObjectRoutes.GET("/paginated", controller.GetPaginated)
-	kl04kvObjectRoutes.GET("/document/export", controller.Export)
-	kl04kvObjectRoutes.GET("/document/template", controller.GetTemplateFile)
-	kl04kvObjectRoutes.GET("/search/object-names", controller.GetObjectNamesForSearch)
-	kl04kvObjectRoutes.POST("/", controller.Create)
-	kl04kvObjectRoutes.POST("/document/import", controller.Import)
-	kl04kvObjectRoutes.PATCH("/", controller.Update)
-	kl04kvObjectRoutes.DELETE("/:id", controller.Delete)
-}
-
 func InitWorkerRoutes(router *gin.RouterGroup, controller controller.IWorkerController) {
 	workerRoutes := router.Group("/worker")
 	workerRoutes.Use(
@@ -828,3 +823,323 @@ func InitOperationRoutes(router *gin.RouterGroup, controller controller.IOperati
 	operationRoutes.PATCH("/", controller.Update)
 	operationRoutes.DELETE("/:id", controller.Delete)
 }
+
+func InitOperatorErrorFoundRoutes(router *gin.RouterGroup, controller controller.IOperatorErrorFoundController) {
+	operatorErrorRoutes := router.Group("/operator-error")
+	operatorErrorRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	operatorErrorRoutes.GET("/statistics/supervisor", controller.StatisticsBySupervisor)
+	operatorErrorRoutes.GET("/statistics/team", controller.StatisticsByTeam)
+	operatorErrorRoutes.GET("/statistics/material", controller.StatisticsByMaterial)
+	operatorErrorRoutes.POST("/report", controller.Report)
+}
+
+func InitUnitRoutes(router *gin.RouterGroup, controller controller.IUnitController) {
+	unitRoutes := router.Group("/unit")
+	unitRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	unitRoutes.GET("/all", controller.GetAll)
+	unitRoutes.GET("/material/:materialID", controller.GetMaterialUnits)
+	unitRoutes.POST("/", controller.Create)
+	unitRoutes.POST("/material", controller.CreateMaterialUnit)
+	unitRoutes.PATCH("/", controller.Update)
+	unitRoutes.PATCH("/material", controller.UpdateMaterialUnit)
+	unitRoutes.DELETE("/:id", controller.Delete)
+	unitRoutes.DELETE("/material/:id", controller.DeleteMaterialUnit)
+}
+
+func InitMaterialLotRoutes(router *gin.RouterGroup, controller controller.IMaterialLotController) {
+	materialLotRoutes := router.Group("/material-lot")
+	materialLotRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	materialLotRoutes.GET("/expiring", controller.GetExpiring)
+	materialLotRoutes.GET("/expiring/report", controller.ExpiringReport)
+	materialLotRoutes.GET("/invoice/:invoiceType/:invoiceID", controller.GetByInvoice)
+}
+
+func InitCostAllocationRoutes(router *gin.RouterGroup, controller controller.ICostAllocationController) {
+	costAllocationRoutes := router.Group("/cost-allocation")
+	costAllocationRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	costAllocationRoutes.GET("/strategies", controller.GetStrategies)
+	costAllocationRoutes.GET("/strategy", controller.GetStrategy)
+	costAllocationRoutes.GET("/preview", controller.Preview)
+	costAllocationRoutes.PATCH("/strategy", controller.UpdateStrategy)
+}
+
+func InitOperationBOMRoutes(router *gin.RouterGroup, controller controller.IOperationBOMController) {
+	operationBOMRoutes := router.Group("/operation-bom")
+	operationBOMRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	operationBOMRoutes.GET("/:operationID", controller.GetByOperationID)
+	operationBOMRoutes.GET("/deviations/:invoiceObjectID", controller.GetDeviations)
+	operationBOMRoutes.POST("/", controller.Replace)
+	operationBOMRoutes.POST("/requisition", controller.Requisition)
+}
+
+func InitObjectStatusRoutes(router *gin.RouterGroup, controller controller.IObjectStatusController) {
+	objectStatusRoutes := router.Group("/object-status")
+	objectStatusRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	objectStatusRoutes.GET("/all", controller.GetAll)
+	objectStatusRoutes.GET("/:objectID/transitions", controller.GetAvailableTransitions)
+	objectStatusRoutes.GET("/:objectID/timeline", controller.GetTimeline)
+	objectStatusRoutes.POST("/", controller.Transition)
+}
+
+func InitNetworkTopologyRoutes(router *gin.RouterGroup, controller controller.INetworkTopologyController) {
+	networkTopologyRoutes := router.Group("/network-topology")
+	networkTopologyRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	networkTopologyRoutes.GET("/graph", controller.GetGraph)
+	networkTopologyRoutes.GET("/graphml", controller.ExportGraphML)
+	networkTopologyRoutes.GET("/:objectID/upstream", controller.GetUpstream)
+	networkTopologyRoutes.GET("/:objectID/downstream", controller.GetDownstream)
+	networkTopologyRoutes.POST("/connection", controller.CreateConnection)
+	networkTopologyRoutes.POST("/resolve-nourashes", controller.ResolveNourashes)
+	networkTopologyRoutes.DELETE("/connection/:id", controller.DeleteConnection)
+}
+
+func InitObjectGeolocationRoutes(router *gin.RouterGroup, controller controller.IObjectGeolocationController) {
+	objectGeolocationRoutes := router.Group("/object-geolocation")
+	objectGeolocationRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	objectGeolocationRoutes.GET("/geojson", controller.GetGeoJSON)
+	objectGeolocationRoutes.GET("/geojson/export", controller.ExportGeoJSON)
+	objectGeolocationRoutes.GET("/search", controller.Search)
+}
+
+func InitObjectTypeRoutes(router *gin.RouterGroup, controller controller.IObjectTypeController) {
+	objectTypeRoutes := router.Group("/object-type")
+	objectTypeRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	objectTypeRoutes.GET("/all", controller.GetAll)
+	objectTypeRoutes.POST("/", controller.Create)
+	objectTypeRoutes.DELETE("/:type", controller.Delete)
+	objectTypeRoutes.GET("/:type/objects/paginated", controller.GetObjectsPaginated)
+	objectTypeRoutes.GET("/:type/objects/all", controller.GetAllObjects)
+	objectTypeRoutes.GET("/:type/objects/search/object-names", controller.GetObjectNamesForSearch)
+	objectTypeRoutes.POST("/:type/objects", controller.CreateObject)
+	objectTypeRoutes.PATCH("/:type/objects", controller.UpdateObject)
+	objectTypeRoutes.DELETE("/:type/objects/:objectID", controller.DeleteObject)
+	objectTypeRoutes.GET("/:type/objects/document/template", controller.GetTemplateFile)
+	objectTypeRoutes.POST("/:type/objects/document/import", controller.Import)
+	objectTypeRoutes.GET("/:type/objects/document/export", controller.Export)
+}
+
+func InitObjectPlanRoutes(router *gin.RouterGroup, controller controller.IObjectPlanController) {
+	objectPlanRoutes := router.Group("/object-plan")
+	objectPlanRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	objectPlanRoutes.GET("/:objectID", controller.GetByObjectID)
+	objectPlanRoutes.POST("/", controller.Replace)
+	objectPlanRoutes.GET("/document/template", controller.GetTemplateFile)
+	objectPlanRoutes.POST("/document/import", controller.Import)
+	objectPlanRoutes.GET("/report", controller.GetReport)
+	objectPlanRoutes.GET("/report/export", controller.ExportReport)
+}
+
+func InitObjectProfitabilityRoutes(router *gin.RouterGroup, controller controller.IObjectProfitabilityController) {
+	objectProfitabilityRoutes := router.Group("/object-profitability")
+	objectProfitabilityRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	objectProfitabilityRoutes.GET("/summary", controller.GetSummary)
+	objectProfitabilityRoutes.GET("/export", controller.Export)
+	objectProfitabilityRoutes.GET("/:objectID/invoices", controller.GetInvoices)
+}
+
+func InitAttachmentRoutes(router *gin.RouterGroup, controller controller.IAttachmentController) {
+	attachmentRoutes := router.Group("/attachment")
+	attachmentRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	attachmentRoutes.GET("/object/:objectID", controller.GetByObject)
+	attachmentRoutes.POST("/object/:objectID", controller.UploadForObject)
+	attachmentRoutes.GET("/invoice-object/:invoiceObjectID", controller.GetByInvoiceObject)
+	attachmentRoutes.POST("/invoice-object/:invoiceObjectID", controller.UploadForInvoiceObject)
+	attachmentRoutes.GET("/:id/download", controller.Download)
+	attachmentRoutes.GET("/:id/thumbnail", controller.GetThumbnail)
+	attachmentRoutes.DELETE("/:id", controller.Delete)
+}
+
+func InitHandoverActRoutes(router *gin.RouterGroup, controller controller.IHandoverActController) {
+	handoverActRoutes := router.Group("/handover-act")
+	handoverActRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	handoverActRoutes.GET("/all", controller.GetAll)
+	handoverActRoutes.POST("/generate", controller.Generate)
+	handoverActRoutes.GET("/document/template", controller.GetTemplateFile)
+	handoverActRoutes.POST("/document/template", controller.UploadTemplate)
+	handoverActRoutes.DELETE("/document/template", controller.ResetTemplate)
+}
+
+func InitCompletedWorksRoutes(router *gin.RouterGroup, controller controller.ICompletedWorksController) {
+	completedWorksRoutes := router.Group("/completed-works")
+	completedWorksRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	completedWorksRoutes.GET("/ks2", controller.GetKS2)
+	completedWorksRoutes.GET("/ks2/export", controller.ExportKS2)
+	completedWorksRoutes.GET("/ks3", controller.GetKS3)
+	completedWorksRoutes.GET("/ks3/export", controller.ExportKS3)
+}
+
+func InitMaterialConsumptionRoutes(router *gin.RouterGroup, controller controller.IMaterialConsumptionController) {
+	materialConsumptionRoutes := router.Group("/material-consumption")
+	materialConsumptionRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	materialConsumptionRoutes.GET("/m29", controller.GetReport)
+	materialConsumptionRoutes.GET("/m29/export", controller.Export)
+}
+
+func InitTimesheetRoutes(router *gin.RouterGroup, controller controller.ITimesheetController) {
+	timesheetRoutes := router.Group("/timesheet")
+	timesheetRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	timesheetRoutes.GET("/", controller.GetTimesheet)
+	timesheetRoutes.GET("/export", controller.Export)
+	timesheetRoutes.GET("/calendar", controller.GetCalendar)
+	timesheetRoutes.POST("/calendar", controller.CreateCalendarDay)
+	timesheetRoutes.DELETE("/calendar/:id", controller.DeleteCalendarDay)
+}
+
+func InitTeamEarningsRoutes(router *gin.RouterGroup, controller controller.ITeamEarningsController) {
+	teamEarningsRoutes := router.Group("/team-earnings")
+	teamEarningsRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	teamEarningsRoutes.GET("/", controller.GetReport)
+	teamEarningsRoutes.GET("/export", controller.Export)
+	teamEarningsRoutes.POST("/adjustment", controller.CreateAdjustment)
+	teamEarningsRoutes.POST("/adjustment/losses", controller.ChargeLosses)
+	teamEarningsRoutes.DELETE("/adjustment/:id", controller.DeleteAdjustment)
+}
+
+func InitTeamMaterialAgingRoutes(router *gin.RouterGroup, controller controller.ITeamMaterialAgingController) {
+	teamMaterialAgingRoutes := router.Group("/team-materials")
+	teamMaterialAgingRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	teamMaterialAgingRoutes.GET("/aging", controller.GetReport)
+	teamMaterialAgingRoutes.GET("/aging/export", controller.Export)
+	teamMaterialAgingRoutes.GET("/statement/all", controller.GetStatements)
+	teamMaterialAgingRoutes.POST("/statement", controller.CreateStatement)
+}
+
+func InitQualificationRoutes(router *gin.RouterGroup, controller controller.IQualificationController) {
+	qualificationRoutes := router.Group("/qualification")
+	qualificationRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	qualificationRoutes.GET("/type/all", controller.GetTypes)
+	qualificationRoutes.POST("/type", controller.CreateType)
+	qualificationRoutes.PATCH("/type", controller.UpdateType)
+	qualificationRoutes.DELETE("/type/:id", controller.DeleteType)
+	qualificationRoutes.GET("/worker/:workerID", controller.GetByWorkerID)
+	qualificationRoutes.GET("/expiring", controller.GetExpiring)
+	qualificationRoutes.GET("/expiring/export", controller.ExportExpiring)
+	qualificationRoutes.POST("/", controller.Create)
+	qualificationRoutes.PATCH("/", controller.Update)
+	qualificationRoutes.DELETE("/:id", controller.Delete)
+	qualificationRoutes.POST("/:id/document", controller.UploadDocument)
+	qualificationRoutes.GET("/:id/document", controller.GetDocument)
+}
+
+func InitTeamMemberRoutes(router *gin.RouterGroup, controller controller.ITeamMemberController) {
+	teamMemberRoutes := router.Group("/team-member")
+	teamMemberRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	teamMemberRoutes.GET("/", controller.GetOnDate)
+	teamMemberRoutes.GET("/team/:teamID", controller.GetTeamHistory)
+	teamMemberRoutes.GET("/worker/:workerID", controller.GetWorkerHistory)
+	teamMemberRoutes.POST("/", controller.Add)
+	teamMemberRoutes.POST("/transfer", controller.Transfer)
+	teamMemberRoutes.PATCH("/end", controller.End)
+	teamMemberRoutes.DELETE("/:id", controller.Delete)
+}
+
+func InitKPIRoutes(router *gin.RouterGroup, controller controller.IKPIController) {
+	kpiRoutes := router.Group("/statistics/kpi")
+	kpiRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	kpiRoutes.GET("/team", controller.GetTeamKPI)
+	kpiRoutes.GET("/supervisor", controller.GetSupervisorKPI)
+}
+
+func InitScheduleRoutes(router *gin.RouterGroup, controller controller.IScheduleController) {
+	scheduleRoutes := router.Group("/schedule")
+	scheduleRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	scheduleRoutes.GET("/", controller.GetAssignments)
+	scheduleRoutes.GET("/calendar", controller.GetCalendar)
+	scheduleRoutes.GET("/conflicts", controller.GetConflicts)
+	scheduleRoutes.GET("/comparison", controller.GetComparison)
+	scheduleRoutes.GET("/work-order", controller.WorkOrder)
+	scheduleRoutes.POST("/", controller.Create)
+	scheduleRoutes.PATCH("/", controller.Update)
+	scheduleRoutes.DELETE("/:id", controller.Delete)
+}
+
+func InitSelfServiceRoutes(router *gin.RouterGroup, controller controller.ISelfServiceController) {
+	selfServiceRoutes := router.Group("/me")
+	selfServiceRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	selfServiceRoutes.GET("/profile", controller.GetProfile)
+	selfServiceRoutes.GET("/materials", controller.GetMaterials)
+	selfServiceRoutes.GET("/invoices", controller.GetInvoices)
+	selfServiceRoutes.GET("/attendance", controller.GetAttendance)
+	selfServiceRoutes.GET("/qualifications", controller.GetQualifications)
+}
+
+func InitWorkerMergeRoutes(router *gin.RouterGroup, controller controller.IWorkerMergeController) {
+	workerMergeRoutes := router.Group("/worker-merge")
+	workerMergeRoutes.Use(
+		middleware.Authentication(),
+	)
+
+	workerMergeRoutes.GET("/", controller.GetAll)
+	workerMergeRoutes.GET("/duplicates", controller.GetDuplicates)
+	workerMergeRoutes.POST("/", controller.Merge)
+	workerMergeRoutes.POST("/undo/:id", controller.Undo)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type attachmentController struct {
+	attachmentService service.IAttachmentService
+}
+
+func NewAttachmentController(attachmentService service.IAttachmentService) IAttachmentController {
+	return &attachmentController{
+		attachmentService: attachmentService,
+	}
+}
+
+type IAttachmentController interface {
+	UploadForObject(c *gin.Context)
+	UploadForInvoiceObject(c *gin.Context)
+	GetByObject(c *gin.Context)
+	GetByInvoiceObject(c *gin.Context)
+	Download(c *gin.Context)
+	GetThumbnail(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+func (controller *attachmentController) UploadForObject(c *gin.Context) {
+	controller.upload(c, "object", "objectID")
+}
+
+func (controller *attachmentController) UploadForInvoiceObject(c *gin.Context) {
+	controller.upload(c, "invoice-object", "invoiceObjectID")
+}
+
+func (controller *attachmentController) GetByObject(c *gin.Context) {
+	controller.getByTarget(c, "object", "objectID")
+}
+
+func (controller *attachmentController) GetByInvoiceObject(c *gin.Context) {
+	controller.getByTarget(c, "invoice-object", "invoiceObjectID")
+}
+
+func (controller *attachmentController) upload(c *gin.Context, attachedTo, paramName string) {
+	attachedToIDRaw := c.Param(paramName)
+	attachedToID, err := strconv.ParseUint(attachedToIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файлы не могут быть получены: %v", err))
+		return
+	}
+
+	data, err := controller.attachmentService.Upload(c.GetUint("projectID"), c.GetUint("userID"), attachedTo, uint(attachedToID), form.File["files"])
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *attachmentController) getByTarget(c *gin.Context, attachedTo, paramName string) {
+	attachedToIDRaw := c.Param(paramName)
+	attachedToID, err := strconv.ParseUint(attachedToIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.attachmentService.GetByTarget(c.GetUint("projectID"), c.GetUint("userID"), attachedTo, uint(attachedToID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *attachmentController) Download(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	file, err := controller.attachmentService.GetFile(c.GetUint("projectID"), c.GetUint("userID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	c.FileAttachment(file.FilePath, file.FileName)
+}
+
+func (controller *attachmentController) GetThumbnail(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	file, err := controller.attachmentService.GetThumbnail(c.GetUint("projectID"), c.GetUint("userID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	c.File(file.FilePath)
+}
+
+func (controller *attachmentController) Delete(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.attachmentService.Delete(c.GetUint("projectID"), c.GetUint("userID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+type completedWorksController struct {
+	completedWorksService service.ICompletedWorksService
+}
+
+func NewCompletedWorksController(completedWorksService service.ICompletedWorksService) ICompletedWorksController {
+	return &completedWorksController{
+		completedWorksService: completedWorksService,
+	}
+}
+
+type ICompletedWorksController interface {
+	GetKS2(c *gin.Context)
+	GetKS3(c *gin.Context)
+	ExportKS2(c *gin.Context)
+	ExportKS3(c *gin.Context)
+}
+
+func (controller *completedWorksController) GetKS2(c *gin.Context) {
+	filter, err := completedWorksFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.completedWorksService.GetKS2(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *completedWorksController) GetKS3(c *gin.Context) {
+	filter, err := completedWorksFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.completedWorksService.GetKS3(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *completedWorksController) ExportKS2(c *gin.Context) {
+	filter, err := completedWorksFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.completedWorksService.ExportKS2(filter, c.DefaultQuery("format", "xlsx"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *completedWorksController) ExportKS3(c *gin.Context) {
+	filter, err := completedWorksFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.completedWorksService.ExportKS3(filter, c.DefaultQuery("format", "xlsx"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func completedWorksFilterFromQuery(c *gin.Context) (dto.CompletedWorksFilter, error) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		return dto.CompletedWorksFilter{}, err
+	}
+
+	return dto.CompletedWorksFilter{
+		ProjectID: c.GetUint("projectID"),
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type costAllocationController struct {
+	costAllocationService service.ICostAllocationService
+}
+
+func NewCostAllocationController(costAllocationService service.ICostAllocationService) ICostAllocationController {
+	return &costAllocationController{
+		costAllocationService: costAllocationService,
+	}
+}
+
+type ICostAllocationController interface {
+	GetStrategies(c *gin.Context)
+	GetStrategy(c *gin.Context)
+	UpdateStrategy(c *gin.Context)
+	Preview(c *gin.Context)
+}
+
+func (controller *costAllocationController) GetStrategies(c *gin.Context) {
+	response.ResponseSuccess(c, controller.costAllocationService.GetStrategies())
+}
+
+func (controller *costAllocationController) GetStrategy(c *gin.Context) {
+	data, err := controller.costAllocationService.GetStrategy(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *costAllocationController) UpdateStrategy(c *gin.Context) {
+	var updateData dto.CostAllocationStrategyUpdate
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	if err := controller.costAllocationService.UpdateStrategy(c.GetUint("projectID"), updateData.Strategy); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "updated")
+}
+
+func (controller *costAllocationController) Preview(c *gin.Context) {
+	materialID, err := uintFromQuery(c, "materialID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	locationID, err := uintFromQuery(c, "locationID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	amount, err := strconv.ParseFloat(c.DefaultQuery("amount", "0"), 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверный параметр amount: %v", err))
+		return
+	}
+
+	locationType := c.DefaultQuery("locationType", "warehouse")
+
+	data, err := controller.costAllocationService.Preview(c.GetUint("projectID"), materialID, locationType, locationID, amount)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handoverActController struct {
+	handoverActService service.IHandoverActService
+}
+
+func NewHandoverActController(handoverActService service.IHandoverActService) IHandoverActController {
+	return &handoverActController{
+		handoverActService: handoverActService,
+	}
+}
+
+type IHandoverActController interface {
+	GetAll(c *gin.Context)
+	Generate(c *gin.Context)
+	GetTemplateFile(c *gin.Context)
+	UploadTemplate(c *gin.Context)
+	ResetTemplate(c *gin.Context)
+}
+
+func (controller *handoverActController) GetAll(c *gin.Context) {
+	data, err := controller.handoverActService.GetAll(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *handoverActController) Generate(c *gin.Context) {
+	var generateData dto.HandoverActGenerate
+	if err := c.ShouldBindJSON(&generateData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	fileName, err := controller.handoverActService.Generate(c.GetUint("projectID"), c.GetUint("userID"), generateData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *handoverActController) GetTemplateFile(c *gin.Context) {
+	templateFilePath := controller.handoverActService.GetTemplateFile(c.GetUint("projectID"))
+	c.FileAttachment(templateFilePath, "Шаблон акта приемки-передачи.xlsx")
+}
+
+func (controller *handoverActController) UploadTemplate(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файл не может быть сформирован, проверьте файл: %v", err))
+		return
+	}
+
+	date := time.Now()
+	filePath := "./pkg/excels/temp/" + date.Format("2006-01-02 15-04-05") + file.Filename
+	err = c.SaveUploadedFile(file, filePath)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файл не может быть сохранен на сервере: %v", err))
+		return
+	}
+
+	err = controller.handoverActService.UploadTemplate(c.GetUint("projectID"), filePath)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, true)
+}
+
+func (controller *handoverActController) ResetTemplate(c *gin.Context) {
+	err := controller.handoverActService.ResetTemplate(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
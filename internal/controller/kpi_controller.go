@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type kpiController struct {
+	kpiService service.IKPIService
+}
+
+func NewKPIController(kpiService service.IKPIService) IKPIController {
+	return &kpiController{
+		kpiService: kpiService,
+	}
+}
+
+type IKPIController interface {
+	GetTeamKPI(c *gin.Context)
+	GetSupervisorKPI(c *gin.Context)
+}
+
+func (controller *kpiController) GetTeamKPI(c *gin.Context) {
+	controller.getReport(c, "team", "teamID")
+}
+
+func (controller *kpiController) GetSupervisorKPI(c *gin.Context) {
+	controller.getReport(c, "supervisor", "supervisorID")
+}
+
+func (controller *kpiController) getReport(c *gin.Context, groupBy, entityParam string) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	entityID, err := uintFromQuery(c, entityParam)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.kpiService.GetReport(dto.KPIFilter{
+		ProjectID: c.GetUint("projectID"),
+		GroupBy:   groupBy,
+		EntityID:  entityID,
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+		Interval:  c.DefaultQuery("interval", "month"),
+	})
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Система не смогла собрать данные: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+type materialConsumptionController struct {
+	materialConsumptionService service.IMaterialConsumptionService
+}
+
+func NewMaterialConsumptionController(materialConsumptionService service.IMaterialConsumptionService) IMaterialConsumptionController {
+	return &materialConsumptionController{
+		materialConsumptionService: materialConsumptionService,
+	}
+}
+
+type IMaterialConsumptionController interface {
+	GetReport(c *gin.Context)
+	Export(c *gin.Context)
+}
+
+func (controller *materialConsumptionController) GetReport(c *gin.Context) {
+	filter, err := materialConsumptionFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.materialConsumptionService.GetReport(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *materialConsumptionController) Export(c *gin.Context) {
+	filter, err := materialConsumptionFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.materialConsumptionService.Export(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func materialConsumptionFilterFromQuery(c *gin.Context) (dto.MaterialConsumptionFilter, error) {
+	dateFrom, dateTo, err := monthFromQuery(c)
+	if err != nil {
+		return dto.MaterialConsumptionFilter{}, err
+	}
+
+	objectID, err := uintFromQuery(c, "objectID")
+	if err != nil {
+		return dto.MaterialConsumptionFilter{}, err
+	}
+
+	districtID, err := uintFromQuery(c, "districtID")
+	if err != nil {
+		return dto.MaterialConsumptionFilter{}, err
+	}
+
+	return dto.MaterialConsumptionFilter{
+		ProjectID:  c.GetUint("projectID"),
+		ObjectID:   objectID,
+		DistrictID: districtID,
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
+	}, nil
+}
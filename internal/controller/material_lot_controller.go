@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type materialLotController struct {
+	materialLotService service.IMaterialLotService
+}
+
+func NewMaterialLotController(materialLotService service.IMaterialLotService) IMaterialLotController {
+	return &materialLotController{
+		materialLotService: materialLotService,
+	}
+}
+
+type IMaterialLotController interface {
+	GetExpiring(c *gin.Context)
+	ExpiringReport(c *gin.Context)
+	GetByInvoice(c *gin.Context)
+}
+
+func (controller *materialLotController) expiringParameters(c *gin.Context) (int, string, uint, error) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		return 0, "", 0, fmt.Errorf("Неверный параметр days: %v", c.Query("days"))
+	}
+
+	locationID, err := uintFromQuery(c, "locationID")
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	return days, c.DefaultQuery("locationType", ""), locationID, nil
+}
+
+func (controller *materialLotController) GetExpiring(c *gin.Context) {
+	days, locationType, locationID, err := controller.expiringParameters(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.materialLotService.GetExpiring(c.GetUint("projectID"), days, locationType, locationID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *materialLotController) ExpiringReport(c *gin.Context) {
+	days, locationType, locationID, err := controller.expiringParameters(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	reportFileName, err := controller.materialLotService.ExpiringReport(c.GetUint("projectID"), days, locationType, locationID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	reportFilePath := filepath.Join("./pkg/excels/temp/", reportFileName)
+	c.FileAttachment(reportFilePath, reportFileName)
+	os.Remove(reportFilePath)
+}
+
+func (controller *materialLotController) GetByInvoice(c *gin.Context) {
+	invoiceIDRaw := c.Param("invoiceID")
+	invoiceID, err := strconv.ParseUint(invoiceIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.materialLotService.GetByInvoice(uint(invoiceID), c.Param("invoiceType"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type networkTopologyController struct {
+	networkTopologyService service.INetworkTopologyService
+}
+
+func NewNetworkTopologyController(networkTopologyService service.INetworkTopologyService) INetworkTopologyController {
+	return &networkTopologyController{
+		networkTopologyService: networkTopologyService,
+	}
+}
+
+type INetworkTopologyController interface {
+	GetGraph(c *gin.Context)
+	GetUpstream(c *gin.Context)
+	GetDownstream(c *gin.Context)
+	CreateConnection(c *gin.Context)
+	DeleteConnection(c *gin.Context)
+	ResolveNourashes(c *gin.Context)
+	ExportGraphML(c *gin.Context)
+}
+
+func (controller *networkTopologyController) GetGraph(c *gin.Context) {
+	data, err := controller.networkTopologyService.GetGraph(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *networkTopologyController) GetUpstream(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.networkTopologyService.GetUpstream(c.GetUint("projectID"), uint(objectID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *networkTopologyController) GetDownstream(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.networkTopologyService.GetDownstream(c.GetUint("projectID"), uint(objectID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *networkTopologyController) CreateConnection(c *gin.Context) {
+	var createData dto.NetworkConnectionCreate
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data, err := controller.networkTopologyService.CreateConnection(c.GetUint("projectID"), createData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *networkTopologyController) DeleteConnection(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.networkTopologyService.DeleteConnection(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *networkTopologyController) ResolveNourashes(c *gin.Context) {
+	data, err := controller.networkTopologyService.ResolveNourashes(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *networkTopologyController) ExportGraphML(c *gin.Context) {
+	fileName, err := controller.networkTopologyService.ExportGraphML(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
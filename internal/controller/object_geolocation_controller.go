@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+type objectGeolocationController struct {
+	objectGeolocationService service.IObjectGeolocationService
+}
+
+func NewObjectGeolocationController(objectGeolocationService service.IObjectGeolocationService) IObjectGeolocationController {
+	return &objectGeolocationController{
+		objectGeolocationService: objectGeolocationService,
+	}
+}
+
+type IObjectGeolocationController interface {
+	GetGeoJSON(c *gin.Context)
+	ExportGeoJSON(c *gin.Context)
+	Search(c *gin.Context)
+}
+
+func (controller *objectGeolocationController) GetGeoJSON(c *gin.Context) {
+	data, err := controller.objectGeolocationService.GetGeoJSON(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectGeolocationController) ExportGeoJSON(c *gin.Context) {
+	fileName, err := controller.objectGeolocationService.ExportGeoJSON(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *objectGeolocationController) Search(c *gin.Context) {
+	filter := dto.ObjectGeolocationSearch{}
+	for name, destination := range map[string]*float64{
+		"latitude":     &filter.Latitude,
+		"longitude":    &filter.Longitude,
+		"radius":       &filter.Radius,
+		"minLatitude":  &filter.MinLatitude,
+		"minLongitude": &filter.MinLongitude,
+		"maxLatitude":  &filter.MaxLatitude,
+		"maxLongitude": &filter.MaxLongitude,
+	} {
+		value, err := floatFromQuery(c, name)
+		if err != nil {
+			response.ResponseError(c, err.Error())
+			return
+		}
+
+		*destination = value
+	}
+
+	data, err := controller.objectGeolocationService.Search(c.GetUint("projectID"), filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
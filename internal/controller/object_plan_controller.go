@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type objectPlanController struct {
+	objectPlanService service.IObjectPlanService
+}
+
+func NewObjectPlanController(objectPlanService service.IObjectPlanService) IObjectPlanController {
+	return &objectPlanController{
+		objectPlanService: objectPlanService,
+	}
+}
+
+type IObjectPlanController interface {
+	GetByObjectID(c *gin.Context)
+	Replace(c *gin.Context)
+	GetTemplateFile(c *gin.Context)
+	Import(c *gin.Context)
+	GetReport(c *gin.Context)
+	ExportReport(c *gin.Context)
+}
+
+func (controller *objectPlanController) GetByObjectID(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.objectPlanService.GetByObjectID(c.GetUint("projectID"), uint(objectID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectPlanController) Replace(c *gin.Context) {
+	var replaceData dto.ObjectPlanReplace
+	if err := c.ShouldBindJSON(&replaceData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	err := controller.objectPlanService.Replace(c.GetUint("projectID"), replaceData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "updated")
+}
+
+func (controller *objectPlanController) GetTemplateFile(c *gin.Context) {
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "Шаблон для импорта плана объектов.xlsx")
+	c.FileAttachment(templateFilePath, "Шаблон для импорта плана объектов.xlsx")
+}
+
+func (controller *objectPlanController) Import(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файл не может быть сформирован, проверьте файл: %v", err))
+		return
+	}
+
+	date := time.Now()
+	filePath := "./pkg/excels/temp/" + date.Format("2006-01-02 15-04-05") + file.Filename
+	err = c.SaveUploadedFile(file, filePath)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файл не может быть сохранен на сервере: %v", err))
+		return
+	}
+
+	err = controller.objectPlanService.Import(c.GetUint("projectID"), filePath)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, true)
+}
+
+func (controller *objectPlanController) GetReport(c *gin.Context) {
+	objectID, err := uintFromQuery(c, "objectID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.objectPlanService.GetReport(c.GetUint("projectID"), objectID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectPlanController) ExportReport(c *gin.Context) {
+	objectID, err := uintFromQuery(c, "objectID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.objectPlanService.ExportReport(c.GetUint("projectID"), objectID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
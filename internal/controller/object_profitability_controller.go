@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type objectProfitabilityController struct {
+	objectProfitabilityService service.IObjectProfitabilityService
+}
+
+func NewObjectProfitabilityController(objectProfitabilityService service.IObjectProfitabilityService) IObjectProfitabilityController {
+	return &objectProfitabilityController{
+		objectProfitabilityService: objectProfitabilityService,
+	}
+}
+
+type IObjectProfitabilityController interface {
+	GetSummary(c *gin.Context)
+	GetInvoices(c *gin.Context)
+	Export(c *gin.Context)
+}
+
+func (controller *objectProfitabilityController) GetSummary(c *gin.Context) {
+	filter, err := objectProfitabilityFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.objectProfitabilityService.GetSummary(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectProfitabilityController) GetInvoices(c *gin.Context) {
+	filter, err := objectProfitabilityFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+	filter.ObjectID = uint(objectID)
+
+	data, err := controller.objectProfitabilityService.GetInvoices(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectProfitabilityController) Export(c *gin.Context) {
+	filter, err := objectProfitabilityFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.objectProfitabilityService.Export(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func objectProfitabilityFilterFromQuery(c *gin.Context) (dto.ObjectProfitabilityFilter, error) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		return dto.ObjectProfitabilityFilter{}, err
+	}
+
+	objectID, err := uintFromQuery(c, "objectID")
+	if err != nil {
+		return dto.ObjectProfitabilityFilter{}, err
+	}
+
+	return dto.ObjectProfitabilityFilter{
+		ProjectID: c.GetUint("projectID"),
+		ObjectID:  objectID,
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+	}, nil
+}
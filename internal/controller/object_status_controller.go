@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type objectStatusController struct {
+	objectStatusService service.IObjectStatusService
+}
+
+func NewObjectStatusController(objectStatusService service.IObjectStatusService) IObjectStatusController {
+	return &objectStatusController{
+		objectStatusService: objectStatusService,
+	}
+}
+
+type IObjectStatusController interface {
+	GetAll(c *gin.Context)
+	GetAvailableTransitions(c *gin.Context)
+	GetTimeline(c *gin.Context)
+	Transition(c *gin.Context)
+}
+
+func (controller *objectStatusController) GetAll(c *gin.Context) {
+	response.ResponseSuccess(c, controller.objectStatusService.GetAll())
+}
+
+func (controller *objectStatusController) GetAvailableTransitions(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.objectStatusService.GetAvailableTransitions(uint(objectID), c.GetUint("roleID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectStatusController) GetTimeline(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.objectStatusService.GetTimeline(uint(objectID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *objectStatusController) Transition(c *gin.Context) {
+	var transitionData dto.ObjectStatusTransitionCreate
+	if err := c.ShouldBindJSON(&transitionData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	err := controller.objectStatusService.Transition(c.GetUint("projectID"), c.GetUint("userID"), c.GetUint("roleID"), transitionData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "updated")
+}
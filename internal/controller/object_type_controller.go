@@ -13,42 +13,68 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-type substationObjectController struct {
-	substationObjectService service.ISubstationObjectService
+type objectTypeController struct {
+	objectTypeService service.IObjectTypeService
 }
 
-func InitSubstationObjectController(
-	substationObjectService service.ISubstationObjectService,
-) ISubstationObjectController {
-	return &substationObjectController{
-		substationObjectService: substationObjectService,
+func NewObjectTypeController(objectTypeService service.IObjectTypeService) IObjectTypeController {
+	return &objectTypeController{
+		objectTypeService: objectTypeService,
 	}
 }
 
-type ISubstationObjectController interface {
-	GetPaginated(c *gin.Context)
+type IObjectTypeController interface {
+	GetAll(c *gin.Context)
 	Create(c *gin.Context)
-	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	GetObjectsPaginated(c *gin.Context)
+	GetAllObjects(c *gin.Context)
+	GetObjectNamesForSearch(c *gin.Context)
+	CreateObject(c *gin.Context)
+	UpdateObject(c *gin.Context)
+	DeleteObject(c *gin.Context)
 	GetTemplateFile(c *gin.Context)
 	Import(c *gin.Context)
-	GetObjectNamesForSearch(c *gin.Context)
 	Export(c *gin.Context)
-	GetAll(c *gin.Context)
 }
 
-func (controller *substationObjectController) GetAll(c *gin.Context) {
-	data, err := controller.substationObjectService.GetAll(c.GetUint("projectID"))
+func (controller *objectTypeController) GetAll(c *gin.Context) {
+	data, err := controller.objectTypeService.GetAll()
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-  response.ResponseSuccess(c, data)
+	response.ResponseSuccess(c, data)
 }
 
-func (controller *substationObjectController) GetPaginated(c *gin.Context) {
+func (controller *objectTypeController) Create(c *gin.Context) {
+	var createData dto.ObjectTypeCreate
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data, err := controller.objectTypeService.Create(c.GetUint("roleID"), createData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
 
+func (controller *objectTypeController) Delete(c *gin.Context) {
+	err := controller.objectTypeService.Delete(c.GetUint("roleID"), c.Param("type"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *objectTypeController) GetObjectsPaginated(c *gin.Context) {
 	pageStr := c.DefaultQuery("page", "1")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page <= 0 {
@@ -77,20 +103,29 @@ func (controller *substationObjectController) GetPaginated(c *gin.Context) {
 		return
 	}
 
-	filter := dto.SubstationObjectSearchParameters{
+	fedByObjectIDStr := c.DefaultQuery("fedByObjectID", "0")
+	fedByObjectID, err := strconv.Atoi(fedByObjectIDStr)
+	if err != nil || fedByObjectID < 0 {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса fedByObjectID: %v", err))
+		return
+	}
+
+	filter := dto.TypedObjectSearchParameters{
 		ProjectID:          c.GetUint("projectID"),
-		TeamID:             uint(teamID),
-		SupervisorWorkerID: uint(supervisorWorkerID),
+		Type:               c.Param("type"),
 		ObjectName:         c.DefaultQuery("objectName", ""),
+		SupervisorWorkerID: uint(supervisorWorkerID),
+		TeamID:             uint(teamID),
+		FedByObjectID:      uint(fedByObjectID),
 	}
 
-	data, err := controller.substationObjectService.GetPaginated(page, limit, filter)
+	data, err := controller.objectTypeService.GetObjectsPaginated(page, limit, filter)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-	dataCount, err := controller.substationObjectService.Count(filter)
+	dataCount, err := controller.objectTypeService.CountObjects(filter)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
@@ -99,17 +134,18 @@ func (controller *substationObjectController) GetPaginated(c *gin.Context) {
 	response.ResponsePaginatedData(c, data, dataCount)
 }
 
-func (controller *substationObjectController) Create(c *gin.Context) {
-	var createData dto.SubstationObjectCreate
-	if err := c.ShouldBindJSON(&createData); err != nil {
-		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+func (controller *objectTypeController) GetAllObjects(c *gin.Context) {
+	data, err := controller.objectTypeService.GetAllObjects(c.GetUint("projectID"), c.Param("type"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-	projectID := c.GetUint("projectID")
-	createData.BaseInfo.ProjectID = projectID
+	response.ResponseSuccess(c, data)
+}
 
-	data, err := controller.substationObjectService.Create(createData)
+func (controller *objectTypeController) GetObjectNamesForSearch(c *gin.Context) {
+	data, err := controller.objectTypeService.GetObjectNamesForSearch(c.GetUint("projectID"), c.Param("type"))
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
@@ -118,17 +154,14 @@ func (controller *substationObjectController) Create(c *gin.Context) {
 	response.ResponseSuccess(c, data)
 }
 
-func (controller *substationObjectController) Update(c *gin.Context) {
-	var updateData dto.SubstationObjectCreate
-	if err := c.ShouldBindJSON(&updateData); err != nil {
+func (controller *objectTypeController) CreateObject(c *gin.Context) {
+	var createData dto.TypedObjectCreate
+	if err := c.ShouldBindJSON(&createData); err != nil {
 		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
 		return
 	}
 
-	projectID := c.GetUint("projectID")
-	updateData.BaseInfo.ProjectID = projectID
-
-	data, err := controller.substationObjectService.Update(updateData)
+	data, err := controller.objectTypeService.CreateObject(c.GetUint("projectID"), c.Param("type"), createData)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
@@ -137,42 +170,51 @@ func (controller *substationObjectController) Update(c *gin.Context) {
 	response.ResponseSuccess(c, data)
 }
 
-func (controller *substationObjectController) Delete(c *gin.Context) {
-	idRaw := c.Param("id")
-	id, err := strconv.ParseUint(idRaw, 10, 64)
-	if err != nil {
-		response.ResponseError(c, fmt.Sprintf("Неправильный параметр в запросе: %v", err))
+func (controller *objectTypeController) UpdateObject(c *gin.Context) {
+	var updateData dto.TypedObjectCreate
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
 		return
 	}
 
-	projectID := c.GetUint("projectID")
-
-	err = controller.substationObjectService.Delete(uint(id), projectID)
+	data, err := controller.objectTypeService.UpdateObject(c.GetUint("projectID"), c.Param("type"), updateData)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-	response.ResponseSuccess(c, true)
+	response.ResponseSuccess(c, data)
 }
 
-func (controller *substationObjectController) GetTemplateFile(c *gin.Context) {
-	templateFilePath := filepath.Join("./pkg/excels/templates/", "Шаблон для импорта Подстанции.xlsx")
+func (controller *objectTypeController) DeleteObject(c *gin.Context) {
+	objectIDRaw := c.Param("objectID")
+	objectID, err := strconv.ParseUint(objectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
 
-	tmpFilePath, err := controller.substationObjectService.TemplateFile(templateFilePath, c.GetUint("projectID"))
+	err = controller.objectTypeService.DeleteObject(c.GetUint("projectID"), c.Param("type"), uint(objectID))
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-	c.FileAttachment(tmpFilePath, "Шаблон для импорта Подстанции.xlsx")
-	if err := os.Remove(tmpFilePath); err != nil {
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *objectTypeController) GetTemplateFile(c *gin.Context) {
+	tmpFilePath, err := controller.objectTypeService.TemplateFile(c.GetUint("projectID"), c.Param("type"))
+	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
+
+	c.FileAttachment(tmpFilePath, filepath.Base(tmpFilePath))
+	os.Remove(tmpFilePath)
 }
 
-func (controller *substationObjectController) Import(c *gin.Context) {
+func (controller *objectTypeController) Import(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Файл не может быть сформирован, проверьте файл: %v", err))
@@ -187,8 +229,7 @@ func (controller *substationObjectController) Import(c *gin.Context) {
 		return
 	}
 
-	projectID := c.GetUint("projectID")
-	err = controller.substationObjectService.Import(projectID, filePath)
+	err = controller.objectTypeService.Import(c.GetUint("projectID"), c.Param("type"), filePath)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
@@ -197,20 +238,8 @@ func (controller *substationObjectController) Import(c *gin.Context) {
 	response.ResponseSuccess(c, true)
 }
 
-func (controller *substationObjectController) GetObjectNamesForSearch(c *gin.Context) {
-	data, err := controller.substationObjectService.GetObjectNamesForSearch(c.GetUint("projectID"))
-	if err != nil {
-		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
-		return
-	}
-
-	response.ResponseSuccess(c, data)
-}
-
-func (controller *substationObjectController) Export(c *gin.Context) {
-	projectID := c.GetUint("projectID")
-
-	exportFileName, err := controller.substationObjectService.Export(projectID)
+func (controller *objectTypeController) Export(c *gin.Context) {
+	exportFileName, err := controller.objectTypeService.Export(c.GetUint("projectID"), c.Param("type"))
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
@@ -218,8 +247,5 @@ func (controller *substationObjectController) Export(c *gin.Context) {
 
 	exportFilePath := filepath.Join("./pkg/excels/temp/", exportFileName)
 	c.FileAttachment(exportFilePath, exportFileName)
-	if err := os.Remove(exportFilePath); err != nil {
-		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
-		return
-	}
+	os.Remove(exportFilePath)
 }
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type operationBOMController struct {
+	operationBOMService service.IOperationBOMService
+}
+
+func NewOperationBOMController(operationBOMService service.IOperationBOMService) IOperationBOMController {
+	return &operationBOMController{
+		operationBOMService: operationBOMService,
+	}
+}
+
+type IOperationBOMController interface {
+	GetByOperationID(c *gin.Context)
+	Replace(c *gin.Context)
+	Requisition(c *gin.Context)
+	GetDeviations(c *gin.Context)
+}
+
+func (controller *operationBOMController) GetByOperationID(c *gin.Context) {
+	operationIDRaw := c.Param("operationID")
+	operationID, err := strconv.ParseUint(operationIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.operationBOMService.GetByOperationID(uint(operationID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *operationBOMController) Replace(c *gin.Context) {
+	var replaceData dto.OperationBOMReplace
+	if err := c.ShouldBindJSON(&replaceData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	err := controller.operationBOMService.Replace(c.GetUint("projectID"), replaceData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, true)
+}
+
+func (controller *operationBOMController) Requisition(c *gin.Context) {
+	var requisitionData dto.OperationBOMRequisitionRequest
+	if err := c.ShouldBindJSON(&requisitionData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data, err := controller.operationBOMService.Requisition(c.GetUint("projectID"), requisitionData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *operationBOMController) GetDeviations(c *gin.Context) {
+	invoiceObjectIDRaw := c.Param("invoiceObjectID")
+	invoiceObjectID, err := strconv.ParseUint(invoiceObjectIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.operationBOMService.GetDeviations(uint(invoiceObjectID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+type operatorErrorFoundController struct {
+	operatorErrorFoundService service.IOperatorErrorFoundService
+}
+
+func NewOperatorErrorFoundController(
+	operatorErrorFoundService service.IOperatorErrorFoundService,
+) IOperatorErrorFoundController {
+	return &operatorErrorFoundController{
+		operatorErrorFoundService: operatorErrorFoundService,
+	}
+}
+
+type IOperatorErrorFoundController interface {
+	Report(c *gin.Context)
+	StatisticsBySupervisor(c *gin.Context)
+	StatisticsByTeam(c *gin.Context)
+	StatisticsByMaterial(c *gin.Context)
+}
+
+func (controller *operatorErrorFoundController) Report(c *gin.Context) {
+	var filter dto.OperatorErrorFoundFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	filter.ProjectID = c.GetUint("projectID")
+
+	reportFileName, err := controller.operatorErrorFoundService.Report(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	reportFilePath := filepath.Join("./pkg/excels/temp/", reportFileName)
+	c.FileAttachment(reportFilePath, reportFileName)
+	os.Remove(reportFilePath)
+}
+
+func (controller *operatorErrorFoundController) statisticsFilter(c *gin.Context) (dto.OperatorErrorFoundFilter, error) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		return dto.OperatorErrorFoundFilter{}, err
+	}
+
+	return dto.OperatorErrorFoundFilter{
+		ProjectID: c.GetUint("projectID"),
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+	}, nil
+}
+
+func (controller *operatorErrorFoundController) StatisticsBySupervisor(c *gin.Context) {
+	filter, err := controller.statisticsFilter(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.operatorErrorFoundService.StatisticsBySupervisor(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Система не смогла собрать данные: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *operatorErrorFoundController) StatisticsByTeam(c *gin.Context) {
+	filter, err := controller.statisticsFilter(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.operatorErrorFoundService.StatisticsByTeam(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Система не смогла собрать данные: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *operatorErrorFoundController) StatisticsByMaterial(c *gin.Context) {
+	filter, err := controller.statisticsFilter(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.operatorErrorFoundService.StatisticsByMaterial(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Система не смогла собрать данные: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"backend-v2/internal/service"
+	"backend-v2/model"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type qualificationController struct {
+	qualificationService service.IQualificationService
+}
+
+func NewQualificationController(qualificationService service.IQualificationService) IQualificationController {
+	return &qualificationController{
+		qualificationService: qualificationService,
+	}
+}
+
+type IQualificationController interface {
+	GetTypes(c *gin.Context)
+	CreateType(c *gin.Context)
+	UpdateType(c *gin.Context)
+	DeleteType(c *gin.Context)
+	GetByWorkerID(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	UploadDocument(c *gin.Context)
+	GetDocument(c *gin.Context)
+	GetExpiring(c *gin.Context)
+	ExportExpiring(c *gin.Context)
+}
+
+func (controller *qualificationController) GetTypes(c *gin.Context) {
+	data, err := controller.qualificationService.GetTypes(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *qualificationController) CreateType(c *gin.Context) {
+	var data model.QualificationType
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.qualificationService.CreateType(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *qualificationController) UpdateType(c *gin.Context) {
+	var data model.QualificationType
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.qualificationService.UpdateType(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *qualificationController) DeleteType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.qualificationService.DeleteType(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *qualificationController) GetByWorkerID(c *gin.Context) {
+	workerID, err := strconv.ParseUint(c.Param("workerID"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.qualificationService.GetByWorkerID(c.GetUint("projectID"), uint(workerID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *qualificationController) Create(c *gin.Context) {
+	var data model.WorkerQualification
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.qualificationService.Create(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *qualificationController) Update(c *gin.Context) {
+	var data model.WorkerQualification
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.qualificationService.Update(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *qualificationController) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.qualificationService.Delete(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *qualificationController) UploadDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Файл не может быть получен: %v", err))
+		return
+	}
+
+	result, err := controller.qualificationService.UploadDocument(c.GetUint("projectID"), uint(id), file)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *qualificationController) GetDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	file, err := controller.qualificationService.GetDocument(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	c.FileAttachment(file.FilePath, file.FileName)
+}
+
+func (controller *qualificationController) GetExpiring(c *gin.Context) {
+	days, err := uintFromQuery(c, "days")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.qualificationService.GetExpiring(c.GetUint("projectID"), int(days))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *qualificationController) ExportExpiring(c *gin.Context) {
+	days, err := uintFromQuery(c, "days")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.qualificationService.ExportExpiring(c.GetUint("projectID"), int(days))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const queryDateLayout = "2006-01-02"
+
+// Reads optional dateFrom and dateTo query parameters in the YYYY-MM-DD format.
+// Missing parameters are returned as zero time which means "no limit"
+func dateRangeFromQuery(c *gin.Context) (time.Time, time.Time, error) {
+	var dateFrom, dateTo time.Time
+	var err error
+
+	if dateFromStr := c.DefaultQuery("dateFrom", ""); dateFromStr != "" {
+		dateFrom, err = time.Parse(queryDateLayout, dateFromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Неверный параметр dateFrom: %v", err)
+		}
+	}
+
+	if dateToStr := c.DefaultQuery("dateTo", ""); dateToStr != "" {
+		dateTo, err = time.Parse(queryDateLayout, dateToStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Неверный параметр dateTo: %v", err)
+		}
+		dateTo = dateTo.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return dateFrom, dateTo, nil
+}
+
+// Reads optional date query parameter in the YYYY-MM-DD format, missing parameter is returned as zero time
+func dateFromQuery(c *gin.Context, name string) (time.Time, error) {
+	valueStr := c.DefaultQuery(name, "")
+	if valueStr == "" {
+		return time.Time{}, nil
+	}
+
+	value, err := time.Parse(queryDateLayout, valueStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Неверный параметр %s: %v", name, err)
+	}
+
+	return value, nil
+}
+
+// Reads optional unsigned integer query parameter, missing parameter is returned as 0
+func uintFromQuery(c *gin.Context, name string) (uint, error) {
+	valueStr := c.DefaultQuery(name, "0")
+	value, err := strconv.ParseUint(valueStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Неверный параметр %s: %v", name, err)
+	}
+
+	return uint(value), nil
+}
+
+// Reads optional float query parameter, missing parameter is returned as 0
+func floatFromQuery(c *gin.Context, name string) (float64, error) {
+	valueStr := c.DefaultQuery(name, "0")
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Неверный параметр %s: %v", name, err)
+	}
+
+	return value, nil
+}
+
+// Reads required month query parameter in the YYYY-MM format and returns
+// the first and the last moments of that month
+func monthFromQuery(c *gin.Context) (time.Time, time.Time, error) {
+	month, err := time.Parse("2006-01", c.DefaultQuery("month", ""))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Неверный параметр month: %v", err)
+	}
+
+	return month, month.AddDate(0, 1, 0).Add(-time.Nanosecond), nil
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scheduleController struct {
+	scheduleService service.IScheduleService
+}
+
+func NewScheduleController(scheduleService service.IScheduleService) IScheduleController {
+	return &scheduleController{
+		scheduleService: scheduleService,
+	}
+}
+
+type IScheduleController interface {
+	GetAssignments(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetCalendar(c *gin.Context)
+	GetConflicts(c *gin.Context)
+	GetComparison(c *gin.Context)
+	WorkOrder(c *gin.Context)
+}
+
+func (controller *scheduleController) GetAssignments(c *gin.Context) {
+	filter, err := scheduleFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.scheduleService.GetAssignments(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *scheduleController) Create(c *gin.Context) {
+	var data dto.TeamAssignmentMutation
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.scheduleService.Create(c.GetUint("projectID"), c.GetUint("userID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *scheduleController) Update(c *gin.Context) {
+	var data dto.TeamAssignmentMutation
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.scheduleService.Update(c.GetUint("projectID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *scheduleController) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.scheduleService.Delete(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *scheduleController) GetCalendar(c *gin.Context) {
+	filter, err := scheduleFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.scheduleService.GetCalendar(filter, c.DefaultQuery("groupBy", "team"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *scheduleController) GetConflicts(c *gin.Context) {
+	filter, err := scheduleFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.scheduleService.GetConflicts(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *scheduleController) GetComparison(c *gin.Context) {
+	filter, err := scheduleFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.scheduleService.GetComparison(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *scheduleController) WorkOrder(c *gin.Context) {
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	date, err := dateFromQuery(c, "date")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.scheduleService.WorkOrder(c.GetUint("projectID"), teamID, date, c.DefaultQuery("format", "pdf"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func scheduleFilterFromQuery(c *gin.Context) (dto.ScheduleFilter, error) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		return dto.ScheduleFilter{}, err
+	}
+
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		return dto.ScheduleFilter{}, err
+	}
+
+	objectID, err := uintFromQuery(c, "objectID")
+	if err != nil {
+		return dto.ScheduleFilter{}, err
+	}
+
+	districtID, err := uintFromQuery(c, "districtID")
+	if err != nil {
+		return dto.ScheduleFilter{}, err
+	}
+
+	return dto.ScheduleFilter{
+		ProjectID:  c.GetUint("projectID"),
+		TeamID:     teamID,
+		ObjectID:   objectID,
+		DistrictID: districtID,
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type selfServiceController struct {
+	selfServiceService service.ISelfServiceService
+}
+
+func NewSelfServiceController(selfServiceService service.ISelfServiceService) ISelfServiceController {
+	return &selfServiceController{
+		selfServiceService: selfServiceService,
+	}
+}
+
+type ISelfServiceController interface {
+	GetProfile(c *gin.Context)
+	GetMaterials(c *gin.Context)
+	GetInvoices(c *gin.Context)
+	GetAttendance(c *gin.Context)
+	GetQualifications(c *gin.Context)
+}
+
+func (controller *selfServiceController) GetProfile(c *gin.Context) {
+	data, err := controller.selfServiceService.GetProfile(c.GetUint("projectID"), c.GetUint("workerID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *selfServiceController) GetMaterials(c *gin.Context) {
+	data, err := controller.selfServiceService.GetMaterials(c.GetUint("projectID"), c.GetUint("workerID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *selfServiceController) GetInvoices(c *gin.Context) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.selfServiceService.GetInvoices(c.GetUint("projectID"), c.GetUint("workerID"), dateFrom, dateTo)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *selfServiceController) GetAttendance(c *gin.Context) {
+	dateFrom, dateTo, err := monthFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.selfServiceService.GetAttendance(c.GetUint("projectID"), c.GetUint("workerID"), dateFrom, dateTo)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *selfServiceController) GetQualifications(c *gin.Context) {
+	days, err := uintFromQuery(c, "days")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.selfServiceService.GetQualifications(c.GetUint("projectID"), c.GetUint("workerID"), int(days))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type teamEarningsController struct {
+	teamEarningsService service.ITeamEarningsService
+}
+
+func NewTeamEarningsController(teamEarningsService service.ITeamEarningsService) ITeamEarningsController {
+	return &teamEarningsController{
+		teamEarningsService: teamEarningsService,
+	}
+}
+
+type ITeamEarningsController interface {
+	GetReport(c *gin.Context)
+	Export(c *gin.Context)
+	CreateAdjustment(c *gin.Context)
+	ChargeLosses(c *gin.Context)
+	DeleteAdjustment(c *gin.Context)
+}
+
+func (controller *teamEarningsController) GetReport(c *gin.Context) {
+	filter, err := teamEarningsFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.teamEarningsService.GetReport(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamEarningsController) Export(c *gin.Context) {
+	filter, err := teamEarningsFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.teamEarningsService.Export(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *teamEarningsController) CreateAdjustment(c *gin.Context) {
+	var data dto.TeamEarningsAdjustmentCreate
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.teamEarningsService.CreateAdjustment(c.GetUint("projectID"), c.GetUint("userID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *teamEarningsController) ChargeLosses(c *gin.Context) {
+	filter, err := teamEarningsFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	result, err := controller.teamEarningsService.ChargeLosses(c.GetUint("projectID"), c.GetUint("userID"), filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *teamEarningsController) DeleteAdjustment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.teamEarningsService.DeleteAdjustment(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func teamEarningsFilterFromQuery(c *gin.Context) (dto.TeamEarningsFilter, error) {
+	dateFrom, dateTo, err := dateRangeFromQuery(c)
+	if err != nil {
+		return dto.TeamEarningsFilter{}, err
+	}
+
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		return dto.TeamEarningsFilter{}, err
+	}
+
+	return dto.TeamEarningsFilter{
+		ProjectID: c.GetUint("projectID"),
+		TeamID:    teamID,
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+		SplitMode: c.DefaultQuery("splitMode", "equal"),
+	}, nil
+}
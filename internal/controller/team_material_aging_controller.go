@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+type teamMaterialAgingController struct {
+	teamMaterialAgingService service.ITeamMaterialAgingService
+}
+
+func NewTeamMaterialAgingController(teamMaterialAgingService service.ITeamMaterialAgingService) ITeamMaterialAgingController {
+	return &teamMaterialAgingController{
+		teamMaterialAgingService: teamMaterialAgingService,
+	}
+}
+
+type ITeamMaterialAgingController interface {
+	GetReport(c *gin.Context)
+	Export(c *gin.Context)
+	GetStatements(c *gin.Context)
+	CreateStatement(c *gin.Context)
+}
+
+func (controller *teamMaterialAgingController) GetReport(c *gin.Context) {
+	filter, err := teamMaterialAgingFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.teamMaterialAgingService.GetReport(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamMaterialAgingController) Export(c *gin.Context) {
+	filter, err := teamMaterialAgingFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.teamMaterialAgingService.Export(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *teamMaterialAgingController) GetStatements(c *gin.Context) {
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.teamMaterialAgingService.GetStatements(c.GetUint("projectID"), teamID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamMaterialAgingController) CreateStatement(c *gin.Context) {
+	var data dto.TeamAccountabilityStatementCreate
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	fileName, err := controller.teamMaterialAgingService.CreateStatement(c.GetUint("projectID"), c.GetUint("userID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func teamMaterialAgingFilterFromQuery(c *gin.Context) (dto.TeamMaterialAgingFilter, error) {
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		return dto.TeamMaterialAgingFilter{}, err
+	}
+
+	days, err := uintFromQuery(c, "days")
+	if err != nil {
+		return dto.TeamMaterialAgingFilter{}, err
+	}
+
+	return dto.TeamMaterialAgingFilter{
+		ProjectID: c.GetUint("projectID"),
+		TeamID:    teamID,
+		Days:      int(days),
+	}, nil
+}
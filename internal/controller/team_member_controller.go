@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type teamMemberController struct {
+	teamMemberService service.ITeamMemberService
+}
+
+func NewTeamMemberController(teamMemberService service.ITeamMemberService) ITeamMemberController {
+	return &teamMemberController{
+		teamMemberService: teamMemberService,
+	}
+}
+
+type ITeamMemberController interface {
+	GetOnDate(c *gin.Context)
+	GetTeamHistory(c *gin.Context)
+	GetWorkerHistory(c *gin.Context)
+	Add(c *gin.Context)
+	Transfer(c *gin.Context)
+	End(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+func (controller *teamMemberController) GetOnDate(c *gin.Context) {
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	date, err := dateFromQuery(c, "date")
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.teamMemberService.GetOnDate(c.GetUint("projectID"), teamID, date)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamMemberController) GetTeamHistory(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.teamMemberService.GetTeamHistory(c.GetUint("projectID"), uint(teamID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamMemberController) GetWorkerHistory(c *gin.Context) {
+	workerID, err := strconv.ParseUint(c.Param("workerID"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.teamMemberService.GetWorkerHistory(c.GetUint("projectID"), uint(workerID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *teamMemberController) Add(c *gin.Context) {
+	var data dto.TeamMemberAdd
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.teamMemberService.Add(c.GetUint("projectID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *teamMemberController) Transfer(c *gin.Context) {
+	var data dto.TeamMemberTransfer
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.teamMemberService.Transfer(c.GetUint("projectID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *teamMemberController) End(c *gin.Context) {
+	var data dto.TeamMemberEnd
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.teamMemberService.End(c.GetUint("projectID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *teamMemberController) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.teamMemberService.Delete(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
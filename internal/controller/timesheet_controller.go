@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/model"
+	"backend-v2/pkg/response"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type timesheetController struct {
+	timesheetService service.ITimesheetService
+}
+
+func NewTimesheetController(timesheetService service.ITimesheetService) ITimesheetController {
+	return &timesheetController{
+		timesheetService: timesheetService,
+	}
+}
+
+type ITimesheetController interface {
+	GetTimesheet(c *gin.Context)
+	Export(c *gin.Context)
+	GetCalendar(c *gin.Context)
+	CreateCalendarDay(c *gin.Context)
+	DeleteCalendarDay(c *gin.Context)
+}
+
+func (controller *timesheetController) GetTimesheet(c *gin.Context) {
+	filter, err := timesheetFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	data, err := controller.timesheetService.GetTimesheet(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *timesheetController) Export(c *gin.Context) {
+	filter, err := timesheetFilterFromQuery(c)
+	if err != nil {
+		response.ResponseError(c, err.Error())
+		return
+	}
+
+	fileName, err := controller.timesheetService.Export(filter)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	c.FileAttachment(filePath, fileName)
+	os.Remove(filePath)
+}
+
+func (controller *timesheetController) GetCalendar(c *gin.Context) {
+	year, err := strconv.Atoi(c.DefaultQuery("year", fmt.Sprint(time.Now().Year())))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.timesheetService.GetCalendar(c.GetUint("projectID"), year)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *timesheetController) CreateCalendarDay(c *gin.Context) {
+	var data model.ProjectCalendarDay
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ID = 0
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.timesheetService.CreateCalendarDay(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *timesheetController) DeleteCalendarDay(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	err = controller.timesheetService.DeleteCalendarDay(c.GetUint("projectID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func timesheetFilterFromQuery(c *gin.Context) (dto.TimesheetFilter, error) {
+	dateFrom, dateTo, err := monthFromQuery(c)
+	if err != nil {
+		return dto.TimesheetFilter{}, err
+	}
+
+	teamID, err := uintFromQuery(c, "teamID")
+	if err != nil {
+		return dto.TimesheetFilter{}, err
+	}
+
+	shiftHours, err := floatFromQuery(c, "shiftHours")
+	if err != nil {
+		return dto.TimesheetFilter{}, err
+	}
+
+	return dto.TimesheetFilter{
+		ProjectID:  c.GetUint("projectID"),
+		TeamID:     teamID,
+		JobTitle:   strings.TrimSpace(c.DefaultQuery("jobTitle", "")),
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
+		ShiftHours: shiftHours,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"backend-v2/internal/service"
+	"backend-v2/model"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type unitController struct {
+	unitService service.IUnitService
+}
+
+func NewUnitController(unitService service.IUnitService) IUnitController {
+	return &unitController{
+		unitService: unitService,
+	}
+}
+
+type IUnitController interface {
+	GetAll(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetMaterialUnits(c *gin.Context)
+	CreateMaterialUnit(c *gin.Context)
+	UpdateMaterialUnit(c *gin.Context)
+	DeleteMaterialUnit(c *gin.Context)
+}
+
+func (controller *unitController) GetAll(c *gin.Context) {
+	data, err := controller.unitService.GetAll(c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) Create(c *gin.Context) {
+	var createData model.Unit
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	createData.ProjectID = c.GetUint("projectID")
+
+	data, err := controller.unitService.Create(createData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) Update(c *gin.Context) {
+	var updateData model.Unit
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	updateData.ProjectID = c.GetUint("projectID")
+
+	data, err := controller.unitService.Update(updateData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) Delete(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	if err := controller.unitService.Delete(uint(id)); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
+
+func (controller *unitController) GetMaterialUnits(c *gin.Context) {
+	materialIDRaw := c.Param("materialID")
+	materialID, err := strconv.ParseUint(materialIDRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	data, err := controller.unitService.GetMaterialUnits(uint(materialID))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) CreateMaterialUnit(c *gin.Context) {
+	var createData model.MaterialUnit
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data, err := controller.unitService.CreateMaterialUnit(createData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) UpdateMaterialUnit(c *gin.Context) {
+	var updateData model.MaterialUnit
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data, err := controller.unitService.UpdateMaterialUnit(updateData)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *unitController) DeleteMaterialUnit(c *gin.Context) {
+	idRaw := c.Param("id")
+	id, err := strconv.ParseUint(idRaw, 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	if err := controller.unitService.DeleteMaterialUnit(uint(id)); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"backend-v2/internal/service"
+	"backend-v2/model"
 	"backend-v2/pkg/response"
 	"fmt"
 	"path/filepath"
@@ -19,6 +20,9 @@ type IWorkerAttendanceController interface {
 	GetPaginated(c *gin.Context)
 	Progress(c *gin.Context)
   Analysis(c *gin.Context)
+	GetFormats(c *gin.Context)
+	CreateFormat(c *gin.Context)
+	DeleteFormat(c *gin.Context)
 }
 
 func InitWorkerAttendanceController(workerAttendanceService service.IWorkerAttendanceService) IWorkerAttendanceController {
@@ -43,14 +47,19 @@ func (controller *workerAttendanceController) Import(c *gin.Context) {
 		return
 	}
 
+	format := c.PostForm("format")
+	if format == "" {
+		format = "morpho"
+	}
+
 	projectID := c.GetUint("projectID")
-	err = controller.workerAttendanceService.Import(projectID, importFilePath)
+	result, err := controller.workerAttendanceService.Import(projectID, format, importFilePath)
 	if err != nil {
 		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
 		return
 	}
 
-	response.ResponseSuccess(c, true)
+	response.ResponseSuccess(c, result)
 }
 
 func (controller *workerAttendanceController) GetPaginated(c *gin.Context) {
@@ -80,3 +89,43 @@ func (controlelr *workerAttendanceController) Analysis(c *gin.Context) {
 	filepath := "./pkg/excels/templates/Анализ.xlsx"
 	c.FileAttachment(filepath, "Анализ.xlsx")
 }
+
+func (controller *workerAttendanceController) GetFormats(c *gin.Context) {
+	projectID := c.GetUint("projectID")
+	data, err := controller.workerAttendanceService.GetFormats(projectID)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *workerAttendanceController) CreateFormat(c *gin.Context) {
+	var data model.AttendanceImportFormat
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	data.ID = 0
+	data.ProjectID = c.GetUint("projectID")
+	result, err := controller.workerAttendanceService.CreateFormat(data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *workerAttendanceController) DeleteFormat(c *gin.Context) {
+	projectID := c.GetUint("projectID")
+	err := controller.workerAttendanceService.DeleteFormat(projectID, c.Param("code"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, "deleted")
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/service"
+	"backend-v2/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type workerMergeController struct {
+	workerMergeService service.IWorkerMergeService
+}
+
+func NewWorkerMergeController(workerMergeService service.IWorkerMergeService) IWorkerMergeController {
+	return &workerMergeController{
+		workerMergeService: workerMergeService,
+	}
+}
+
+type IWorkerMergeController interface {
+	GetDuplicates(c *gin.Context)
+	GetAll(c *gin.Context)
+	Merge(c *gin.Context)
+	Undo(c *gin.Context)
+}
+
+func (controller *workerMergeController) GetDuplicates(c *gin.Context) {
+	data, err := controller.workerMergeService.GetDuplicates(c.GetUint("roleID"), c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *workerMergeController) GetAll(c *gin.Context) {
+	data, err := controller.workerMergeService.GetAll(c.GetUint("roleID"), c.GetUint("projectID"))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, data)
+}
+
+func (controller *workerMergeController) Merge(c *gin.Context) {
+	var data dto.WorkerMergeRequest
+	if err := c.ShouldBindJSON(&data); err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неверное тело запроса: %v", err))
+		return
+	}
+
+	result, err := controller.workerMergeService.Merge(c.GetUint("roleID"), c.GetUint("projectID"), c.GetUint("userID"), data)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
+
+func (controller *workerMergeController) Undo(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Неправильный параметер запроса: %v", err))
+		return
+	}
+
+	result, err := controller.workerMergeService.Undo(c.GetUint("roleID"), c.GetUint("projectID"), c.GetUint("userID"), uint(id))
+	if err != nil {
+		response.ResponseError(c, fmt.Sprintf("Внутренняя ошибка сервера: %v", err))
+		return
+	}
+
+	response.ResponseSuccess(c, result)
+}
@@ -0,0 +1,6 @@
+package dto
+
+type AttachmentFile struct {
+	FilePath string
+	FileName string
+}
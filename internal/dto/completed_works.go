@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CompletedWorksFilter struct {
+	ProjectID uint
+	DateFrom  time.Time
+	DateTo    time.Time
+}
+
+type CompletedWorksOperationQueryResult struct {
+	ObjectID    uint
+	ObjectName  string
+	ObjectType  string
+	OperationID uint
+	Code        string
+	Name        string
+	Amount      float64
+	Price       decimal.Decimal
+	Sum         decimal.Decimal
+}
+
+type CompletedWorksHeader struct {
+	ProjectName    string    `json:"projectName"`
+	Client         string    `json:"client"`
+	ContractDate   time.Time `json:"contractDate"`
+	DocumentNumber string    `json:"documentNumber"`
+	DateOfDocument time.Time `json:"dateOfDocument"`
+	DateFrom       time.Time `json:"dateFrom"`
+	DateTo         time.Time `json:"dateTo"`
+}
+
+type KS2Row struct {
+	OperationID uint            `json:"operationID"`
+	Code        string          `json:"code"`
+	Name        string          `json:"name"`
+	Amount      float64         `json:"amount"`
+	Price       decimal.Decimal `json:"price"`
+	Sum         decimal.Decimal `json:"sum"`
+}
+
+type KS2Object struct {
+	ObjectID   uint            `json:"objectID"`
+	ObjectName string          `json:"objectName"`
+	ObjectType string          `json:"objectType"`
+	Rows       []KS2Row        `json:"rows"`
+	Sum        decimal.Decimal `json:"sum"`
+}
+
+// Act of completed works (form KS-2)
+type KS2Report struct {
+	Header  CompletedWorksHeader `json:"header"`
+	Objects []KS2Object          `json:"objects"`
+	Sum     decimal.Decimal      `json:"sum"`
+}
+
+type KS3Row struct {
+	ObjectID          uint            `json:"objectID"`
+	ObjectName        string          `json:"objectName"`
+	ObjectType        string          `json:"objectType"`
+	SumSinceStart     decimal.Decimal `json:"sumSinceStart"`
+	SumSinceYearStart decimal.Decimal `json:"sumSinceYearStart"`
+	SumForPeriod      decimal.Decimal `json:"sumForPeriod"`
+}
+
+// Certificate of the cost of completed works (form KS-3)
+type KS3Report struct {
+	Header CompletedWorksHeader `json:"header"`
+	Rows   []KS3Row             `json:"rows"`
+	Total  KS3Row               `json:"total"`
+}
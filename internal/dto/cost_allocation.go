@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type MaterialCostAmountInLocation struct {
+	MaterialID      uint
+	MaterialCostID  uint
+	MaterialCostM19 decimal.Decimal
+	MaterialAmount  float64
+	DateOfReceipt   time.Time
+}
+
+type MaterialCostAllocation struct {
+	MaterialCostID  uint            `json:"materialCostID"`
+	MaterialCostM19 decimal.Decimal `json:"materialCostM19"`
+	Amount          float64         `json:"amount"`
+}
+
+type CostAllocationPreview struct {
+	Strategy    string                   `json:"strategy"`
+	Allocations []MaterialCostAllocation `json:"allocations"`
+	TotalCost   decimal.Decimal          `json:"totalCost"`
+}
+
+type CostAllocationStrategyUpdate struct {
+	Strategy string `json:"strategy"`
+}
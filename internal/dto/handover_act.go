@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type HandoverActGenerate struct {
+	ObjectIDs []uint `json:"objectIDs"`
+	Format    string `json:"format"`
+}
+
+type HandoverActView struct {
+	ID         uint      `json:"id"`
+	ObjectID   uint      `json:"objectID"`
+	ObjectName string    `json:"objectName"`
+	ObjectType string    `json:"objectType"`
+	Number     string    `json:"number"`
+	Date       time.Time `json:"date"`
+}
+
+type HandoverActMaterialQueryResult struct {
+	MaterialID uint
+	Code       string
+	Name       string
+	Unit       string
+	Amount     float64
+}
+
+type HandoverActSerialNumberQueryResult struct {
+	MaterialID uint
+	Code       string
+}
+
+type HandoverActOperationQueryResult struct {
+	OperationID uint
+	Code        string
+	Name        string
+	Amount      float64
+}
+
+type HandoverActWorkPeriod struct {
+	Start time.Time
+	End   time.Time
+}
@@ -45,12 +45,21 @@ type InvoiceCorrectionCreate struct {
 }
 
 type InvoiceCorrectionCreateQuery struct {
-	Details           model.InvoiceObject
-	OperatorDetails   model.InvoiceObjectOperator
-	InvoiceMaterials  []model.InvoiceMaterials
-	InvoiceOperations []model.InvoiceOperations
-	TeamLocation      []model.MaterialLocation
-	ObjectLocation    []model.MaterialLocation
+	Details              model.InvoiceObject
+	OperatorDetails      model.InvoiceObjectOperator
+	InvoiceMaterials     []model.InvoiceMaterials
+	InvoiceOperations    []model.InvoiceOperations
+	TeamLocation         []model.MaterialLocation
+	ObjectLocation       []model.MaterialLocation
+	OperatorErrors       []model.OperatorErrorFound
+	MaterialLotMovements []model.MaterialLotMovement
+}
+
+type InvoiceCorrectionMaterialLine struct {
+	InvoiceMaterialID uint
+	MaterialID        uint
+	MaterialCostID    uint
+	Amount            float64
 }
 
 type InvoiceCorrectionReportFilter struct {
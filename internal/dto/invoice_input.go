@@ -13,8 +13,11 @@ type InvoiceInput struct {
 }
 
 type InvoiceInputMaterial struct {
-	MaterialData  model.InvoiceMaterials `json:"materialData"`
-	SerialNumbers []string               `json:"serialNumbers"`
+	MaterialData    model.InvoiceMaterials `json:"materialData"`
+	SerialNumbers   []string               `json:"serialNumbers"`
+	LotNumber       string                 `json:"lotNumber"`
+	ManufactureDate time.Time              `json:"manufactureDate"`
+	ExpiryDate      time.Time              `json:"expiryDate"`
 }
 
 type InvoiceInputPaginated struct {
@@ -32,6 +35,8 @@ type InvoiceInputCreateQueryData struct {
 	InvoiceMaterials     []model.InvoiceMaterials
 	SerialNumbers        []model.SerialNumber
 	SerialNumberMovement []model.SerialNumberMovement
+	MaterialLots         []model.MaterialLot
+	MaterialLotMovements []model.MaterialLotMovement
 }
 
 type InvoiceInputConfirmationQueryData struct {
@@ -67,6 +72,7 @@ type NewMaterialDataFromInvoiceInput struct {
 	Notes            string          `json:"notes"`
 	ProjectID        uint            `json:"projectID"`
 	HasSerialNumber  bool            `json:"hasSerialNumber"`
+	HasLot           bool            `json:"hasLot"`
 	CostPrime        decimal.Decimal `json:"costPrime" gorm:"type:decimal(20,4)"`
 	CostM19          decimal.Decimal `json:"costM19" gorm:"type:decimal(20,4)"`
 	CostWithCustomer decimal.Decimal `json:"costWithCustomer" gorm:"type:decimal(20,4)"`
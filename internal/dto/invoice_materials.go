@@ -55,4 +55,6 @@ type InvoiceMaterialsDataForReport struct {
 	MaterialCostWithCustomer decimal.Decimal
 	InvoiceMaterialAmount    float64
 	InvoiceMaterialNotes     string
+	EnteredUnitName          string
+	UnitAmount               float64
 }
@@ -42,6 +42,8 @@ type InvoiceObjectCreateQueryData struct {
 	InvoiceMaterials      []model.InvoiceMaterials
 	InvoiceOperations      []model.InvoiceOperations
 	SerialNumberMovements []model.SerialNumberMovement
+	MaterialLotMovements  []model.MaterialLotMovement
+	BOMDeviations         []model.InvoiceObjectBOMDeviation
 }
 
 type InvoiceObjectFullDataItem struct {
@@ -60,6 +62,8 @@ type InvoiceObjectWithMaterialsDescriptive struct {
 	InvoiceData                  InvoiceObjectPaginated                    `json:"invoiceData"`
 	MaterialsWithSerialNumber    []InvoiceMaterialsWithSerialNumberView    `json:"materialsWithSN"`
 	MaterialsWithoutSerialNumber []InvoiceMaterialsWithoutSerialNumberView `json:"materialsWithoutSN"`
+	BOMDeviations                []InvoiceObjectBOMDeviationView           `json:"bomDeviations"`
+	TeamMembers                  []TeamMemberView                          `json:"teamMembers"`
 }
 
 type InvoiceObjectTeamMaterials struct {
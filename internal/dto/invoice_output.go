@@ -25,10 +25,12 @@ type InvoiceOutputPaginated struct {
 }
 
 type InvoiceOutputItem struct {
-	MaterialID    uint     `json:"materialID"`
-	Amount        float64  `json:"amount"`
-	SerialNumbers []string `json:"serialNumbers"`
-	Notes         string   `json:"notes"`
+	MaterialID     uint     `json:"materialID"`
+	Amount         float64  `json:"amount"`
+	SerialNumbers  []string `json:"serialNumbers"`
+	Notes          string   `json:"notes"`
+	MaterialUnitID uint     `json:"materialUnitID"`
+	UnitAmount     float64  `json:"-"`
 }
 
 type InvoiceOutput struct {
@@ -47,6 +49,7 @@ type InvoiceOutputCreateQueryData struct {
 	Invoice               model.InvoiceOutput
 	InvoiceMaterials      []model.InvoiceMaterials
 	SerialNumberMovements []model.SerialNumberMovement
+	MaterialLotMovements  []model.MaterialLotMovement
 }
 
 type InvoiceOutputConfirmationQueryData struct {
@@ -137,6 +140,8 @@ type InvoiceOutputMaterialDataForReport struct {
 	MaterialCostM19 decimal.Decimal
 	Notes           string
 	Amount          float64
+	EnteredUnitName string
+	UnitAmount      float64
 }
 
 type InvoiceOutputImportData struct {
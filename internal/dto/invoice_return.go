@@ -67,11 +67,13 @@ type InvoiceReturnObjectPaginated struct {
 }
 
 type InvoiceReturnItem struct {
-	MaterialID    uint     `json:"materialID"`
-	Amount        float64  `json:"amount"`
-	IsDefected    bool     `json:"isDefected"`
-	SerialNumbers []string `json:"serialNumbers"`
-	Notes         string   `json:"notes"`
+	MaterialID     uint     `json:"materialID"`
+	Amount         float64  `json:"amount"`
+	IsDefected     bool     `json:"isDefected"`
+	SerialNumbers  []string `json:"serialNumbers"`
+	Notes          string   `json:"notes"`
+	MaterialUnitID uint     `json:"materialUnitID"`
+	UnitAmount     float64  `json:"-"`
 }
 
 type InvoiceReturn struct {
@@ -99,6 +101,7 @@ type InvoiceReturnCreateQueryData struct {
 	Invoice               model.InvoiceReturn
 	InvoiceMaterials      []model.InvoiceMaterials
 	SerialNumberMovements []model.SerialNumberMovement
+	MaterialLotMovements  []model.MaterialLotMovement
 }
 
 type InvoiceReturnMaterialsForExcel struct {
@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+type KPIFilter struct {
+	ProjectID uint
+	// "team" or "supervisor"
+	GroupBy  string
+	EntityID uint
+	DateFrom time.Time
+	DateTo   time.Time
+	// "day", "week" or "month"
+	Interval string
+}
+
+type KPIReport struct {
+	GroupBy  string      `json:"groupBy"`
+	Interval string      `json:"interval"`
+	DateFrom time.Time   `json:"dateFrom"`
+	DateTo   time.Time   `json:"dateTo"`
+	Periods  []time.Time `json:"periods"`
+	Entities []KPIEntity `json:"entities"`
+}
+
+// Team or supervisor with the series of every indicator
+type KPIEntity struct {
+	ID     uint        `json:"id"`
+	Name   string      `json:"name"`
+	Series []KPISeries `json:"series"`
+}
+
+type KPISeries struct {
+	Code   string     `json:"code"`
+	Name   string     `json:"name"`
+	Points []KPIPoint `json:"points"`
+}
+
+type KPIPoint struct {
+	Period time.Time `json:"period"`
+	Value  float64   `json:"value"`
+}
+
+type KPIQueryResult struct {
+	EntityID uint
+	Period   time.Time
+	Value    float64
+}
+
+type KPIOperationsQueryResult struct {
+	EntityID uint
+	Period   time.Time
+	Amount   float64
+	Value    float64
+}
+
+type KPIMaterialQueryResult struct {
+	EntityID   uint
+	Period     time.Time
+	MaterialID uint
+	Amount     float64
+}
+
+type KPICorrectionQueryResult struct {
+	EntityID  uint
+	Period    time.Time
+	Total     float64
+	Corrected float64
+}
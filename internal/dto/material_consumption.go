@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+type MaterialConsumptionFilter struct {
+	ProjectID  uint
+	ObjectID   uint
+	DistrictID uint
+	DateFrom   time.Time
+	DateTo     time.Time
+}
+
+type MaterialConsumptionQueryResult struct {
+	ObjectID   uint
+	ObjectName string
+	ObjectType string
+	MaterialID uint
+	Code       string
+	Name       string
+	Unit       string
+	Amount     float64
+}
+
+type MaterialConsumptionRow struct {
+	MaterialID       uint    `json:"materialID"`
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Unit             string  `json:"unit"`
+	NormativeAmount  float64 `json:"normativeAmount"`
+	ActualAmount     float64 `json:"actualAmount"`
+	Overconsumption  float64 `json:"overconsumption"`
+	Underconsumption float64 `json:"underconsumption"`
+}
+
+type MaterialConsumptionObject struct {
+	ObjectID   uint                     `json:"objectID"`
+	ObjectName string                   `json:"objectName"`
+	ObjectType string                   `json:"objectType"`
+	Rows       []MaterialConsumptionRow `json:"rows"`
+}
+
+// Material consumption report in the layout of the form M-29
+type MaterialConsumptionReport struct {
+	ProjectName string                      `json:"projectName"`
+	Scope       string                      `json:"scope"`
+	DateFrom    time.Time                   `json:"dateFrom"`
+	DateTo      time.Time                   `json:"dateTo"`
+	Objects     []MaterialConsumptionObject `json:"objects"`
+	Totals      []MaterialConsumptionRow    `json:"totals"`
+}
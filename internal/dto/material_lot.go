@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type MaterialLotAmountSortedByExpiryQueryResult struct {
+	MaterialCostID uint
+	MaterialLotID  uint
+	ExpiryDate     time.Time
+	Amount         float64
+}
+
+type MaterialLotAllocation struct {
+	MaterialCostID uint
+	MaterialLotID  uint
+	Amount         float64
+}
+
+type MaterialLotExpiringFilter struct {
+	ProjectID     uint
+	ExpiresBefore time.Time
+	LocationType  string
+	LocationID    uint
+}
+
+type MaterialLotExpiringView struct {
+	MaterialLotID   uint      `json:"materialLotID"`
+	LotCode         string    `json:"lotCode"`
+	MaterialName    string    `json:"materialName"`
+	MaterialUnit    string    `json:"materialUnit"`
+	LocationType    string    `json:"locationType"`
+	LocationID      uint      `json:"locationID"`
+	LocationName    string    `json:"locationName"`
+	ManufactureDate time.Time `json:"manufactureDate"`
+	ExpiryDate      time.Time `json:"expiryDate"`
+	Amount          float64   `json:"amount"`
+}
+
+type MaterialLotInInvoiceView struct {
+	MaterialLotID   uint      `json:"materialLotID"`
+	LotCode         string    `json:"lotCode"`
+	MaterialName    string    `json:"materialName"`
+	MaterialUnit    string    `json:"materialUnit"`
+	ManufactureDate time.Time `json:"manufactureDate"`
+	ExpiryDate      time.Time `json:"expiryDate"`
+	Amount          float64   `json:"amount"`
+}
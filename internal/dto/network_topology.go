@@ -0,0 +1,50 @@
+package dto
+
+type NetworkNode struct {
+	ObjectID uint   `json:"objectID"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+}
+
+type NetworkEdge struct {
+	ConnectionID   uint   `json:"connectionID"`
+	SourceObjectID uint   `json:"sourceObjectID"`
+	TargetObjectID uint   `json:"targetObjectID"`
+	Origin         string `json:"origin"`
+}
+
+type NetworkTopology struct {
+	Nodes   []NetworkNode `json:"nodes"`
+	Edges   []NetworkEdge `json:"edges"`
+	Cycles  [][]uint      `json:"cycles"`
+	Orphans []NetworkNode `json:"orphans"`
+}
+
+type NetworkTreeNode struct {
+	ObjectID uint              `json:"objectID"`
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Status   string            `json:"status"`
+	Cycle    bool              `json:"cycle"`
+	Children []NetworkTreeNode `json:"children"`
+}
+
+type NetworkConnectionCreate struct {
+	SourceObjectID uint `json:"sourceObjectID"`
+	TargetObjectID uint `json:"targetObjectID"`
+}
+
+type KL04KVNourashesQueryResult struct {
+	ObjectID  uint
+	Name      string
+	Nourashes string
+}
+
+type NourashesResolveResult struct {
+	ObjectID          uint     `json:"objectID"`
+	Name              string   `json:"name"`
+	Nourashes         string   `json:"nourashes"`
+	ResolvedObjectIDs []uint   `json:"resolvedObjectIDs"`
+	Unresolved        []string `json:"unresolved"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+type ObjectGeolocationQueryResult struct {
+	ObjectID  uint
+	Name      string
+	Type      string
+	Status    string
+	Latitude  float64
+	Longitude float64
+	Route     string
+}
+
+type ObjectTeamNumberQueryResult struct {
+	ObjectID   uint
+	TeamNumber string
+}
+
+// Either the circle (Latitude, Longitude, Radius in meters) or
+// the bounding box (MinLatitude, MinLongitude, MaxLatitude, MaxLongitude) is used
+type ObjectGeolocationSearch struct {
+	Latitude     float64
+	Longitude    float64
+	Radius       float64
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string                      `json:"type"`
+	Geometry   GeoJSONGeometry             `json:"geometry"`
+	Properties ObjectGeolocationProperties `json:"properties"`
+}
+
+// Coordinates are [longitude, latitude] for Point and a list of them for LineString
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type ObjectGeolocationProperties struct {
+	ObjectID   uint     `json:"objectID"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Status     string   `json:"status"`
+	StatusName string   `json:"statusName"`
+	Teams      []string `json:"teams"`
+}
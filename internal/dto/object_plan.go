@@ -0,0 +1,80 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type ObjectPlannedItemView struct {
+	ID     uint    `json:"id"`
+	Code   string  `json:"code"`
+	Name   string  `json:"name"`
+	Unit   string  `json:"unit"`
+	Amount float64 `json:"amount"`
+}
+
+type ObjectPlanView struct {
+	ObjectID   uint                    `json:"objectID"`
+	Materials  []ObjectPlannedItemView `json:"materials"`
+	Operations []ObjectPlannedItemView `json:"operations"`
+}
+
+type ObjectPlanReplaceMaterial struct {
+	MaterialID uint    `json:"materialID"`
+	Amount     float64 `json:"amount"`
+}
+
+type ObjectPlanReplaceOperation struct {
+	OperationID uint    `json:"operationID"`
+	Amount      float64 `json:"amount"`
+}
+
+type ObjectPlanReplace struct {
+	ObjectID   uint                         `json:"objectID"`
+	Materials  []ObjectPlanReplaceMaterial  `json:"materials"`
+	Operations []ObjectPlanReplaceOperation `json:"operations"`
+}
+
+// Planned or actual amount of the material or operation on the object
+type ObjectPlanAmountQueryResult struct {
+	ObjectID   uint
+	ObjectName string
+	ObjectType string
+	ItemID     uint
+	Code       string
+	Name       string
+	Unit       string
+	Amount     float64
+	Sum        decimal.Decimal
+}
+
+type ObjectPlanReportRow struct {
+	ID               uint            `json:"id"`
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	Unit             string          `json:"unit"`
+	PlannedAmount    float64         `json:"plannedAmount"`
+	ActualAmount     float64         `json:"actualAmount"`
+	AmountDifference float64         `json:"amountDifference"`
+	PlannedSum       decimal.Decimal `json:"plannedSum"`
+	ActualSum        decimal.Decimal `json:"actualSum"`
+	SumDifference    decimal.Decimal `json:"sumDifference"`
+	Overrun          bool            `json:"overrun"`
+}
+
+type ObjectPlanReportObject struct {
+	ObjectID   uint                  `json:"objectID"`
+	ObjectName string                `json:"objectName"`
+	ObjectType string                `json:"objectType"`
+	Materials  []ObjectPlanReportRow `json:"materials"`
+	Operations []ObjectPlanReportRow `json:"operations"`
+	PlannedSum decimal.Decimal       `json:"plannedSum"`
+	ActualSum  decimal.Decimal       `json:"actualSum"`
+	Overrun    bool                  `json:"overrun"`
+}
+
+type ObjectPlanReport struct {
+	Objects    []ObjectPlanReportObject `json:"objects"`
+	Materials  []ObjectPlanReportRow    `json:"materials"`
+	Operations []ObjectPlanReportRow    `json:"operations"`
+	PlannedSum decimal.Decimal          `json:"plannedSum"`
+	ActualSum  decimal.Decimal          `json:"actualSum"`
+	Overrun    bool                     `json:"overrun"`
+}
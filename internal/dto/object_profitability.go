@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ObjectProfitabilityFilter struct {
+	ProjectID uint
+	ObjectID  uint
+	DateFrom  time.Time
+	DateTo    time.Time
+}
+
+// Customer value and prime cost of the materials and operations written in one invoice
+type ObjectProfitabilityInvoiceQueryResult struct {
+	InvoiceID           uint
+	DeliveryCode        string
+	DateOfInvoice       time.Time
+	ConfirmedByOperator bool
+	ObjectID            uint
+	ObjectName          string
+	ObjectType          string
+	DistrictID          uint
+	DistrictName        string
+	MaterialValue       decimal.Decimal
+	MaterialCost        decimal.Decimal
+	OperationValue      decimal.Decimal
+	LabourCost          decimal.Decimal
+}
+
+type ObjectProfitabilityRow struct {
+	ID            uint            `json:"id"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	ObjectType    string          `json:"objectType"`
+	CustomerValue decimal.Decimal `json:"customerValue"`
+	MaterialCost  decimal.Decimal `json:"materialCost"`
+	LabourCost    decimal.Decimal `json:"labourCost"`
+	PrimeCost     decimal.Decimal `json:"primeCost"`
+	Margin        decimal.Decimal `json:"margin"`
+	MarginPercent float64         `json:"marginPercent"`
+}
+
+type ObjectProfitabilitySummary struct {
+	Objects     []ObjectProfitabilityRow `json:"objects"`
+	ObjectTypes []ObjectProfitabilityRow `json:"objectTypes"`
+	Districts   []ObjectProfitabilityRow `json:"districts"`
+	Total       ObjectProfitabilityRow   `json:"total"`
+}
+
+type ObjectProfitabilityInvoice struct {
+	InvoiceID           uint            `json:"invoiceID"`
+	DeliveryCode        string          `json:"deliveryCode"`
+	DateOfInvoice       time.Time       `json:"dateOfInvoice"`
+	ConfirmedByOperator bool            `json:"confirmedByOperator"`
+	DistrictName        string          `json:"districtName"`
+	CustomerValue       decimal.Decimal `json:"customerValue"`
+	MaterialCost        decimal.Decimal `json:"materialCost"`
+	LabourCost          decimal.Decimal `json:"labourCost"`
+	PrimeCost           decimal.Decimal `json:"primeCost"`
+	Margin              decimal.Decimal `json:"margin"`
+	MarginPercent       float64         `json:"marginPercent"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type ObjectStatusView struct {
+	Status string `json:"status"`
+	Name   string `json:"name"`
+}
+
+type ObjectStatusTransitionCreate struct {
+	ObjectID uint   `json:"objectID"`
+	ToStatus string `json:"toStatus"`
+	Comment  string `json:"comment"`
+}
+
+type ObjectStatusTransitionView struct {
+	ID             uint      `json:"id"`
+	FromStatus     string    `json:"fromStatus"`
+	FromStatusName string    `json:"fromStatusName"`
+	ToStatus       string    `json:"toStatus"`
+	ToStatusName   string    `json:"toStatusName"`
+	Username       string    `json:"username"`
+	WorkerName     string    `json:"workerName"`
+	Date           time.Time `json:"date"`
+	Comment        string    `json:"comment"`
+}
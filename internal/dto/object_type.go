@@ -0,0 +1,98 @@
+package dto
+
+type ObjectTypeAttributeView struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+	Required bool   `json:"required"`
+}
+
+type ObjectTypeView struct {
+	Code         string                    `json:"code"`
+	Name         string                    `json:"name"`
+	Builtin      bool                      `json:"builtin"`
+	NetworkLevel uint                      `json:"networkLevel"`
+	Attributes   []ObjectTypeAttributeView `json:"attributes"`
+}
+
+type ObjectTypeCreate struct {
+	Code         string                    `json:"code"`
+	Name         string                    `json:"name"`
+	NetworkLevel uint                      `json:"networkLevel"`
+	Attributes   []ObjectTypeAttributeView `json:"attributes"`
+}
+
+// Where the details of the object are kept: in the columns of the detail table
+// of the builtin type or in the attribute values of the custom type
+type ObjectDetailsStorage struct {
+	DetailTable     string
+	ColumnValues    map[string]interface{}
+	ObjectTypeID    uint
+	AttributeValues map[uint]string
+}
+
+type TypedObjectCreate struct {
+	ObjectID    uint                   `json:"objectID"`
+	Name        string                 `json:"name"`
+	Status      string                 `json:"status"`
+	Values      map[string]interface{} `json:"values"`
+	Supervisors []uint                 `json:"supervisors"`
+	Teams       []uint                 `json:"teams"`
+
+	// Objects feeding the object and objects fed by it, on update omitted lists
+	// leave the existing relations untouched
+	FedBy []uint `json:"fedBy"`
+	Feeds []uint `json:"feeds"`
+}
+
+// Feeding relations of the object, FedByTable is the table that keeps the feeders
+// of the object type. Nil lists leave the existing relations untouched
+type ObjectFeeding struct {
+	FedByTable string
+	FedBy      []uint
+	Feeds      []uint
+}
+
+type TypedObjectSearchParameters struct {
+	ProjectID          uint
+	Type               string
+	ObjectName         string
+	SupervisorWorkerID uint
+	TeamID             uint
+	FedByObjectID      uint
+}
+
+type TypedObjectQuery struct {
+	ObjectID         uint   `json:"objectID"`
+	ObjectDetailedID uint   `json:"objectDetailedID"`
+	Name             string `json:"name"`
+	Status           string `json:"status"`
+}
+
+type CustomObjectAttributeValueQuery struct {
+	CustomObjectID        uint
+	ObjectTypeAttributeID uint
+	Value                 string
+}
+
+type TypedObjectPaginated struct {
+	ObjectID         uint                   `json:"objectID"`
+	ObjectDetailedID uint                   `json:"objectDetailedID"`
+	Type             string                 `json:"type"`
+	Name             string                 `json:"name"`
+	Status           string                 `json:"status"`
+	StatusName       string                 `json:"statusName"`
+	Values           map[string]interface{} `json:"values"`
+	Supervisors      []string               `json:"supervisors"`
+	Teams            []string               `json:"teams"`
+	FedBy            []string               `json:"fedBy"`
+}
+
+type TypedObjectImportData struct {
+	Name        string
+	Status      string
+	Details     ObjectDetailsStorage
+	Supervisors []uint
+	Teams       []uint
+	Feeding     ObjectFeeding
+}
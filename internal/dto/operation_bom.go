@@ -0,0 +1,54 @@
+package dto
+
+type OperationBOMItemView struct {
+	ID           uint    `json:"id"`
+	OperationID  uint    `json:"operationID"`
+	MaterialID   uint    `json:"materialID"`
+	MaterialName string  `json:"materialName"`
+	MaterialUnit string  `json:"materialUnit"`
+	Amount       float64 `json:"amount"`
+}
+
+type OperationBOMReplaceItem struct {
+	MaterialID uint    `json:"materialID"`
+	Amount     float64 `json:"amount"`
+}
+
+type OperationBOMReplace struct {
+	OperationID uint                      `json:"operationID"`
+	Items       []OperationBOMReplaceItem `json:"items"`
+}
+
+type OperationBOMPlannedOperation struct {
+	OperationID uint    `json:"operationID"`
+	Amount      float64 `json:"amount"`
+}
+
+type OperationBOMRequisitionRequest struct {
+	TeamID     uint                           `json:"teamID"`
+	Operations []OperationBOMPlannedOperation `json:"operations"`
+}
+
+type OperationBOMRequisitionMaterial struct {
+	MaterialID        uint    `json:"materialID"`
+	MaterialName      string  `json:"materialName"`
+	MaterialUnit      string  `json:"materialUnit"`
+	RequiredAmount    float64 `json:"requiredAmount"`
+	AmountInTeam      float64 `json:"amountInTeam"`
+	AmountInWarehouse float64 `json:"amountInWarehouse"`
+	AmountToIssue     float64 `json:"amountToIssue"`
+}
+
+type OperationBOMRequisition struct {
+	Materials   []OperationBOMRequisitionMaterial `json:"materials"`
+	OutputItems []InvoiceOutputItem               `json:"outputItems"`
+}
+
+type InvoiceObjectBOMDeviationView struct {
+	MaterialID       uint    `json:"materialID"`
+	MaterialName     string  `json:"materialName"`
+	MaterialUnit     string  `json:"materialUnit"`
+	ExpectedAmount   float64 `json:"expectedAmount"`
+	ActualAmount     float64 `json:"actualAmount"`
+	DeviationPercent float64 `json:"deviationPercent"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type OperatorErrorFoundFilter struct {
+	ProjectID          uint      `json:"projectID"`
+	TeamID             uint      `json:"teamID"`
+	SupervisorWorkerID uint      `json:"supervisorWorkerID"`
+	MaterialID         uint      `json:"materialID"`
+	DateFrom           time.Time `json:"dateFrom"`
+	DateTo             time.Time `json:"dateTo"`
+}
+
+type OperatorErrorFoundReportData struct {
+	DeliveryCode     string
+	ObjectName       string
+	ObjectType       string
+	SupervisorName   string
+	TeamNumber       string
+	OperatorName     string
+	DateOfInvoice    time.Time
+	DateOfCorrection time.Time
+	MaterialName     string
+	MaterialUnit     string
+	MaterialCostM19  float64
+	ExpectedAmount   float64
+	CorrectedAmount  float64
+	Amount           float64
+	Notes            string
+}
+
+type OperatorErrorFoundStatistics struct {
+	ID                uint    `json:"id"`
+	Label             string  `json:"label"`
+	ErrorCount        int     `json:"errorCount"`
+	InvoiceCount      int     `json:"invoiceCount"`
+	DiscrepancyAmount float64 `json:"discrepancyAmount"`
+}
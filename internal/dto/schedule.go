@@ -0,0 +1,127 @@
+package dto
+
+import "time"
+
+type ScheduleFilter struct {
+	ProjectID  uint
+	TeamID     uint
+	ObjectID   uint
+	DistrictID uint
+	DateFrom   time.Time
+	DateTo     time.Time
+}
+
+type TeamAssignmentMutation struct {
+	ID         uint                          `json:"id"`
+	TeamID     uint                          `json:"teamID"`
+	ObjectID   uint                          `json:"objectID"`
+	DistrictID uint                          `json:"districtID"`
+	DateFrom   time.Time                     `json:"dateFrom"`
+	DateTo     time.Time                     `json:"dateTo"`
+	Notes      string                        `json:"notes"`
+	Operations []TeamAssignmentOperationItem `json:"operations"`
+	// Team is saved even when it already works elsewhere on the same days
+	AllowConflict bool `json:"allowConflict"`
+}
+
+type TeamAssignmentOperationItem struct {
+	OperationID uint    `json:"operationID"`
+	Amount      float64 `json:"amount"`
+}
+
+type TeamAssignmentQueryResult struct {
+	ID           uint
+	TeamID       uint
+	TeamNumber   string
+	ObjectID     uint
+	ObjectName   string
+	ObjectType   string
+	DistrictID   uint
+	DistrictName string
+	DateFrom     time.Time
+	DateTo       time.Time
+	Notes        string
+}
+
+type TeamAssignmentOperationQueryResult struct {
+	TeamAssignmentID uint
+	OperationID      uint
+	Code             string
+	Name             string
+	Amount           float64
+}
+
+type TeamAssignmentView struct {
+	ID           uint                          `json:"id"`
+	TeamID       uint                          `json:"teamID"`
+	TeamNumber   string                        `json:"teamNumber"`
+	ObjectID     uint                          `json:"objectID"`
+	ObjectName   string                        `json:"objectName"`
+	ObjectType   string                        `json:"objectType"`
+	DistrictID   uint                          `json:"districtID"`
+	DistrictName string                        `json:"districtName"`
+	DateFrom     time.Time                     `json:"dateFrom"`
+	DateTo       time.Time                     `json:"dateTo"`
+	Notes        string                        `json:"notes"`
+	Operations   []TeamAssignmentOperationView `json:"operations"`
+}
+
+type TeamAssignmentOperationView struct {
+	OperationID uint    `json:"operationID"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Amount      float64 `json:"amount"`
+}
+
+// Calendar with a row for every team, object or district and a cell for every day
+type ScheduleCalendar struct {
+	GroupBy     string                `json:"groupBy"`
+	Dates       []time.Time           `json:"dates"`
+	Rows        []ScheduleCalendarRow `json:"rows"`
+	Assignments []TeamAssignmentView  `json:"assignments"`
+}
+
+type ScheduleCalendarRow struct {
+	ID    uint                   `json:"id"`
+	Name  string                 `json:"name"`
+	Cells []ScheduleCalendarCell `json:"cells"`
+}
+
+type ScheduleCalendarCell struct {
+	Date          time.Time `json:"date"`
+	AssignmentIDs []uint    `json:"assignmentIDs"`
+	Conflict      bool      `json:"conflict"`
+}
+
+// Two assignments of the same team that share days
+type ScheduleConflict struct {
+	TeamID     uint               `json:"teamID"`
+	TeamNumber string             `json:"teamNumber"`
+	DateFrom   time.Time          `json:"dateFrom"`
+	DateTo     time.Time          `json:"dateTo"`
+	First      TeamAssignmentView `json:"first"`
+	Second     TeamAssignmentView `json:"second"`
+}
+
+type ScheduleActualOperationQueryResult struct {
+	OperationID uint
+	Code        string
+	Name        string
+	Amount      float64
+}
+
+type ScheduleComparison struct {
+	Assignment        TeamAssignmentView      `json:"assignment"`
+	InvoiceCount      int64                   `json:"invoiceCount"`
+	Rows              []ScheduleComparisonRow `json:"rows"`
+	CompletionPercent float64                 `json:"completionPercent"`
+}
+
+type ScheduleComparisonRow struct {
+	OperationID uint    `json:"operationID"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Planned     float64 `json:"planned"`
+	Actual      float64 `json:"actual"`
+	Difference  float64 `json:"difference"`
+}
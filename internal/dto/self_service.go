@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+type SelfServiceProfile struct {
+	WorkerID          uint              `json:"workerID"`
+	Name              string            `json:"name"`
+	CompanyWorkerID   string            `json:"companyWorkerID"`
+	JobTitleInCompany string            `json:"jobTitleInCompany"`
+	JobTitleInProject string            `json:"jobTitleInProject"`
+	MobileNumber      string            `json:"mobileNumber"`
+	Teams             []SelfServiceTeam `json:"teams"`
+}
+
+// Team the worker leads ("leader") or is a member of today ("member")
+type SelfServiceTeam struct {
+	TeamID        uint      `json:"teamID"`
+	TeamNumber    string    `json:"teamNumber"`
+	Role          string    `json:"role"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+type SelfServiceTeamBalance struct {
+	TeamID     uint                  `json:"teamID"`
+	TeamNumber string                `json:"teamNumber"`
+	Materials  []SelfServiceMaterial `json:"materials"`
+}
+
+type SelfServiceMaterial struct {
+	MaterialID    uint     `json:"materialID"`
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Unit          string   `json:"unit"`
+	Amount        float64  `json:"amount"`
+	SerialNumbers []string `json:"serialNumbers"`
+}
+
+// Invoice the worker signed, Role is "recipient" or "released"
+type SelfServiceInvoice struct {
+	InvoiceType   string    `json:"invoiceType"`
+	ID            uint      `json:"id"`
+	DeliveryCode  string    `json:"deliveryCode"`
+	DateOfInvoice time.Time `json:"dateOfInvoice"`
+	Role          string    `json:"role"`
+	Confirmed     bool      `json:"confirmed"`
+	TeamNumber    string    `json:"teamNumber"`
+}
+
+type SelfServiceAttendance struct {
+	DateFrom   time.Time                  `json:"dateFrom"`
+	DateTo     time.Time                  `json:"dateTo"`
+	Days       []SelfServiceAttendanceDay `json:"days"`
+	TotalDays  int                        `json:"totalDays"`
+	TotalHours float64                    `json:"totalHours"`
+}
+
+type SelfServiceAttendanceDay struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Hours float64   `json:"hours"`
+}
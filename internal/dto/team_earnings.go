@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TeamEarningsFilter struct {
+	ProjectID uint
+	TeamID    uint
+	DateFrom  time.Time
+	DateTo    time.Time
+	SplitMode string
+}
+
+type TeamEarningsOperationQueryResult struct {
+	TeamID      uint
+	TeamNumber  string
+	OperationID uint
+	Code        string
+	Name        string
+	Amount      float64
+	Price       decimal.Decimal
+	Sum         decimal.Decimal
+}
+
+type TeamEarningsMemberQueryResult struct {
+	TeamID          uint
+	WorkerID        uint
+	WorkerName      string
+	CompanyWorkerID string
+}
+
+type TeamEarningsWorkerHours struct {
+	TeamID   uint
+	WorkerID uint
+	Hours    float64
+}
+
+type TeamEarningsLossQueryResult struct {
+	InvoiceWriteOffID uint
+	TeamID            uint
+	DeliveryCode      string
+	DateOfInvoice     time.Time
+	Sum               decimal.Decimal
+}
+
+type TeamEarningsOperation struct {
+	OperationID uint            `json:"operationID"`
+	Code        string          `json:"code"`
+	Name        string          `json:"name"`
+	Amount      float64         `json:"amount"`
+	Price       decimal.Decimal `json:"price"`
+	Sum         decimal.Decimal `json:"sum"`
+}
+
+type TeamEarningsMember struct {
+	WorkerID        uint            `json:"workerID"`
+	WorkerName      string          `json:"workerName"`
+	CompanyWorkerID string          `json:"companyWorkerID"`
+	Hours           float64         `json:"hours"`
+	SharePercent    float64         `json:"sharePercent"`
+	PieceRate       decimal.Decimal `json:"pieceRate"`
+	Bonuses         decimal.Decimal `json:"bonuses"`
+	Deductions      decimal.Decimal `json:"deductions"`
+	Total           decimal.Decimal `json:"total"`
+}
+
+type TeamEarningsAdjustment struct {
+	ID                uint            `json:"id"`
+	WorkerID          uint            `json:"workerID"`
+	WorkerName        string          `json:"workerName"`
+	Date              time.Time       `json:"date"`
+	Kind              string          `json:"kind"`
+	Amount            decimal.Decimal `json:"amount"`
+	Reason            string          `json:"reason"`
+	InvoiceWriteOffID uint            `json:"invoiceWriteOffID"`
+}
+
+type TeamEarnings struct {
+	TeamID      uint                     `json:"teamID"`
+	TeamNumber  string                   `json:"teamNumber"`
+	Operations  []TeamEarningsOperation  `json:"operations"`
+	Adjustments []TeamEarningsAdjustment `json:"adjustments"`
+	Members     []TeamEarningsMember     `json:"members"`
+	PieceRate   decimal.Decimal          `json:"pieceRate"`
+	Bonuses     decimal.Decimal          `json:"bonuses"`
+	Deductions  decimal.Decimal          `json:"deductions"`
+	Total       decimal.Decimal          `json:"total"`
+}
+
+type TeamEarningsReport struct {
+	ProjectName string          `json:"projectName"`
+	DateFrom    time.Time       `json:"dateFrom"`
+	DateTo      time.Time       `json:"dateTo"`
+	SplitMode   string          `json:"splitMode"`
+	Teams       []TeamEarnings  `json:"teams"`
+	Total       decimal.Decimal `json:"total"`
+	Warnings    []string        `json:"warnings"`
+}
+
+type TeamEarningsAdjustmentCreate struct {
+	TeamID   uint            `json:"teamID"`
+	WorkerID uint            `json:"workerID"`
+	Date     time.Time       `json:"date"`
+	Kind     string          `json:"kind"`
+	Amount   decimal.Decimal `json:"amount"`
+	Reason   string          `json:"reason"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TeamMaterialAgingFilter struct {
+	ProjectID uint
+	TeamID    uint
+	Days      int
+}
+
+type TeamMaterialHeldQueryResult struct {
+	TeamID         uint
+	TeamNumber     string
+	MaterialCostID uint
+	MaterialID     uint
+	Code           string
+	Name           string
+	Unit           string
+	CostM19        decimal.Decimal
+	Amount         float64
+}
+
+type TeamMaterialIssueQueryResult struct {
+	TeamID         uint
+	MaterialCostID uint
+	DeliveryCode   string
+	DateOfInvoice  time.Time
+	Amount         float64
+}
+
+type TeamSerialNumberHeldQueryResult struct {
+	TeamID         uint
+	MaterialCostID uint
+	Code           string
+	DeliveryCode   string
+	DateOfInvoice  time.Time
+}
+
+// Part of the held amount that came with one output invoice, the amounts held
+// are taken from the latest invoices as the earliest ones are installed first
+type TeamMaterialBatch struct {
+	DeliveryCode  string          `json:"deliveryCode"`
+	DateOfInvoice time.Time       `json:"dateOfInvoice"`
+	Amount        float64         `json:"amount"`
+	Value         decimal.Decimal `json:"value"`
+	DaysHeld      int             `json:"daysHeld"`
+	Overdue       bool            `json:"overdue"`
+}
+
+type TeamMaterialSerialNumber struct {
+	Code          string    `json:"code"`
+	DeliveryCode  string    `json:"deliveryCode"`
+	DateOfInvoice time.Time `json:"dateOfInvoice"`
+	DaysHeld      int       `json:"daysHeld"`
+	Overdue       bool      `json:"overdue"`
+}
+
+type TeamMaterialAgingRow struct {
+	MaterialID    uint                       `json:"materialID"`
+	Code          string                     `json:"code"`
+	Name          string                     `json:"name"`
+	Unit          string                     `json:"unit"`
+	CostM19       decimal.Decimal            `json:"costM19"`
+	Amount        float64                    `json:"amount"`
+	Value         decimal.Decimal            `json:"value"`
+	MaxDaysHeld   int                        `json:"maxDaysHeld"`
+	OverdueAmount float64                    `json:"overdueAmount"`
+	OverdueValue  decimal.Decimal            `json:"overdueValue"`
+	Batches       []TeamMaterialBatch        `json:"batches"`
+	SerialNumbers []TeamMaterialSerialNumber `json:"serialNumbers"`
+}
+
+type TeamMaterialAging struct {
+	TeamID       uint                   `json:"teamID"`
+	TeamNumber   string                 `json:"teamNumber"`
+	LeaderNames  []string               `json:"leaderNames"`
+	Rows         []TeamMaterialAgingRow `json:"rows"`
+	Value        decimal.Decimal        `json:"value"`
+	OverdueValue decimal.Decimal        `json:"overdueValue"`
+}
+
+type TeamMaterialAgingReport struct {
+	Date  time.Time           `json:"date"`
+	Days  int                 `json:"days"`
+	Teams []TeamMaterialAging `json:"teams"`
+}
+
+type TeamAccountabilityStatementCreate struct {
+	TeamID uint   `json:"teamID"`
+	Reason string `json:"reason"`
+	Format string `json:"format"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type TeamMemberView struct {
+	ID              uint      `json:"id"`
+	TeamID          uint      `json:"teamID"`
+	TeamNumber      string    `json:"teamNumber"`
+	WorkerID        uint      `json:"workerID"`
+	WorkerName      string    `json:"workerName"`
+	CompanyWorkerID string    `json:"companyWorkerID"`
+	JobTitle        string    `json:"jobTitle"`
+	EffectiveFrom   time.Time `json:"effectiveFrom"`
+	EffectiveTo     time.Time `json:"effectiveTo"`
+}
+
+type TeamMemberAdd struct {
+	TeamID        uint      `json:"teamID"`
+	WorkerIDs     []uint    `json:"workerIDs"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+// Worker leaves the current team the day before the date and joins the new team on the date
+type TeamMemberTransfer struct {
+	WorkerID uint      `json:"workerID"`
+	ToTeamID uint      `json:"toTeamID"`
+	Date     time.Time `json:"date"`
+}
+
+// Last day of the worker in the team
+type TeamMemberEnd struct {
+	ID          uint      `json:"id"`
+	EffectiveTo time.Time `json:"effectiveTo"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+type TimesheetFilter struct {
+	ProjectID  uint
+	TeamID     uint
+	JobTitle   string
+	DateFrom   time.Time
+	DateTo     time.Time
+	ShiftHours float64
+}
+
+type TimesheetQueryResult struct {
+	WorkerID        uint
+	WorkerName      string
+	CompanyWorkerID string
+	JobTitle        string
+	Start           time.Time
+	End             time.Time
+}
+
+type TimesheetDay struct {
+	Date     time.Time `json:"date"`
+	IsDayOff bool      `json:"isDayOff"`
+	Holiday  string    `json:"holiday"`
+}
+
+// Hours of the worker in one day of the month, MissingPunch is set when
+// the day has only the coming punch
+type TimesheetCell struct {
+	Hours        float64 `json:"hours"`
+	Overtime     float64 `json:"overtime"`
+	MissingPunch bool    `json:"missingPunch"`
+}
+
+type TimesheetRow struct {
+	WorkerID        uint            `json:"workerID"`
+	WorkerName      string          `json:"workerName"`
+	CompanyWorkerID string          `json:"companyWorkerID"`
+	JobTitle        string          `json:"jobTitle"`
+	Cells           []TimesheetCell `json:"cells"`
+	DaysWorked      int             `json:"daysWorked"`
+	TotalHours      float64         `json:"totalHours"`
+	OvertimeHours   float64         `json:"overtimeHours"`
+	DayOffHours     float64         `json:"dayOffHours"`
+	MissingPunches  int             `json:"missingPunches"`
+}
+
+type Timesheet struct {
+	ProjectName   string         `json:"projectName"`
+	DateFrom      time.Time      `json:"dateFrom"`
+	DateTo        time.Time      `json:"dateTo"`
+	ShiftHours    float64        `json:"shiftHours"`
+	Days          []TimesheetDay `json:"days"`
+	Rows          []TimesheetRow `json:"rows"`
+	TotalHours    float64        `json:"totalHours"`
+	OvertimeHours float64        `json:"overtimeHours"`
+	DayOffHours   float64        `json:"dayOffHours"`
+}
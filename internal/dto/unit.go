@@ -0,0 +1,11 @@
+package dto
+
+type MaterialUnitView struct {
+	ID            uint    `json:"id"`
+	MaterialID    uint    `json:"materialID"`
+	BaseUnit      string  `json:"baseUnit"`
+	UnitID        uint    `json:"unitID"`
+	UnitName      string  `json:"unitName"`
+	UnitShortName string  `json:"unitShortName"`
+	Factor        float64 `json:"factor"`
+}
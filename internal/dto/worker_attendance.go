@@ -8,4 +8,18 @@ type WorkerAttendancePaginated struct {
 	CompanyWorkerID string    `json:"companyWorkerID"`
 	Start           time.Time `json:"start"`
 	End             time.Time `json:"end"`
+	TeamNumber      string    `json:"teamNumber"`
+}
+
+type WorkerAttendanceImportResult struct {
+	Imported         int      `json:"imported"`
+	Duplicates       int      `json:"duplicates"`
+	UnknownWorkerIDs []string `json:"unknownWorkerIDs"`
+	Warnings         []string `json:"warnings"`
+}
+
+// One punch of the worker read from the file of attendance terminal
+type WorkerAttendancePunch struct {
+	CompanyWorkerID string
+	Date            time.Time
 }
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// Workers of the project that share the same CompanyWorkerID, normalised name or phone.
+// MatchedBy is "companyWorkerID", "name" or "phone"
+type WorkerDuplicateGroup struct {
+	MatchedBy string                     `json:"matchedBy"`
+	Value     string                     `json:"value"`
+	Workers   []WorkerDuplicateCandidate `json:"workers"`
+}
+
+type WorkerDuplicateCandidate struct {
+	ID                uint   `json:"id"`
+	Name              string `json:"name"`
+	CompanyWorkerID   string `json:"companyWorkerID"`
+	JobTitleInProject string `json:"jobTitleInProject"`
+	MobileNumber      string `json:"mobileNumber"`
+	References        int64  `json:"references"`
+}
+
+type WorkerReferenceCount struct {
+	WorkerID uint
+	Amount   int64
+}
+
+type WorkerMergeRequest struct {
+	KeptWorkerID    uint   `json:"keptWorkerID"`
+	MergedWorkerIDs []uint `json:"mergedWorkerIDs"`
+}
+
+type WorkerMergeView struct {
+	ID               uint      `json:"id"`
+	KeptWorkerID     uint      `json:"keptWorkerID"`
+	KeptWorkerName   string    `json:"keptWorkerName"`
+	MergedWorkerID   uint      `json:"mergedWorkerID"`
+	MergedWorkerName string    `json:"mergedWorkerName"`
+	CompanyWorkerID  string    `json:"companyWorkerID"`
+	MergedByUsername string    `json:"mergedByUsername"`
+	MergedAt         time.Time `json:"mergedAt"`
+	UndoneAt         time.Time `json:"undoneAt"`
+	ReferenceCount   int64     `json:"referenceCount"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type WorkerQualificationView struct {
+	ID                    uint      `json:"id"`
+	WorkerID              uint      `json:"workerID"`
+	WorkerName            string    `json:"workerName"`
+	CompanyWorkerID       string    `json:"companyWorkerID"`
+	QualificationTypeID   uint      `json:"qualificationTypeID"`
+	QualificationTypeName string    `json:"qualificationTypeName"`
+	Number                string    `json:"number"`
+	Grade                 string    `json:"grade"`
+	IssuedAt              time.Time `json:"issuedAt"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	DocumentName          string    `json:"documentName"`
+	DaysLeft              int       `json:"daysLeft"`
+}
+
+// Required qualification that the worker does not hold or that has expired
+type WorkerClearanceIssue struct {
+	WorkerID              uint
+	WorkerName            string
+	QualificationTypeName string
+	ExpiresAt             time.Time
+}
+
+type WorkerQualificationDocument struct {
+	FilePath string
+	FileName string
+}
@@ -174,13 +174,17 @@ func (repo *invoiceMaterialsRepository) GetDataForReport(invoiceID uint, invoice
         material_costs.cost_m19 as material_cost_m19,
         material_costs.cost_with_customer as material_cost_with_customer,
         invoice_materials.amount as invoice_material_amount,
-        invoice_materials.notes as invoice_material_notes
+        invoice_materials.notes as invoice_material_notes,
+        COALESCE(units.name, '') as entered_unit_name,
+        invoice_materials.unit_amount as unit_amount
       FROM invoice_materials
       INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
       INNER JOIN materials ON materials.id = material_costs.material_id
+      LEFT JOIN material_units ON material_units.id = invoice_materials.material_unit_id
+      LEFT JOIN units ON units.id = material_units.unit_id
       WHERE
-        invoice_type = ? AND
-        invoice_id = ?;
+        invoice_materials.invoice_type = ? AND
+        invoice_materials.invoice_id = ?;
     `, invoiceType, invoiceID).Scan(&result).Error
   
   return result, err
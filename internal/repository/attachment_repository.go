@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"backend-v2/model"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) IAttachmentRepository {
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+type IAttachmentRepository interface {
+	GetByID(id uint) (model.Attachment, error)
+	GetByTarget(attachedTo string, attachedToID uint) ([]model.Attachment, error)
+	CreateInBatches(data []model.Attachment) ([]model.Attachment, error)
+	Delete(id uint) error
+}
+
+func (repo *attachmentRepository) GetByID(id uint) (model.Attachment, error) {
+	data := model.Attachment{}
+	err := repo.db.Raw(`SELECT * FROM attachments WHERE id = ?`, id).Scan(&data).Error
+	return data, err
+}
+
+func (repo *attachmentRepository) GetByTarget(attachedTo string, attachedToID uint) ([]model.Attachment, error) {
+	data := []model.Attachment{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM attachments
+    WHERE
+      attached_to = ? AND
+      attached_to_id = ?
+    ORDER BY uploaded_at DESC, id DESC
+    `, attachedTo, attachedToID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *attachmentRepository) CreateInBatches(data []model.Attachment) ([]model.Attachment, error) {
+	err := repo.db.CreateInBatches(&data, 10).Error
+	return data, err
+}
+
+func (repo *attachmentRepository) Delete(id uint) error {
+	return repo.db.Delete(&model.Attachment{}, "id = ?", id).Error
+}
+
+// Paths of the files of the attachments that match the condition, used before the
+// attachment rows are deleted together with the object or the object invoice
+func attachmentFilePaths(tx *gorm.DB, query string, args ...interface{}) ([]string, error) {
+	attachments := []model.Attachment{}
+	if err := tx.Where(query, args...).Find(&attachments).Error; err != nil {
+		return []string{}, err
+	}
+
+	paths := []string{}
+	for _, attachment := range attachments {
+		paths = append(paths, attachment.FilePath)
+		if attachment.ThumbnailPath != "" {
+			paths = append(paths, attachment.ThumbnailPath)
+		}
+	}
+
+	return paths, nil
+}
+
+// Files are removed only after the transaction that deleted their rows is committed
+func removeAttachmentFiles(paths []string) {
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
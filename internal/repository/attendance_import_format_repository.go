@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type attendanceImportFormatRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendanceImportFormatRepository(db *gorm.DB) IAttendanceImportFormatRepository {
+	return &attendanceImportFormatRepository{
+		db: db,
+	}
+}
+
+type IAttendanceImportFormatRepository interface {
+	GetAll(projectID uint) ([]model.AttendanceImportFormat, error)
+	GetByCode(projectID uint, code string) (model.AttendanceImportFormat, error)
+	Create(data model.AttendanceImportFormat) (model.AttendanceImportFormat, error)
+	Delete(projectID uint, code string) error
+}
+
+func (repo *attendanceImportFormatRepository) GetAll(projectID uint) ([]model.AttendanceImportFormat, error) {
+	data := []model.AttendanceImportFormat{}
+	err := repo.db.Order("id").Find(&data, "project_id = ?", projectID).Error
+	return data, err
+}
+
+func (repo *attendanceImportFormatRepository) GetByCode(projectID uint, code string) (model.AttendanceImportFormat, error) {
+	data := model.AttendanceImportFormat{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM attendance_import_formats
+    WHERE
+      project_id = ? AND
+      code = ?
+    `, projectID, code).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *attendanceImportFormatRepository) Create(data model.AttendanceImportFormat) (model.AttendanceImportFormat, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *attendanceImportFormatRepository) Delete(projectID uint, code string) error {
+	return repo.db.Delete(&model.AttendanceImportFormat{}, "project_id = ? AND code = ?", projectID, code).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type completedWorksRepository struct {
+	db *gorm.DB
+}
+
+func NewCompletedWorksRepository(db *gorm.DB) ICompletedWorksRepository {
+	return &completedWorksRepository{
+		db: db,
+	}
+}
+
+type ICompletedWorksRepository interface {
+	GetConfirmedOperations(filter dto.CompletedWorksFilter) ([]dto.CompletedWorksOperationQueryResult, error)
+}
+
+// Only the invoices confirmed by operator are paid by the customer,
+// their amounts are the ones written in the correction
+func (repo *completedWorksRepository) GetConfirmedOperations(filter dto.CompletedWorksFilter) ([]dto.CompletedWorksOperationQueryResult, error) {
+	data := []dto.CompletedWorksOperationQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      operations.id as operation_id,
+      operations.code as code,
+      operations.name as name,
+      SUM(invoice_operations.amount) as amount,
+      operations.cost_with_customer as price,
+      SUM(invoice_operations.amount * operations.cost_with_customer) as "sum"
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operations ON operations.id = invoice_operations.operation_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      invoice_objects.confirmed_by_operator = true AND
+      invoice_operations.invoice_type = 'object-correction' AND
+      (? OR invoice_objects.date_of_invoice >= ?) AND
+      (? OR invoice_objects.date_of_invoice <= ?)
+    GROUP BY objects.id, objects.name, objects.type, operations.id, operations.code, operations.name, operations.cost_with_customer
+    HAVING SUM(invoice_operations.amount) <> 0
+    ORDER BY objects.name, objects.id, operations.code
+    `,
+		filter.ProjectID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"backend-v2/pkg/utils"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type handoverActRepository struct {
+	db *gorm.DB
+}
+
+func NewHandoverActRepository(db *gorm.DB) IHandoverActRepository {
+	return &handoverActRepository{
+		db: db,
+	}
+}
+
+type IHandoverActRepository interface {
+	GetAll(projectID uint) ([]dto.HandoverActView, error)
+	GetByObjectID(objectID uint) (model.HandoverAct, error)
+	Create(data model.HandoverAct) (model.HandoverAct, error)
+	GetMaterials(objectID uint) ([]dto.HandoverActMaterialQueryResult, error)
+	GetSerialNumbers(objectID uint) ([]dto.HandoverActSerialNumberQueryResult, error)
+	GetOperations(objectID uint) ([]dto.HandoverActOperationQueryResult, error)
+	GetWorkPeriod(objectID uint) (dto.HandoverActWorkPeriod, error)
+	GetTeamNumbers(objectID uint) ([]string, error)
+	GetSupervisorNames(objectID uint) ([]string, error)
+}
+
+func (repo *handoverActRepository) GetAll(projectID uint) ([]dto.HandoverActView, error) {
+	data := []dto.HandoverActView{}
+	err := repo.db.Raw(`
+    SELECT
+      handover_acts.id as id,
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      handover_acts.number as number,
+      handover_acts.date as date
+    FROM handover_acts
+      INNER JOIN objects ON objects.id = handover_acts.object_id
+    WHERE handover_acts.project_id = ?
+    ORDER BY handover_acts.id DESC
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetByObjectID(objectID uint) (model.HandoverAct, error) {
+	data := model.HandoverAct{}
+	err := repo.db.Raw(`SELECT * FROM handover_acts WHERE object_id = ?`, objectID).Scan(&data).Error
+	return data, err
+}
+
+// Number of the act follows the greatest number of the project, so the numbers of the
+// deleted acts are not given again. The table is locked until the act is created so
+// that concurrent generation neither repeats a number nor creates two acts of one object
+func (repo *handoverActRepository) Create(data model.HandoverAct) (model.HandoverAct, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`LOCK TABLE handover_acts IN SHARE ROW EXCLUSIVE MODE`).Error; err != nil {
+			return err
+		}
+
+		existing := model.HandoverAct{}
+		if err := tx.Raw(`SELECT * FROM handover_acts WHERE object_id = ?`, data.ObjectID).Scan(&existing).Error; err != nil {
+			return err
+		}
+
+		if existing.ID != 0 {
+			data = existing
+			return nil
+		}
+
+		var lastNumber int64
+		err := tx.Raw(`
+      SELECT COALESCE(MAX(NULLIF(regexp_replace(split_part(handover_acts.number, '-', 3), '\D', '', 'g'), '')::bigint), 0)
+      FROM handover_acts
+      WHERE handover_acts.project_id = ?
+      `, data.ProjectID).Scan(&lastNumber).Error
+		if err != nil {
+			return err
+		}
+
+		data.Number = utils.UniqueCodeGeneration("АП", lastNumber+1, data.ProjectID)
+		return tx.Create(&data).Error
+	})
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetMaterials(objectID uint) ([]dto.HandoverActMaterialQueryResult, error) {
+	data := []dto.HandoverActMaterialQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      materials.id as material_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      SUM(invoice_materials.amount) as amount
+    FROM invoice_objects
+      INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_objects.id
+      INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+      INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      invoice_objects.object_id = ? AND
+      %s
+    GROUP BY materials.id, materials.code, materials.name, materials.unit
+    HAVING SUM(invoice_materials.amount) > 0
+    ORDER BY materials.code
+    `, confirmedInvoiceAmounts("invoice_materials")), objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetSerialNumbers(objectID uint) ([]dto.HandoverActSerialNumberQueryResult, error) {
+	data := []dto.HandoverActSerialNumberQueryResult{}
+	err := repo.db.Raw(`
+    SELECT DISTINCT
+      material_costs.material_id as material_id,
+      serial_numbers.code as code
+    FROM invoice_objects
+      INNER JOIN serial_number_movements ON serial_number_movements.invoice_id = invoice_objects.id
+      INNER JOIN serial_numbers ON serial_numbers.id = serial_number_movements.serial_number_id
+      INNER JOIN material_costs ON material_costs.id = serial_numbers.material_cost_id
+    WHERE
+      invoice_objects.object_id = ? AND
+      serial_number_movements.invoice_type = 'object'
+    ORDER BY serial_numbers.code
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetOperations(objectID uint) ([]dto.HandoverActOperationQueryResult, error) {
+	data := []dto.HandoverActOperationQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      operations.id as operation_id,
+      operations.code as code,
+      operations.name as name,
+      SUM(invoice_operations.amount) as amount
+    FROM invoice_objects
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operations ON operations.id = invoice_operations.operation_id
+    WHERE
+      invoice_objects.object_id = ? AND
+      %s
+    GROUP BY operations.id, operations.code, operations.name
+    HAVING SUM(invoice_operations.amount) > 0
+    ORDER BY operations.code
+    `, confirmedInvoiceAmounts("invoice_operations")), objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetWorkPeriod(objectID uint) (dto.HandoverActWorkPeriod, error) {
+	data := dto.HandoverActWorkPeriod{}
+	err := repo.db.Raw(`
+    SELECT
+      MIN(invoice_objects.date_of_invoice) as start,
+      MAX(invoice_objects.date_of_invoice) as "end"
+    FROM invoice_objects
+    WHERE invoice_objects.object_id = ?
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetTeamNumbers(objectID uint) ([]string, error) {
+	data := []string{}
+	err := repo.db.Raw(`
+    SELECT teams.number
+    FROM object_teams
+      INNER JOIN teams ON teams.id = object_teams.team_id
+    WHERE object_teams.object_id = ?
+    ORDER BY teams.number
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *handoverActRepository) GetSupervisorNames(objectID uint) ([]string, error) {
+	data := []string{}
+	err := repo.db.Raw(`
+    SELECT workers.name
+    FROM object_supervisors
+      INNER JOIN workers ON workers.id = object_supervisors.supervisor_worker_id
+    WHERE object_supervisors.object_id = ?
+    ORDER BY workers.name
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"backend-v2/internal/dto"
 	"backend-v2/model"
+	"fmt"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -30,6 +31,7 @@ type IInvoiceCorrectionRepository interface {
 	GetOperationsByInvoiceObjectID(id uint) ([]dto.InvoiceCorrectionOperationsData, error)
 	GetTeamsInInvoiceCorrection(projectID uint) ([]dto.DataForSelect[uint], error)
 	GetObjectsInInvoiceCorrection(projectID uint) ([]dto.DataForSelect[uint], error)
+	GetInvoiceMaterialLinesByInvoiceObjectID(id uint) ([]dto.InvoiceCorrectionMaterialLine, error)
 }
 
 func (repo *invoiceCorrectionRepository) GetPaginatedFiltered(page, limit int, filter dto.InvoiceCorrectionPaginatedParamters) ([]dto.InvoiceCorrectionPaginated, error) {
@@ -143,6 +145,27 @@ func (repo *invoiceCorrectionRepository) Create(data dto.InvoiceCorrectionCreate
 			return err
 		}
 
+		if len(data.OperatorErrors) != 0 {
+			if err := tx.CreateInBatches(&data.OperatorErrors, 15).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(data.MaterialLotMovements) != 0 {
+			for index := range data.MaterialLotMovements {
+				data.MaterialLotMovements[index].InvoiceID = result.ID
+				data.MaterialLotMovements[index].InvoiceType = "object-correction"
+			}
+
+			if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := moveMaterialLots(tx, "object-correction", result.ID, "team", result.TeamID, "object", result.ObjectID); err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -304,3 +327,32 @@ func (repo *invoiceCorrectionRepository) GetObjectsInInvoiceCorrection(projectID
 
 	return objects, err
 }
+
+func (repo *invoiceCorrectionRepository) GetInvoiceMaterialLinesByInvoiceObjectID(id uint) ([]dto.InvoiceCorrectionMaterialLine, error) {
+	data := []dto.InvoiceCorrectionMaterialLine{}
+	err := repo.db.Raw(`
+    SELECT
+      invoice_materials.id AS invoice_material_id,
+      material_costs.material_id AS material_id,
+      invoice_materials.material_cost_id AS material_cost_id,
+      invoice_materials.amount AS amount
+    FROM invoice_materials
+    INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+    WHERE
+      invoice_materials.invoice_type = 'object' AND
+      invoice_materials.invoice_id = ?
+    ORDER BY invoice_materials.id
+    `, id).Scan(&data).Error
+
+	return data, err
+}
+
+// Condition of the object invoice amounts stored in the given table that are
+// counted as done. Amounts written by operator in the correction replace the
+// amounts of the team once the invoice is confirmed
+func confirmedInvoiceAmounts(amountsTable string) string {
+	return fmt.Sprintf(`(
+        (invoice_objects.confirmed_by_operator = true AND %[1]s.invoice_type = 'object-correction') OR
+        (invoice_objects.confirmed_by_operator = false AND %[1]s.invoice_type = 'object')
+      )`, amountsTable)
+}
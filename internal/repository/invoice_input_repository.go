@@ -167,6 +167,10 @@ func (repo *invoiceInputRespository) Create(data dto.InvoiceInputCreateQueryData
 			return err
 		}
 
+		if err := createInputMaterialLots(tx, result.ID, data.MaterialLots, data.MaterialLotMovements); err != nil {
+			return err
+		}
+
 		err := tx.Exec(`
       UPDATE invoice_counts
       SET count = count + 1
@@ -231,6 +235,14 @@ func (repo *invoiceInputRespository) Update(data dto.InvoiceInputCreateQueryData
 			return err
 		}
 
+		if err := deleteInputMaterialLots(tx, result.ID); err != nil {
+			return err
+		}
+
+		if err := createInputMaterialLots(tx, result.ID, data.MaterialLots, data.MaterialLotMovements); err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -248,10 +260,52 @@ func (repo *invoiceInputRespository) Delete(id uint) error {
 			return err
 		}
 
+		if err := deleteInputMaterialLots(tx, id); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
 
+// Lots are created by the input invoice, so movement of each lot is matched to the lot by index
+func createInputMaterialLots(tx *gorm.DB, invoiceID uint, materialLots []model.MaterialLot, materialLotMovements []model.MaterialLotMovement) error {
+	if err := tx.CreateInBatches(&materialLots, 15).Error; err != nil {
+		return err
+	}
+
+	for index := range materialLotMovements {
+		materialLotMovements[index].MaterialLotID = materialLots[index].ID
+		materialLotMovements[index].InvoiceID = invoiceID
+	}
+
+	return tx.CreateInBatches(&materialLotMovements, 15).Error
+}
+
+// Movements are deleted before the lots they point to
+func deleteInputMaterialLots(tx *gorm.DB, invoiceID uint) error {
+	materialLotIDs := []uint{}
+	if err := tx.Raw(`
+    SELECT material_lot_movements.material_lot_id
+    FROM material_lot_movements
+    WHERE
+      material_lot_movements.invoice_type = 'input' AND
+      material_lot_movements.invoice_id = ?
+    `, invoiceID).Scan(&materialLotIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&model.MaterialLotMovement{}, "invoice_type = 'input' AND invoice_id = ?", invoiceID).Error; err != nil {
+		return err
+	}
+
+	if len(materialLotIDs) == 0 {
+		return nil
+	}
+
+	return tx.Delete(&model.MaterialLot{}, "id IN ?", materialLotIDs).Error
+}
+
 func (repo *invoiceInputRespository) Count(filter dto.InvoiceInputSearchParameters) (int64, error) {
 	var count int64
 	var err error
@@ -429,6 +483,10 @@ func (repo *invoiceInputRespository) Confirmation(data dto.InvoiceInputConfirmat
 			return err
 		}
 
+		if err := moveMaterialLots(tx, "input", data.InvoiceData.ID, "", 0, "warehouse", 0); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -89,6 +89,22 @@ func (repo *invoiceObjectRepository) Create(data dto.InvoiceObjectCreateQueryDat
 			}
 		}
 
+		for index := range data.MaterialLotMovements {
+			data.MaterialLotMovements[index].InvoiceID = invoice.ID
+		}
+
+		if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+			return err
+		}
+
+		for index := range data.BOMDeviations {
+			data.BOMDeviations[index].InvoiceObjectID = invoice.ID
+		}
+
+		if err := tx.CreateInBatches(&data.BOMDeviations, 15).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -96,7 +112,33 @@ func (repo *invoiceObjectRepository) Create(data dto.InvoiceObjectCreateQueryDat
 }
 
 func (repo *invoiceObjectRepository) Delete(id uint) error {
-	err := repo.db.Delete(&model.InvoiceObject{}, "id = ?", id).Error
+	attachmentFiles := []string{}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.InvoiceObjectBOMDeviation{}, "invoice_object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		files, err := attachmentFilePaths(tx, "attached_to = 'invoice-object' AND attached_to_id = ?", id)
+		if err != nil {
+			return err
+		}
+		attachmentFiles = files
+
+		if err := tx.Delete(&model.Attachment{}, "attached_to = 'invoice-object' AND attached_to_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.InvoiceObject{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		removeAttachmentFiles(attachmentFiles)
+	}
+
 	return err
 }
 
@@ -134,6 +134,14 @@ func (repo *invoiceOutputRepository) Create(data dto.InvoiceOutputCreateQueryDat
 			return err
 		}
 
+		for index := range data.MaterialLotMovements {
+			data.MaterialLotMovements[index].InvoiceID = result.ID
+		}
+
+		if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+			return err
+		}
+
 		err := tx.Exec(`
       UPDATE invoice_counts
       SET count = count + 1
@@ -183,6 +191,18 @@ func (repo *invoiceOutputRepository) Update(data dto.InvoiceOutputCreateQueryDat
 			return err
 		}
 
+		if err = tx.Delete(model.MaterialLotMovement{}, "invoice_id = ? AND invoice_type='output'", result.ID).Error; err != nil {
+			return err
+		}
+
+		for index := range data.MaterialLotMovements {
+			data.MaterialLotMovements[index].InvoiceID = result.ID
+		}
+
+		if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -199,6 +219,10 @@ func (repo *invoiceOutputRepository) Delete(id uint) error {
 			return err
 		}
 
+		if err := tx.Delete(&model.MaterialLotMovement{}, "invoice_type = 'output' AND invoice_id = ?", id).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -407,6 +431,10 @@ func (repo *invoiceOutputRepository) Confirmation(data dto.InvoiceOutputConfirma
 			return err
 		}
 
+		if err := moveMaterialLots(tx, "output", data.InvoiceData.ID, "warehouse", 0, "team", data.InvoiceData.TeamID); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -472,10 +500,14 @@ func (repo *invoiceOutputRepository) GetMaterialDataForReport(invoiceID uint) ([
       materials.unit as material_unit,
       material_costs.cost_m19 as material_cost_m19,
       invoice_materials.notes as notes,
-      invoice_materials.amount as amount
+      invoice_materials.amount as amount,
+      COALESCE(units.name, '') as entered_unit_name,
+      invoice_materials.unit_amount as unit_amount
     FROM invoice_materials
     INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
     INNER JOIN materials ON materials.id = material_costs.material_id
+    LEFT JOIN material_units ON material_units.id = invoice_materials.material_unit_id
+    LEFT JOIN units ON units.id = material_units.unit_id
     WHERE 
       invoice_materials.invoice_type = 'output' AND
       invoice_materials.invoice_id = ?;
@@ -137,6 +137,14 @@ func (repo *invoiceReturnRepository) Create(data dto.InvoiceReturnCreateQueryDat
 			return err
 		}
 
+		for index := range data.MaterialLotMovements {
+			data.MaterialLotMovements[index].InvoiceID = result.ID
+		}
+
+		if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+			return err
+		}
+
 		err := tx.Exec(`
       UPDATE invoice_counts
       SET count = count + 1
@@ -185,6 +193,18 @@ func (repo *invoiceReturnRepository) Update(data dto.InvoiceReturnCreateQueryDat
 			return err
 		}
 
+		if err := tx.Delete(model.MaterialLotMovement{}, "invoice_id = ? AND invoice_type='return'", result.ID).Error; err != nil {
+			return err
+		}
+
+		for index := range data.MaterialLotMovements {
+			data.MaterialLotMovements[index].InvoiceID = result.ID
+		}
+
+		if err := tx.CreateInBatches(&data.MaterialLotMovements, 15).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -201,6 +221,10 @@ func (repo *invoiceReturnRepository) Delete(id uint) error {
 			return err
 		}
 
+		if err := tx.Delete(&model.MaterialLotMovement{}, "invoice_type = 'return' AND invoice_id = ?", id).Error; err != nil {
+			return err
+		}
+
 		return nil
 
 	})
@@ -404,6 +428,10 @@ func (repo *invoiceReturnRepository) Confirmation(data dto.InvoiceReturnConfirmD
 			return err
 		}
 
+		if err := moveMaterialLots(tx, "return", data.Invoice.ID, data.Invoice.ReturnerType, data.Invoice.ReturnerID, data.Invoice.AcceptorType, data.Invoice.AcceptorID); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type kpiRepository struct {
+	db *gorm.DB
+}
+
+func NewKPIRepository(db *gorm.DB) IKPIRepository {
+	return &kpiRepository{
+		db: db,
+	}
+}
+
+// Every indicator is grouped by the team or by the supervisor (filter.GroupBy)
+// and by the period of the chart (filter.Interval)
+type IKPIRepository interface {
+	GetOperations(filter dto.KPIFilter) ([]dto.KPIOperationsQueryResult, error)
+	GetObjectsFinished(filter dto.KPIFilter) ([]dto.KPIQueryResult, error)
+	GetNormativeMaterials(filter dto.KPIFilter) ([]dto.KPIMaterialQueryResult, error)
+	GetActualMaterials(filter dto.KPIFilter) ([]dto.KPIMaterialQueryResult, error)
+	GetCorrections(filter dto.KPIFilter) ([]dto.KPICorrectionQueryResult, error)
+	GetLosses(filter dto.KPIFilter) ([]dto.KPIQueryResult, error)
+	GetDaysToInstallation(filter dto.KPIFilter) ([]dto.KPIQueryResult, error)
+}
+
+// Operations of the invoices confirmed by operator in the amounts written in the correction
+func (repo *kpiRepository) GetOperations(filter dto.KPIFilter) ([]dto.KPIOperationsQueryResult, error) {
+	data := []dto.KPIOperationsQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      SUM(amount) as amount,
+      SUM(value) as value
+    FROM (
+      SELECT
+        CASE WHEN ? = 'team' THEN invoice_objects.team_id ELSE invoice_objects.supervisor_worker_id END as entity_id,
+        date_trunc(?, invoice_objects.date_of_invoice) as period,
+        invoice_operations.amount as amount,
+        (invoice_operations.amount * operations.cost_prime)::float as value
+      FROM invoice_objects
+        INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+        INNER JOIN operations ON operations.id = invoice_operations.operation_id
+      WHERE
+        invoice_objects.project_id = ? AND
+        invoice_objects.confirmed_by_operator = true AND
+        invoice_operations.invoice_type = 'object-correction' AND
+        invoice_objects.date_of_invoice >= ? AND
+        invoice_objects.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period
+    ORDER BY entity_id, period
+    `,
+		filter.GroupBy, filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Objects that reached the "installed" status, counted for every team and supervisor of the object
+func (repo *kpiRepository) GetObjectsFinished(filter dto.KPIFilter) ([]dto.KPIQueryResult, error) {
+	data := []dto.KPIQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      COUNT(DISTINCT object_id) as value
+    FROM (
+      SELECT
+        object_entities.entity_id as entity_id,
+        date_trunc(?, object_status_transitions.date) as period,
+        object_status_transitions.object_id as object_id
+      FROM object_status_transitions
+        INNER JOIN (
+          SELECT object_id, team_id as entity_id, 'team' as group_by FROM object_teams
+          UNION ALL
+          SELECT object_id, supervisor_worker_id as entity_id, 'supervisor' as group_by FROM object_supervisors
+        ) AS object_entities ON object_entities.object_id = object_status_transitions.object_id
+      WHERE
+        object_status_transitions.project_id = ? AND
+        object_status_transitions.to_status = 'installed' AND
+        object_status_transitions.date >= ? AND
+        object_status_transitions.date <= ? AND
+        object_entities.group_by = ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period
+    ORDER BY entity_id, period
+    `,
+		filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.GroupBy,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Installed amount of every operation multiplied by its bill of materials
+func (repo *kpiRepository) GetNormativeMaterials(filter dto.KPIFilter) ([]dto.KPIMaterialQueryResult, error) {
+	data := []dto.KPIMaterialQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      material_id,
+      SUM(amount) as amount
+    FROM (
+      SELECT
+        CASE WHEN ? = 'team' THEN invoice_objects.team_id ELSE invoice_objects.supervisor_worker_id END as entity_id,
+        date_trunc(?, invoice_objects.date_of_invoice) as period,
+        operation_bom_items.material_id as material_id,
+        invoice_operations.amount * operation_bom_items.amount as amount
+      FROM invoice_objects
+        INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+        INNER JOIN operation_bom_items ON operation_bom_items.operation_id = invoice_operations.operation_id
+      WHERE
+        invoice_objects.project_id = ? AND
+        invoice_objects.confirmed_by_operator = true AND
+        invoice_operations.invoice_type = 'object-correction' AND
+        invoice_objects.date_of_invoice >= ? AND
+        invoice_objects.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period, material_id
+    `,
+		filter.GroupBy, filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *kpiRepository) GetActualMaterials(filter dto.KPIFilter) ([]dto.KPIMaterialQueryResult, error) {
+	data := []dto.KPIMaterialQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      material_id,
+      SUM(amount) as amount
+    FROM (
+      SELECT
+        CASE WHEN ? = 'team' THEN invoice_objects.team_id ELSE invoice_objects.supervisor_worker_id END as entity_id,
+        date_trunc(?, invoice_objects.date_of_invoice) as period,
+        material_costs.material_id as material_id,
+        invoice_materials.amount as amount
+      FROM invoice_objects
+        INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_objects.id
+        INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+      WHERE
+        invoice_objects.project_id = ? AND
+        invoice_objects.confirmed_by_operator = true AND
+        invoice_materials.invoice_type = 'object-correction' AND
+        invoice_objects.date_of_invoice >= ? AND
+        invoice_objects.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period, material_id
+    `,
+		filter.GroupBy, filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Confirmed object invoices and the ones where operator found mismatches during the correction
+func (repo *kpiRepository) GetCorrections(filter dto.KPIFilter) ([]dto.KPICorrectionQueryResult, error) {
+	data := []dto.KPICorrectionQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      COUNT(*) as total,
+      COUNT(*) FILTER (WHERE corrected) as corrected
+    FROM (
+      SELECT
+        CASE WHEN ? = 'team' THEN invoice_objects.team_id ELSE invoice_objects.supervisor_worker_id END as entity_id,
+        date_trunc(?, invoice_objects.date_of_invoice) as period,
+        EXISTS (
+          SELECT 1
+          FROM operator_error_founds
+          WHERE operator_error_founds.invoice_object_id = invoice_objects.id
+        ) as corrected
+      FROM invoice_objects
+      WHERE
+        invoice_objects.project_id = ? AND
+        invoice_objects.confirmed_by_operator = true AND
+        invoice_objects.date_of_invoice >= ? AND
+        invoice_objects.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period
+    ORDER BY entity_id, period
+    `,
+		filter.GroupBy, filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Confirmed loss write-offs in M19 prices, losses of the team for the teams
+// and losses on the objects for the supervisors of the object
+func (repo *kpiRepository) GetLosses(filter dto.KPIFilter) ([]dto.KPIQueryResult, error) {
+	data := []dto.KPIQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      SUM(value) as value
+    FROM (
+      SELECT
+        CASE
+          WHEN invoice_write_offs.write_off_type = 'loss-team' THEN invoice_write_offs.write_off_location_id
+          ELSE object_supervisors.supervisor_worker_id
+        END as entity_id,
+        date_trunc(?, invoice_write_offs.date_of_invoice) as period,
+        (invoice_materials.amount * material_costs.cost_m19)::float as value
+      FROM invoice_write_offs
+        INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_write_offs.id
+        INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+        LEFT JOIN object_supervisors ON
+          invoice_write_offs.write_off_type = 'loss-object' AND
+          object_supervisors.object_id = invoice_write_offs.write_off_location_id
+      WHERE
+        invoice_write_offs.project_id = ? AND
+        invoice_write_offs.confirmation = true AND
+        invoice_materials.invoice_type = 'writeoff' AND
+        invoice_write_offs.write_off_type = CASE WHEN ? = 'team' THEN 'loss-team' ELSE 'loss-object' END AND
+        invoice_write_offs.date_of_invoice >= ? AND
+        invoice_write_offs.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE
+      entity_id IS NOT NULL AND
+      (nullif(?, 0) IS NULL OR entity_id = ?)
+    GROUP BY entity_id, period
+    ORDER BY entity_id, period
+    `,
+		filter.Interval,
+		filter.ProjectID, filter.GroupBy, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Days between the latest confirmed output of the material to the team
+// and the object invoice where the team installed it
+func (repo *kpiRepository) GetDaysToInstallation(filter dto.KPIFilter) ([]dto.KPIQueryResult, error) {
+	data := []dto.KPIQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      entity_id,
+      period,
+      AVG(days) as value
+    FROM (
+      SELECT
+        CASE WHEN ? = 'team' THEN invoice_objects.team_id ELSE invoice_objects.supervisor_worker_id END as entity_id,
+        date_trunc(?, invoice_objects.date_of_invoice) as period,
+        invoice_objects.date_of_invoice::date - last_output.date_of_invoice::date as days
+      FROM invoice_objects
+        INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_objects.id
+        INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+        INNER JOIN LATERAL (
+          SELECT invoice_outputs.date_of_invoice
+          FROM invoice_outputs
+            INNER JOIN invoice_materials AS output_materials ON output_materials.invoice_id = invoice_outputs.id
+            INNER JOIN material_costs AS output_costs ON output_costs.id = output_materials.material_cost_id
+          WHERE
+            invoice_outputs.confirmation = true AND
+            output_materials.invoice_type = 'output' AND
+            invoice_outputs.team_id = invoice_objects.team_id AND
+            output_costs.material_id = material_costs.material_id AND
+            invoice_outputs.date_of_invoice <= invoice_objects.date_of_invoice
+          ORDER BY invoice_outputs.date_of_invoice DESC
+          LIMIT 1
+        ) AS last_output ON true
+      WHERE
+        invoice_objects.project_id = ? AND
+        invoice_objects.confirmed_by_operator = true AND
+        invoice_materials.invoice_type = 'object-correction' AND
+        invoice_objects.date_of_invoice >= ? AND
+        invoice_objects.date_of_invoice <= ?
+    ) AS kpi_rows
+    WHERE nullif(?, 0) IS NULL OR entity_id = ?
+    GROUP BY entity_id, period
+    ORDER BY entity_id, period
+    `,
+		filter.GroupBy, filter.Interval,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.EntityID, filter.EntityID,
+	).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type materialConsumptionRepository struct {
+	db *gorm.DB
+}
+
+func NewMaterialConsumptionRepository(db *gorm.DB) IMaterialConsumptionRepository {
+	return &materialConsumptionRepository{
+		db: db,
+	}
+}
+
+type IMaterialConsumptionRepository interface {
+	GetNormative(filter dto.MaterialConsumptionFilter) ([]dto.MaterialConsumptionQueryResult, error)
+	GetActual(filter dto.MaterialConsumptionFilter) ([]dto.MaterialConsumptionQueryResult, error)
+}
+
+// Normative consumption is the installed amount of every operation multiplied by its bill of materials.
+func (repo *materialConsumptionRepository) GetNormative(filter dto.MaterialConsumptionFilter) ([]dto.MaterialConsumptionQueryResult, error) {
+	data := []dto.MaterialConsumptionQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      materials.id as material_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      SUM(invoice_operations.amount * operation_bom_items.amount) as amount
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operation_bom_items ON operation_bom_items.operation_id = invoice_operations.operation_id
+      INNER JOIN materials ON materials.id = operation_bom_items.material_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?) AND
+      (nullif(?, 0) IS NULL OR invoice_objects.district_id = ?) AND
+      (? OR invoice_objects.date_of_invoice >= ?) AND
+      (? OR invoice_objects.date_of_invoice <= ?) AND
+      %s
+    GROUP BY objects.id, objects.name, objects.type, materials.id, materials.code, materials.name, materials.unit
+    ORDER BY objects.name, objects.id, materials.code
+    `, confirmedInvoiceAmounts("invoice_operations")),
+		filter.ProjectID,
+		filter.ObjectID, filter.ObjectID,
+		filter.DistrictID, filter.DistrictID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *materialConsumptionRepository) GetActual(filter dto.MaterialConsumptionFilter) ([]dto.MaterialConsumptionQueryResult, error) {
+	data := []dto.MaterialConsumptionQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      materials.id as material_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      SUM(invoice_materials.amount) as amount
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_objects.id
+      INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+      INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?) AND
+      (nullif(?, 0) IS NULL OR invoice_objects.district_id = ?) AND
+      (? OR invoice_objects.date_of_invoice >= ?) AND
+      (? OR invoice_objects.date_of_invoice <= ?) AND
+      %s
+    GROUP BY objects.id, objects.name, objects.type, materials.id, materials.code, materials.name, materials.unit
+    ORDER BY objects.name, objects.id, materials.code
+    `, confirmedInvoiceAmounts("invoice_materials")),
+		filter.ProjectID,
+		filter.ObjectID, filter.ObjectID,
+		filter.DistrictID, filter.DistrictID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
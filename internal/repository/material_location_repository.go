@@ -42,6 +42,7 @@ type IMaterialLocationRepository interface {
 	GetDataForBalanceReport(projectID uint, locationType string, locationID uint) ([]dto.BalanceReportQueryResult, error)
 	GetMaterialAmountSortedByCostM19InLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialAmountSortedByCostM19QueryResult, error)
 	GetMaterialAmountReverseSortedByCostM19InLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialAmountSortedByCostM19QueryResult, error)
+	GetMaterialAmountsWithDateOfReceiptInLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialCostAmountInLocation, error)
 	GetMaterialsInLocationBasedOnInvoiceID(locationID uint, locationType string, invoiceID uint, invoiceType string) ([]model.MaterialLocation, error)
 	Live(data dto.MaterialLocationLiveSearchParameters) ([]dto.MaterialLocationLiveView, error)
 }
@@ -392,6 +393,38 @@ func (repo *materialLocationRepository) GetMaterialAmountReverseSortedByCostM19I
 	return data, err
 }
 
+// Date of receipt of the material cost is the date of its first input invoice,
+// material costs that never came through input invoice are considered the oldest
+func (repo *materialLocationRepository) GetMaterialAmountsWithDateOfReceiptInLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialCostAmountInLocation, error) {
+	data := []dto.MaterialCostAmountInLocation{}
+	err := repo.db.Raw(`
+    SELECT 
+      material_costs.material_id AS material_id,
+      material_costs.id AS material_cost_id,
+      material_costs.cost_m19 AS material_cost_m19,
+      material_locations.amount AS material_amount,
+      COALESCE((
+        SELECT MIN(invoice_inputs.date_of_invoice)
+        FROM invoice_materials
+        INNER JOIN invoice_inputs ON invoice_inputs.id = invoice_materials.invoice_id
+        WHERE
+          invoice_materials.invoice_type = 'input' AND
+          invoice_materials.material_cost_id = material_costs.id
+      ), '0001-01-01 00:00:00+00') AS date_of_receipt
+    FROM material_locations
+    INNER JOIN material_costs ON material_costs.id = material_locations.material_cost_id
+    WHERE 
+      material_locations.project_id = ? AND
+      material_locations.location_type = ? AND
+      material_locations.location_id = ? AND
+      material_costs.material_id = ? AND
+      material_locations.amount > 0
+    ORDER BY material_costs.id;
+  `, projectID, locationType, locationID, materialID).Scan(&data).Error
+
+	return data, err
+}
+
 func (repo *materialLocationRepository) GetMaterialsInLocationBasedOnInvoiceID(locationID uint, locationType string, invoiceID uint, invoiceType string) ([]model.MaterialLocation, error) {
 	data := []model.MaterialLocation{}
 	err := repo.db.Raw(`
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type materialLotRepository struct {
+	db *gorm.DB
+}
+
+func NewMaterialLotRepository(db *gorm.DB) IMaterialLotRepository {
+	return &materialLotRepository{
+		db: db,
+	}
+}
+
+type IMaterialLotRepository interface {
+	GetAmountSortedByExpiryInLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialLotAmountSortedByExpiryQueryResult, error)
+	GetExpiring(filter dto.MaterialLotExpiringFilter) ([]dto.MaterialLotExpiringView, error)
+	GetByInvoice(invoiceID uint, invoiceType string) ([]dto.MaterialLotInInvoiceView, error)
+}
+
+// Lots that expire first come first, lots without expiry date come last
+func (repo *materialLotRepository) GetAmountSortedByExpiryInLocation(projectID, materialID uint, locationType string, locationID uint) ([]dto.MaterialLotAmountSortedByExpiryQueryResult, error) {
+	data := []dto.MaterialLotAmountSortedByExpiryQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      material_lots.material_cost_id AS material_cost_id,
+      material_lots.id AS material_lot_id,
+      material_lots.expiry_date AS expiry_date,
+      material_lot_locations.amount AS amount
+    FROM material_lot_locations
+    INNER JOIN material_lots ON material_lots.id = material_lot_locations.material_lot_id
+    INNER JOIN material_costs ON material_costs.id = material_lots.material_cost_id
+    WHERE
+      material_lot_locations.project_id = ? AND
+      material_lot_locations.location_type = ? AND
+      material_lot_locations.location_id = ? AND
+      material_costs.material_id = ? AND
+      material_lot_locations.amount > 0
+    ORDER BY nullif(material_lots.expiry_date, '0001-01-01 00:00:00+00') ASC NULLS LAST, material_lots.id
+  `, projectID, locationType, locationID, materialID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *materialLotRepository) GetExpiring(filter dto.MaterialLotExpiringFilter) ([]dto.MaterialLotExpiringView, error) {
+	data := []dto.MaterialLotExpiringView{}
+	err := repo.db.Raw(`
+    SELECT
+      material_lots.id AS material_lot_id,
+      material_lots.code AS lot_code,
+      materials.name AS material_name,
+      materials.unit AS material_unit,
+      material_lot_locations.location_type AS location_type,
+      material_lot_locations.location_id AS location_id,
+      CASE material_lot_locations.location_type
+        WHEN 'warehouse' THEN 'Склад'
+        WHEN 'team' THEN teams.number
+        WHEN 'object' THEN objects.name
+        ELSE ''
+      END AS location_name,
+      material_lots.manufacture_date AS manufacture_date,
+      material_lots.expiry_date AS expiry_date,
+      material_lot_locations.amount AS amount
+    FROM material_lot_locations
+    INNER JOIN material_lots ON material_lots.id = material_lot_locations.material_lot_id
+    INNER JOIN material_costs ON material_costs.id = material_lots.material_cost_id
+    INNER JOIN materials ON materials.id = material_costs.material_id
+    LEFT JOIN teams ON material_lot_locations.location_type = 'team' AND teams.id = material_lot_locations.location_id
+    LEFT JOIN objects ON material_lot_locations.location_type = 'object' AND objects.id = material_lot_locations.location_id
+    WHERE
+      material_lot_locations.project_id = ? AND
+      material_lot_locations.amount > 0 AND
+      material_lots.expiry_date > '0001-01-01 00:00:00+00' AND
+      material_lots.expiry_date <= ? AND
+      (? = '' OR material_lot_locations.location_type = ?) AND
+      (? = 0 OR material_lot_locations.location_id = ?)
+    ORDER BY material_lot_locations.location_type, material_lot_locations.location_id, material_lots.expiry_date
+  `,
+		filter.ProjectID,
+		filter.ExpiresBefore,
+		filter.LocationType, filter.LocationType,
+		filter.LocationID, filter.LocationID,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *materialLotRepository) GetByInvoice(invoiceID uint, invoiceType string) ([]dto.MaterialLotInInvoiceView, error) {
+	data := []dto.MaterialLotInInvoiceView{}
+	err := repo.db.Raw(`
+    SELECT
+      material_lots.id AS material_lot_id,
+      material_lots.code AS lot_code,
+      materials.name AS material_name,
+      materials.unit AS material_unit,
+      material_lots.manufacture_date AS manufacture_date,
+      material_lots.expiry_date AS expiry_date,
+      material_lot_movements.amount AS amount
+    FROM material_lot_movements
+    INNER JOIN material_lots ON material_lots.id = material_lot_movements.material_lot_id
+    INNER JOIN material_costs ON material_costs.id = material_lots.material_cost_id
+    INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      material_lot_movements.invoice_id = ? AND
+      material_lot_movements.invoice_type = ?
+    ORDER BY materials.name, material_lots.expiry_date
+  `, invoiceID, invoiceType).Scan(&data).Error
+
+	return data, err
+}
+
+// Moves amounts of lots written in the movements of the invoice from one location to another
+// and confirms the movements. Empty fromType is used when lots come into the project
+func moveMaterialLots(tx *gorm.DB, invoiceType string, invoiceID uint, fromType string, fromID uint, toType string, toID uint) error {
+	if fromType != "" {
+		if err := tx.Exec(`
+      UPDATE material_lot_locations
+      SET amount = material_lot_locations.amount - moved.amount
+      FROM (
+        SELECT material_lot_id, SUM(amount) AS amount
+        FROM material_lot_movements
+        WHERE invoice_type = ? AND invoice_id = ?
+        GROUP BY material_lot_id
+      ) AS moved
+      WHERE
+        material_lot_locations.material_lot_id = moved.material_lot_id AND
+        material_lot_locations.location_type = ? AND
+        material_lot_locations.location_id = ?
+    `, invoiceType, invoiceID, fromType, fromID).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(`
+    UPDATE material_lot_locations
+    SET amount = material_lot_locations.amount + moved.amount
+    FROM (
+      SELECT material_lot_id, SUM(amount) AS amount
+      FROM material_lot_movements
+      WHERE invoice_type = ? AND invoice_id = ?
+      GROUP BY material_lot_id
+    ) AS moved
+    WHERE
+      material_lot_locations.material_lot_id = moved.material_lot_id AND
+      material_lot_locations.location_type = ? AND
+      material_lot_locations.location_id = ?
+  `, invoiceType, invoiceID, toType, toID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+    INSERT INTO material_lot_locations (material_lot_id, project_id, location_type, location_id, amount)
+    SELECT
+      material_lot_movements.material_lot_id,
+      material_lot_movements.project_id,
+      ?,
+      ?,
+      SUM(material_lot_movements.amount)
+    FROM material_lot_movements
+    WHERE
+      material_lot_movements.invoice_type = ? AND
+      material_lot_movements.invoice_id = ? AND
+      NOT EXISTS (
+        SELECT 1
+        FROM material_lot_locations
+        WHERE
+          material_lot_locations.material_lot_id = material_lot_movements.material_lot_id AND
+          material_lot_locations.location_type = ? AND
+          material_lot_locations.location_id = ?
+      )
+    GROUP BY material_lot_movements.material_lot_id, material_lot_movements.project_id
+  `, toType, toID, invoiceType, invoiceID, toType, toID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`
+    UPDATE material_lot_movements
+    SET confirmation = true
+    WHERE invoice_type = ? AND invoice_id = ?
+  `, invoiceType, invoiceID).Error
+}
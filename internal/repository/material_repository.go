@@ -88,7 +88,25 @@ func (repo *materialRepository) Update(data model.Material) (model.Material, err
 }
 
 func (repo *materialRepository) Delete(id uint) error {
-	return repo.db.Delete(&model.Material{}, "id = ?", id).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.MaterialUnit{}, "material_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.OperationBOMItem{}, "material_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectPlannedMaterial{}, "material_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.Material{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (repo *materialRepository) Count(filter model.Material) (int64, error) {
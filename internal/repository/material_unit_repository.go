@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type materialUnitRepository struct {
+	db *gorm.DB
+}
+
+func NewMaterialUnitRepository(db *gorm.DB) IMaterialUnitRepository {
+	return &materialUnitRepository{
+		db: db,
+	}
+}
+
+type IMaterialUnitRepository interface {
+	GetByID(id uint) (model.MaterialUnit, error)
+	GetByMaterialID(materialID uint) ([]dto.MaterialUnitView, error)
+	GetViewByID(id uint) (dto.MaterialUnitView, error)
+	Create(data model.MaterialUnit) (model.MaterialUnit, error)
+	Update(data model.MaterialUnit) (model.MaterialUnit, error)
+	Delete(id uint) error
+	DoesExist(materialID, unitID uint) (bool, error)
+}
+
+func (repo *materialUnitRepository) GetByID(id uint) (model.MaterialUnit, error) {
+	data := model.MaterialUnit{}
+	err := repo.db.First(&data, "id = ?", id).Error
+	return data, err
+}
+
+func (repo *materialUnitRepository) GetByMaterialID(materialID uint) ([]dto.MaterialUnitView, error) {
+	data := []dto.MaterialUnitView{}
+	err := repo.db.Raw(`
+    SELECT
+      material_units.id AS id,
+      material_units.material_id AS material_id,
+      materials.unit AS base_unit,
+      material_units.unit_id AS unit_id,
+      units.name AS unit_name,
+      units.short_name AS unit_short_name,
+      material_units.factor AS factor
+    FROM material_units
+    INNER JOIN units ON units.id = material_units.unit_id
+    INNER JOIN materials ON materials.id = material_units.material_id
+    WHERE material_units.material_id = ?
+    ORDER BY material_units.id
+    `, materialID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *materialUnitRepository) GetViewByID(id uint) (dto.MaterialUnitView, error) {
+	data := dto.MaterialUnitView{}
+	err := repo.db.Raw(`
+    SELECT
+      material_units.id AS id,
+      material_units.material_id AS material_id,
+      materials.unit AS base_unit,
+      material_units.unit_id AS unit_id,
+      units.name AS unit_name,
+      units.short_name AS unit_short_name,
+      material_units.factor AS factor
+    FROM material_units
+    INNER JOIN units ON units.id = material_units.unit_id
+    INNER JOIN materials ON materials.id = material_units.material_id
+    WHERE material_units.id = ?
+    `, id).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *materialUnitRepository) Create(data model.MaterialUnit) (model.MaterialUnit, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *materialUnitRepository) Update(data model.MaterialUnit) (model.MaterialUnit, error) {
+	err := repo.db.Model(&model.MaterialUnit{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	return data, err
+}
+
+func (repo *materialUnitRepository) Delete(id uint) error {
+	return repo.db.Delete(&model.MaterialUnit{}, "id = ?", id).Error
+}
+
+func (repo *materialUnitRepository) DoesExist(materialID, unitID uint) (bool, error) {
+	var count int64
+	err := repo.db.Raw(`SELECT COUNT(*) FROM material_units WHERE material_id = ? AND unit_id = ?`, materialID, unitID).Scan(&count).Error
+	return count != 0, err
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type networkTopologyRepository struct {
+	db *gorm.DB
+}
+
+func NewNetworkTopologyRepository(db *gorm.DB) INetworkTopologyRepository {
+	return &networkTopologyRepository{
+		db: db,
+	}
+}
+
+type INetworkTopologyRepository interface {
+	GetNodes(projectID uint) ([]dto.NetworkNode, error)
+	GetEdges(projectID uint) ([]dto.NetworkEdge, error)
+	GetConnectionByID(id uint) (model.NetworkConnection, error)
+	CreateConnection(data model.NetworkConnection) (model.NetworkConnection, error)
+	DeleteConnection(id uint) error
+	GetKL04KVWithoutConnections(projectID uint) ([]dto.KL04KVNourashesQueryResult, error)
+	CreateConnectionsFromSource(projectID, sourceObjectID uint, targetObjectIDs []uint) error
+}
+
+func (repo *networkTopologyRepository) GetNodes(projectID uint) ([]dto.NetworkNode, error) {
+	data := []dto.NetworkNode{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status
+    FROM objects
+    WHERE objects.project_id = ?
+    ORDER BY objects.id
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+// Edges are collected from all the tables that store feeding relations between objects,
+// only edges from network_connections have ConnectionID and can be deleted as connections
+func (repo *networkTopologyRepository) GetEdges(projectID uint) ([]dto.NetworkEdge, error) {
+	data := []dto.NetworkEdge{}
+	err := repo.db.Raw(`
+    SELECT
+      0 as connection_id,
+      tp_nourashes_objects.tp_object_id as source_object_id,
+      tp_nourashes_objects.target_id as target_object_id,
+      'tp_nourashes_objects' as origin
+    FROM tp_nourashes_objects
+      INNER JOIN objects ON objects.id = tp_nourashes_objects.tp_object_id
+    WHERE objects.project_id = ?
+
+    UNION ALL
+
+    SELECT
+      0 as connection_id,
+      substation_cell_nourashes_substation_objects.substation_object_id as source_object_id,
+      substation_cell_nourashes_substation_objects.substation_cell_object_id as target_object_id,
+      'substation_cell_nourashes_substation_objects' as origin
+    FROM substation_cell_nourashes_substation_objects
+      INNER JOIN objects ON objects.id = substation_cell_nourashes_substation_objects.substation_object_id
+    WHERE objects.project_id = ?
+
+    UNION ALL
+
+    SELECT
+      network_connections.id as connection_id,
+      network_connections.source_object_id as source_object_id,
+      network_connections.target_object_id as target_object_id,
+      'network_connections' as origin
+    FROM network_connections
+    WHERE network_connections.project_id = ?
+    `, projectID, projectID, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *networkTopologyRepository) GetConnectionByID(id uint) (model.NetworkConnection, error) {
+	data := model.NetworkConnection{}
+	err := repo.db.Find(&data, "id = ?", id).Error
+	return data, err
+}
+
+func (repo *networkTopologyRepository) CreateConnection(data model.NetworkConnection) (model.NetworkConnection, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *networkTopologyRepository) DeleteConnection(id uint) error {
+	return repo.db.Delete(&model.NetworkConnection{}, "id = ?", id).Error
+}
+
+func (repo *networkTopologyRepository) GetKL04KVWithoutConnections(projectID uint) ([]dto.KL04KVNourashesQueryResult, error) {
+	data := []dto.KL04KVNourashesQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      kl04_kv_objects.nourashes as nourashes
+    FROM objects
+      INNER JOIN kl04_kv_objects ON kl04_kv_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'kl04kv_objects' AND
+      objects.project_id = ? AND
+      kl04_kv_objects.nourashes <> '' AND
+      NOT EXISTS (
+        SELECT 1
+        FROM network_connections
+        WHERE network_connections.source_object_id = objects.id
+      )
+    ORDER BY objects.id
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *networkTopologyRepository) CreateConnectionsFromSource(projectID, sourceObjectID uint, targetObjectIDs []uint) error {
+	return createNetworkConnectionsFromSource(repo.db, projectID, sourceObjectID, targetObjectIDs)
+}
+
+func createNetworkConnectionsFromSource(tx *gorm.DB, projectID, sourceObjectID uint, targetObjectIDs []uint) error {
+	connections := []model.NetworkConnection{}
+	for _, targetObjectID := range targetObjectIDs {
+		connections = append(connections, model.NetworkConnection{
+			ProjectID:      projectID,
+			SourceObjectID: sourceObjectID,
+			TargetObjectID: targetObjectID,
+		})
+	}
+
+	return tx.CreateInBatches(&connections, 10).Error
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type objectGeolocationRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectGeolocationRepository(db *gorm.DB) IObjectGeolocationRepository {
+	return &objectGeolocationRepository{
+		db: db,
+	}
+}
+
+type IObjectGeolocationRepository interface {
+	GetLocatedObjects(projectID uint) ([]dto.ObjectGeolocationQueryResult, error)
+	GetTeamNumbers(projectID uint) ([]dto.ObjectTeamNumberQueryResult, error)
+}
+
+// Returns the objects that have coordinates: points for TP, substations and MJD
+// and routes for KL04KV and SIP lines
+func (repo *objectGeolocationRepository) GetLocatedObjects(projectID uint) ([]dto.ObjectGeolocationQueryResult, error) {
+	data := []dto.ObjectGeolocationQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status,
+      tp_objects.latitude as latitude,
+      tp_objects.longitude as longitude,
+      '' as route
+    FROM objects
+      INNER JOIN tp_objects ON tp_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'tp_objects' AND
+      objects.project_id = ? AND
+      NOT (tp_objects.latitude = 0 AND tp_objects.longitude = 0)
+
+    UNION ALL
+
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status,
+      substation_objects.latitude as latitude,
+      substation_objects.longitude as longitude,
+      '' as route
+    FROM objects
+      INNER JOIN substation_objects ON substation_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'substation_objects' AND
+      objects.project_id = ? AND
+      NOT (substation_objects.latitude = 0 AND substation_objects.longitude = 0)
+
+    UNION ALL
+
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status,
+      mjd_objects.latitude as latitude,
+      mjd_objects.longitude as longitude,
+      '' as route
+    FROM objects
+      INNER JOIN mjd_objects ON mjd_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'mjd_objects' AND
+      objects.project_id = ? AND
+      NOT (mjd_objects.latitude = 0 AND mjd_objects.longitude = 0)
+
+    UNION ALL
+
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status,
+      0 as latitude,
+      0 as longitude,
+      kl04_kv_objects.route as route
+    FROM objects
+      INNER JOIN kl04_kv_objects ON kl04_kv_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'kl04kv_objects' AND
+      objects.project_id = ? AND
+      kl04_kv_objects.route <> ''
+
+    UNION ALL
+
+    SELECT
+      objects.id as object_id,
+      objects.name as name,
+      objects.type as type,
+      objects.status as status,
+      0 as latitude,
+      0 as longitude,
+      s_ip_objects.route as route
+    FROM objects
+      INNER JOIN s_ip_objects ON s_ip_objects.id = objects.object_detailed_id
+    WHERE
+      objects.type = 'sip_objects' AND
+      objects.project_id = ? AND
+      s_ip_objects.route <> ''
+    `, projectID, projectID, projectID, projectID, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectGeolocationRepository) GetTeamNumbers(projectID uint) ([]dto.ObjectTeamNumberQueryResult, error) {
+	data := []dto.ObjectTeamNumberQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      object_teams.object_id as object_id,
+      teams.number as team_number
+    FROM object_teams
+      INNER JOIN teams ON teams.id = object_teams.team_id
+      INNER JOIN objects ON objects.id = object_teams.object_id
+    WHERE objects.project_id = ?
+    ORDER BY teams.number
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type objectPlanRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectPlanRepository(db *gorm.DB) IObjectPlanRepository {
+	return &objectPlanRepository{
+		db: db,
+	}
+}
+
+type IObjectPlanRepository interface {
+	GetPlannedMaterialsByObjectID(objectID uint) ([]dto.ObjectPlannedItemView, error)
+	GetPlannedOperationsByObjectID(objectID uint) ([]dto.ObjectPlannedItemView, error)
+	Replace(objectIDs []uint, materials []model.ObjectPlannedMaterial, operations []model.ObjectPlannedOperation) error
+	GetPlannedMaterials(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error)
+	GetActualMaterials(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error)
+	GetPlannedOperations(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error)
+	GetActualOperations(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error)
+}
+
+func (repo *objectPlanRepository) GetPlannedMaterialsByObjectID(objectID uint) ([]dto.ObjectPlannedItemView, error) {
+	data := []dto.ObjectPlannedItemView{}
+	err := repo.db.Raw(`
+    SELECT
+      materials.id as id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      object_planned_materials.amount as amount
+    FROM object_planned_materials
+      INNER JOIN materials ON materials.id = object_planned_materials.material_id
+    WHERE object_planned_materials.object_id = ?
+    ORDER BY materials.code
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectPlanRepository) GetPlannedOperationsByObjectID(objectID uint) ([]dto.ObjectPlannedItemView, error) {
+	data := []dto.ObjectPlannedItemView{}
+	err := repo.db.Raw(`
+    SELECT
+      operations.id as id,
+      operations.code as code,
+      operations.name as name,
+      object_planned_operations.amount as amount
+    FROM object_planned_operations
+      INNER JOIN operations ON operations.id = object_planned_operations.operation_id
+    WHERE object_planned_operations.object_id = ?
+    ORDER BY operations.code
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+// The whole plan of every given object is replaced, objects that are not given keep their plan
+func (repo *objectPlanRepository) Replace(objectIDs []uint, materials []model.ObjectPlannedMaterial, operations []model.ObjectPlannedOperation) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.ObjectPlannedMaterial{}, "object_id IN ?", objectIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectPlannedOperation{}, "object_id IN ?", objectIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.CreateInBatches(&materials, 50).Error; err != nil {
+			return err
+		}
+
+		if err := tx.CreateInBatches(&operations, 50).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Planned sum is counted with the latest price of the material
+func (repo *objectPlanRepository) GetPlannedMaterials(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error) {
+	data := []dto.ObjectPlanAmountQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      materials.id as item_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      object_planned_materials.amount as amount,
+      object_planned_materials.amount * COALESCE((
+        SELECT material_costs.cost_with_customer
+        FROM material_costs
+        WHERE material_costs.material_id = materials.id
+        ORDER BY material_costs.id DESC
+        LIMIT 1
+      ), 0) as "sum"
+    FROM object_planned_materials
+      INNER JOIN objects ON objects.id = object_planned_materials.object_id
+      INNER JOIN materials ON materials.id = object_planned_materials.material_id
+    WHERE
+      object_planned_materials.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?)
+    ORDER BY objects.id, materials.code
+    `, projectID, objectID, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectPlanRepository) GetActualMaterials(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error) {
+	data := []dto.ObjectPlanAmountQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      materials.id as item_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      SUM(invoice_materials.amount) as amount,
+      SUM(invoice_materials.amount * material_costs.cost_with_customer) as "sum"
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_objects.id
+      INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+      INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?) AND
+      %s
+    GROUP BY objects.id, objects.name, objects.type, materials.id, materials.code, materials.name, materials.unit
+    ORDER BY objects.id, materials.code
+    `, confirmedInvoiceAmounts("invoice_materials")), projectID, objectID, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectPlanRepository) GetPlannedOperations(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error) {
+	data := []dto.ObjectPlanAmountQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      operations.id as item_id,
+      operations.code as code,
+      operations.name as name,
+      object_planned_operations.amount as amount,
+      object_planned_operations.amount * operations.cost_with_customer as "sum"
+    FROM object_planned_operations
+      INNER JOIN objects ON objects.id = object_planned_operations.object_id
+      INNER JOIN operations ON operations.id = object_planned_operations.operation_id
+    WHERE
+      object_planned_operations.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?)
+    ORDER BY objects.id, operations.code
+    `, projectID, objectID, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectPlanRepository) GetActualOperations(projectID, objectID uint) ([]dto.ObjectPlanAmountQueryResult, error) {
+	data := []dto.ObjectPlanAmountQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      operations.id as item_id,
+      operations.code as code,
+      operations.name as name,
+      SUM(invoice_operations.amount) as amount,
+      SUM(invoice_operations.amount * operations.cost_with_customer) as "sum"
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operations ON operations.id = invoice_operations.operation_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?) AND
+      %s
+    GROUP BY objects.id, objects.name, objects.type, operations.id, operations.code, operations.name
+    ORDER BY objects.id, operations.code
+    `, confirmedInvoiceAmounts("invoice_operations")), projectID, objectID, objectID).Scan(&data).Error
+
+	return data, err
+}
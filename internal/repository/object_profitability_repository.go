@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type objectProfitabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectProfitabilityRepository(db *gorm.DB) IObjectProfitabilityRepository {
+	return &objectProfitabilityRepository{
+		db: db,
+	}
+}
+
+type IObjectProfitabilityRepository interface {
+	GetInvoices(filter dto.ObjectProfitabilityFilter) ([]dto.ObjectProfitabilityInvoiceQueryResult, error)
+}
+
+// Labour is the prime cost of the operations done on the object
+func (repo *objectProfitabilityRepository) GetInvoices(filter dto.ObjectProfitabilityFilter) ([]dto.ObjectProfitabilityInvoiceQueryResult, error) {
+	data := []dto.ObjectProfitabilityInvoiceQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    WITH invoice_material_sums AS (
+      SELECT
+        invoice_materials.invoice_id as invoice_id,
+        SUM(invoice_materials.amount * material_costs.cost_with_customer) as customer_sum,
+        SUM(invoice_materials.amount * material_costs.cost_prime) as prime_sum
+      FROM invoice_materials
+        INNER JOIN invoice_objects ON invoice_objects.id = invoice_materials.invoice_id
+        INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+      WHERE
+        invoice_objects.project_id = ? AND
+        %s
+      GROUP BY invoice_materials.invoice_id
+    ),
+    invoice_operation_sums AS (
+      SELECT
+        invoice_operations.invoice_id as invoice_id,
+        SUM(invoice_operations.amount * operations.cost_with_customer) as customer_sum,
+        SUM(invoice_operations.amount * operations.cost_prime) as prime_sum
+      FROM invoice_operations
+        INNER JOIN invoice_objects ON invoice_objects.id = invoice_operations.invoice_id
+        INNER JOIN operations ON operations.id = invoice_operations.operation_id
+      WHERE
+        invoice_objects.project_id = ? AND
+        %s
+      GROUP BY invoice_operations.invoice_id
+    )
+    SELECT
+      invoice_objects.id as invoice_id,
+      invoice_objects.delivery_code as delivery_code,
+      invoice_objects.date_of_invoice as date_of_invoice,
+      invoice_objects.confirmed_by_operator as confirmed_by_operator,
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      COALESCE(districts.id, 0) as district_id,
+      COALESCE(districts.name, '') as district_name,
+      COALESCE(invoice_material_sums.customer_sum, 0) as material_value,
+      COALESCE(invoice_material_sums.prime_sum, 0) as material_cost,
+      COALESCE(invoice_operation_sums.customer_sum, 0) as operation_value,
+      COALESCE(invoice_operation_sums.prime_sum, 0) as labour_cost
+    FROM invoice_objects
+      INNER JOIN objects ON objects.id = invoice_objects.object_id
+      LEFT JOIN districts ON districts.id = invoice_objects.district_id
+      LEFT JOIN invoice_material_sums ON invoice_material_sums.invoice_id = invoice_objects.id
+      LEFT JOIN invoice_operation_sums ON invoice_operation_sums.invoice_id = invoice_objects.id
+    WHERE
+      invoice_objects.project_id = ? AND
+      (nullif(?, 0) IS NULL OR objects.id = ?) AND
+      (? OR invoice_objects.date_of_invoice >= ?) AND
+      (? OR invoice_objects.date_of_invoice <= ?) AND
+      (invoice_material_sums.invoice_id IS NOT NULL OR invoice_operation_sums.invoice_id IS NOT NULL)
+    ORDER BY invoice_objects.date_of_invoice, invoice_objects.id
+    `, confirmedInvoiceAmounts("invoice_materials"), confirmedInvoiceAmounts("invoice_operations")),
+		filter.ProjectID,
+		filter.ProjectID,
+		filter.ProjectID,
+		filter.ObjectID, filter.ObjectID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
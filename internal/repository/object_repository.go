@@ -94,7 +94,65 @@ func (repo *objectRepository) Update(data model.Object) (model.Object, error) {
 }
 
 func (repo *objectRepository) Delete(id uint) error {
-	return repo.db.Delete(&model.Object{}, "id = ?", id).Error
+	attachmentFiles := []string{}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.ObjectStatusTransition{}, "object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.NetworkConnection{}, "source_object_id = ? OR target_object_id = ?", id, id).Error; err != nil {
+			return err
+		}
+
+		files, err := attachmentFilePaths(tx, "attached_to = 'object' AND attached_to_id = ?", id)
+		if err != nil {
+			return err
+		}
+		attachmentFiles = files
+
+		if err := tx.Delete(&model.Attachment{}, "attached_to = 'object' AND attached_to_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.HandoverAct{}, "object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectPlannedMaterial{}, "object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectPlannedOperation{}, "object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+      DELETE FROM team_assignment_operations
+      WHERE team_assignment_id IN (
+        SELECT id
+        FROM team_assignments
+        WHERE object_id = ?
+      )
+    `, id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.TeamAssignment{}, "object_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.Object{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		removeAttachmentFiles(attachmentFiles)
+	}
+
+	return err
 }
 
 func (repo *objectRepository) Count() (int64, error) {
@@ -117,3 +175,147 @@ func (repo *objectRepository) GetByRangeOfIDs(ids []uint) ([]model.Object, error
 	err := repo.db.Model(model.Object{}).Select("*").Where("id IN ?", ids).Scan(&data).Error
 	return data, err
 }
+
+// Removes the rows that reference the object through foreign keys but are not
+// removed by the object type repositories themselves. Returns the files of the
+// removed attachments that are to be removed after the transaction is committed
+func deleteObjectReferences(tx *gorm.DB, objectDetailedID uint, objectType string) ([]string, error) {
+	err := tx.Exec(`
+    DELETE FROM object_status_transitions
+    WHERE object_status_transitions.object_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM object_planned_materials
+    WHERE object_planned_materials.object_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM object_planned_operations
+    WHERE object_planned_operations.object_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM handover_acts
+    WHERE handover_acts.object_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM team_assignment_operations
+    WHERE team_assignment_operations.team_assignment_id IN (
+      SELECT team_assignments.id
+      FROM team_assignments
+        INNER JOIN objects ON objects.id = team_assignments.object_id
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM team_assignments
+    WHERE team_assignments.object_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	attachmentFiles, err := attachmentFilePaths(tx, `
+    attached_to = 'object' AND
+    attached_to_id IN (
+      SELECT objects.id
+      FROM objects
+      WHERE
+        objects.object_detailed_id = ? AND
+        objects.type = ?
+    )
+    `, objectDetailedID, objectType)
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM attachments
+    WHERE
+      attachments.attached_to = 'object' AND
+      attachments.attached_to_id IN (
+        SELECT objects.id
+        FROM objects
+        WHERE
+          objects.object_detailed_id = ? AND
+          objects.type = ?
+      )
+    `, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	err = tx.Exec(`
+    DELETE FROM network_connections
+    WHERE
+      network_connections.source_object_id IN (
+        SELECT objects.id
+        FROM objects
+        WHERE
+          objects.object_detailed_id = ? AND
+          objects.type = ?
+      ) OR
+      network_connections.target_object_id IN (
+        SELECT objects.id
+        FROM objects
+        WHERE
+          objects.object_detailed_id = ? AND
+          objects.type = ?
+      )
+    `, objectDetailedID, objectType, objectDetailedID, objectType).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	return attachmentFiles, nil
+}
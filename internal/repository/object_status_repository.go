@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type objectStatusRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectStatusRepository(db *gorm.DB) IObjectStatusRepository {
+	return &objectStatusRepository{
+		db: db,
+	}
+}
+
+type IObjectStatusRepository interface {
+	GetTimeline(objectID uint) ([]dto.ObjectStatusTransitionView, error)
+	Transition(data model.ObjectStatusTransition) (bool, error)
+	HasConfirmedObjectInvoice(objectID uint) (bool, error)
+	HasConfirmedOutputToObjectTeams(objectID uint) (bool, error)
+}
+
+func (repo *objectStatusRepository) GetTimeline(objectID uint) ([]dto.ObjectStatusTransitionView, error) {
+	data := []dto.ObjectStatusTransitionView{}
+	err := repo.db.Raw(`
+    SELECT
+      object_status_transitions.id as id,
+      object_status_transitions.from_status as from_status,
+      object_status_transitions.to_status as to_status,
+      users.username as username,
+      workers.name as worker_name,
+      object_status_transitions.date as date,
+      object_status_transitions.comment as comment
+    FROM object_status_transitions
+      LEFT JOIN users ON users.id = object_status_transitions.user_id
+      LEFT JOIN workers ON workers.id = users.worker_id
+    WHERE object_status_transitions.object_id = ?
+    ORDER BY object_status_transitions.date, object_status_transitions.id
+    `, objectID).Scan(&data).Error
+
+	return data, err
+}
+
+// Changes the status of the object only if it still has the FromStatus,
+// false is returned when the status was changed by someone else in the meantime
+func (repo *objectStatusRepository) Transition(data model.ObjectStatusTransition) (bool, error) {
+	changed := false
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+      UPDATE objects
+      SET status = ?
+      WHERE id = ? AND status = ?
+      `, data.ToStatus, data.ObjectID, data.FromStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&data).Error; err != nil {
+			return err
+		}
+
+		changed = true
+		return nil
+	})
+
+	return changed, err
+}
+
+func (repo *objectStatusRepository) HasConfirmedObjectInvoice(objectID uint) (bool, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM invoice_objects
+    WHERE object_id = ? AND confirmed_by_operator = true
+    `, objectID).Scan(&count).Error
+
+	return count != 0, err
+}
+
+func (repo *objectStatusRepository) HasConfirmedOutputToObjectTeams(objectID uint) (bool, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM invoice_outputs
+    WHERE
+      invoice_outputs.confirmation = true AND
+      invoice_outputs.team_id IN (
+        SELECT object_teams.team_id
+        FROM object_teams
+        WHERE object_teams.object_id = ?
+      )
+    `, objectID).Scan(&count).Error
+
+	return count != 0, err
+}
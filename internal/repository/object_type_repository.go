@@ -0,0 +1,586 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type objectTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectTypeRepository(db *gorm.DB) IObjectTypeRepository {
+	return &objectTypeRepository{
+		db: db,
+	}
+}
+
+type IObjectTypeRepository interface {
+	GetAll() ([]model.ObjectType, error)
+	GetAllAttributes() ([]model.ObjectTypeAttribute, error)
+	Create(objectType model.ObjectType, attributes []model.ObjectTypeAttribute) (model.ObjectType, error)
+	Delete(id uint) error
+	CountObjectsOfType(objectType string) (int64, error)
+	GetObjectsPaginated(page, limit int, filter dto.TypedObjectSearchParameters) ([]dto.TypedObjectQuery, error)
+	CountObjects(filter dto.TypedObjectSearchParameters) (int64, error)
+	GetAllObjects(projectID uint, objectType string) ([]dto.TypedObjectQuery, error)
+	GetObjectNamesForSearch(projectID uint, objectType string) ([]dto.DataForSelect[string], error)
+	GetColumnValues(detailTable string, columns []string, ids []uint) ([]map[string]interface{}, error)
+	GetCustomAttributeValues(customObjectIDs []uint) ([]dto.CustomObjectAttributeValueQuery, error)
+	GetObjectByName(projectID uint, name string) (model.Object, error)
+	GetFedByNames(objectID uint, objectType, fedByTable string) ([]string, error)
+	CreateObject(object model.Object, details dto.ObjectDetailsStorage, supervisors, teams []uint, feeding dto.ObjectFeeding) (model.Object, error)
+	UpdateObject(object model.Object, details dto.ObjectDetailsStorage, supervisors, teams []uint, feeding dto.ObjectFeeding) error
+	DeleteObject(object model.Object, detailTable string) error
+	CreateObjectsInBatches(projectID uint, objectType string, data []dto.TypedObjectImportData) error
+}
+
+func (repo *objectTypeRepository) GetAll() ([]model.ObjectType, error) {
+	data := []model.ObjectType{}
+	err := repo.db.Raw(`SELECT * FROM object_types ORDER BY id`).Scan(&data).Error
+	return data, err
+}
+
+func (repo *objectTypeRepository) GetAllAttributes() ([]model.ObjectTypeAttribute, error) {
+	data := []model.ObjectTypeAttribute{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM object_type_attributes
+    ORDER BY object_type_id, order_number
+    `).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) Create(objectType model.ObjectType, attributes []model.ObjectTypeAttribute) (model.ObjectType, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&objectType).Error; err != nil {
+			return err
+		}
+
+		for index := range attributes {
+			attributes[index].ObjectTypeID = objectType.ID
+		}
+
+		return tx.CreateInBatches(&attributes, 20).Error
+	})
+
+	return objectType, err
+}
+
+func (repo *objectTypeRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.ObjectTypeAttribute{}, "object_type_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&model.ObjectType{}, "id = ?", id).Error
+	})
+}
+
+func (repo *objectTypeRepository) CountObjectsOfType(objectType string) (int64, error) {
+	var count int64
+	err := repo.db.Raw(`SELECT COUNT(*) FROM objects WHERE type = ?`, objectType).Scan(&count).Error
+	return count, err
+}
+
+// Feeders are searched in all the tables of the feeding relations, so the filter
+// does not depend on the table used by the object type
+const typedObjectFilter = `
+      objects.project_id = ? AND
+      objects.type = ? AND
+      (nullif(?, '') IS NULL OR objects.name = ?) AND
+      (nullif(?, 0) IS NULL OR EXISTS (
+        SELECT 1
+        FROM object_supervisors
+        WHERE
+          object_supervisors.object_id = objects.id AND
+          object_supervisors.supervisor_worker_id = ?
+      )) AND
+      (nullif(?, 0) IS NULL OR EXISTS (
+        SELECT 1
+        FROM object_teams
+        WHERE
+          object_teams.object_id = objects.id AND
+          object_teams.team_id = ?
+      )) AND
+      (nullif(?, 0) IS NULL OR EXISTS (
+        SELECT 1
+        FROM tp_nourashes_objects
+        WHERE
+          tp_nourashes_objects.target_id = objects.id AND
+          tp_nourashes_objects.target_type = objects.type AND
+          tp_nourashes_objects.tp_object_id = ?
+      ) OR EXISTS (
+        SELECT 1
+        FROM substation_cell_nourashes_substation_objects
+        WHERE
+          substation_cell_nourashes_substation_objects.substation_cell_object_id = objects.id AND
+          substation_cell_nourashes_substation_objects.substation_object_id = ?
+      ) OR EXISTS (
+        SELECT 1
+        FROM network_connections
+        WHERE
+          network_connections.target_object_id = objects.id AND
+          network_connections.source_object_id = ?
+      ))`
+
+func typedObjectFilterValues(filter dto.TypedObjectSearchParameters) []interface{} {
+	return []interface{}{
+		filter.ProjectID, filter.Type,
+		filter.ObjectName, filter.ObjectName,
+		filter.SupervisorWorkerID, filter.SupervisorWorkerID,
+		filter.TeamID, filter.TeamID,
+		filter.FedByObjectID, filter.FedByObjectID, filter.FedByObjectID, filter.FedByObjectID,
+	}
+}
+
+func (repo *objectTypeRepository) GetObjectsPaginated(page, limit int, filter dto.TypedObjectSearchParameters) ([]dto.TypedObjectQuery, error) {
+	data := []dto.TypedObjectQuery{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.object_detailed_id as object_detailed_id,
+      objects.name as name,
+      objects.status as status
+    FROM objects
+    WHERE`+typedObjectFilter+`
+    ORDER BY objects.id DESC
+    LIMIT ?
+    OFFSET ?
+    `, append(typedObjectFilterValues(filter), limit, (page-1)*limit)...).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) CountObjects(filter dto.TypedObjectSearchParameters) (int64, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM objects
+    WHERE`+typedObjectFilter,
+		typedObjectFilterValues(filter)...,
+	).Scan(&count).Error
+
+	return count, err
+}
+
+func (repo *objectTypeRepository) GetAllObjects(projectID uint, objectType string) ([]dto.TypedObjectQuery, error) {
+	data := []dto.TypedObjectQuery{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.id as object_id,
+      objects.object_detailed_id as object_detailed_id,
+      objects.name as name,
+      objects.status as status
+    FROM objects
+    WHERE
+      objects.project_id = ? AND
+      objects.type = ?
+    ORDER BY objects.id DESC
+    `, projectID, objectType).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) GetObjectNamesForSearch(projectID uint, objectType string) ([]dto.DataForSelect[string], error) {
+	data := []dto.DataForSelect[string]{}
+	err := repo.db.Raw(`
+    SELECT
+      objects.name as "label",
+      objects.name as "value"
+    FROM objects
+    WHERE
+      objects.project_id = ? AND
+      objects.type = ?
+    ORDER BY objects.name
+    `, projectID, objectType).Scan(&data).Error
+
+	return data, err
+}
+
+// Table and column names come from the object type registry and never from the user input
+func (repo *objectTypeRepository) GetColumnValues(detailTable string, columns []string, ids []uint) ([]map[string]interface{}, error) {
+	data := []map[string]interface{}{}
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	selectedColumns := append([]string{"id"}, columns...)
+	err := repo.db.Raw(
+		fmt.Sprintf(`SELECT %s FROM %s WHERE id IN ?`, strings.Join(selectedColumns, ", "), detailTable),
+		ids,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) GetCustomAttributeValues(customObjectIDs []uint) ([]dto.CustomObjectAttributeValueQuery, error) {
+	data := []dto.CustomObjectAttributeValueQuery{}
+	err := repo.db.Raw(`
+    SELECT
+      custom_object_id,
+      object_type_attribute_id,
+      value
+    FROM custom_object_attribute_values
+    WHERE custom_object_id IN ?
+    `, customObjectIDs).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) GetObjectByName(projectID uint, name string) (model.Object, error) {
+	data := model.Object{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM objects
+    WHERE
+      objects.project_id = ? AND
+      objects.name = ?
+    ORDER BY objects.id
+    LIMIT 1
+    `, projectID, name).Scan(&data).Error
+
+	return data, err
+}
+
+// Names of the objects feeding the object, kept in the feeders table of the object type
+func (repo *objectTypeRepository) GetFedByNames(objectID uint, objectType, fedByTable string) ([]string, error) {
+	data := []string{}
+	var err error
+	switch fedByTable {
+	case "tp_nourashes_objects":
+		err = repo.db.Raw(`
+      SELECT objects.name
+      FROM tp_nourashes_objects
+        INNER JOIN objects ON objects.id = tp_nourashes_objects.tp_object_id
+      WHERE
+        tp_nourashes_objects.target_id = ? AND
+        tp_nourashes_objects.target_type = ?
+      ORDER BY objects.id
+      `, objectID, objectType).Scan(&data).Error
+
+	case "substation_cell_nourashes_substation_objects":
+		err = repo.db.Raw(`
+      SELECT objects.name
+      FROM substation_cell_nourashes_substation_objects
+        INNER JOIN objects ON objects.id = substation_cell_nourashes_substation_objects.substation_object_id
+      WHERE substation_cell_nourashes_substation_objects.substation_cell_object_id = ?
+      ORDER BY objects.id
+      `, objectID).Scan(&data).Error
+
+	default:
+		err = repo.db.Raw(`
+      SELECT objects.name
+      FROM network_connections
+        INNER JOIN objects ON objects.id = network_connections.source_object_id
+      WHERE network_connections.target_object_id = ?
+      ORDER BY objects.id
+      `, objectID).Scan(&data).Error
+	}
+
+	return data, err
+}
+
+func (repo *objectTypeRepository) CreateObject(object model.Object, details dto.ObjectDetailsStorage, supervisors, teams []uint, feeding dto.ObjectFeeding) (model.Object, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		objectDetailedID, err := createObjectDetails(tx, details)
+		if err != nil {
+			return err
+		}
+
+		object.ObjectDetailedID = objectDetailedID
+		if err := tx.Create(&object).Error; err != nil {
+			return err
+		}
+
+		if err := saveObjectFeeding(tx, object, feeding); err != nil {
+			return err
+		}
+
+		return createObjectSupervisorsAndTeams(tx, object.ID, supervisors, teams)
+	})
+
+	return object, err
+}
+
+func (repo *objectTypeRepository) UpdateObject(object model.Object, details dto.ObjectDetailsStorage, supervisors, teams []uint, feeding dto.ObjectFeeding) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if details.DetailTable != "" {
+			if len(details.ColumnValues) != 0 {
+				if err := tx.Table(details.DetailTable).Where("id = ?", object.ObjectDetailedID).Updates(details.ColumnValues).Error; err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := tx.Delete(&model.CustomObjectAttributeValue{}, "custom_object_id = ?", object.ObjectDetailedID).Error; err != nil {
+				return err
+			}
+
+			if err := createCustomObjectAttributeValues(tx, object.ObjectDetailedID, details.AttributeValues); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&model.Object{}).Where("id = ?", object.ID).Update("name", object.Name).Error; err != nil {
+			return err
+		}
+
+		if err := saveObjectFeeding(tx, object, feeding); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectSupervisors{}, "object_id = ?", object.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectTeams{}, "object_id = ?", object.ID).Error; err != nil {
+			return err
+		}
+
+		return createObjectSupervisorsAndTeams(tx, object.ID, supervisors, teams)
+	})
+}
+
+func (repo *objectTypeRepository) DeleteObject(object model.Object, detailTable string) error {
+	attachmentFiles := []string{}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.ObjectSupervisors{}, "object_id = ?", object.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectTeams{}, "object_id = ?", object.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.TPNourashesObjects{}, "tp_object_id = ? OR target_id = ?", object.ID, object.ID).Error; err != nil {
+			return err
+		}
+
+		err := tx.Delete(
+			&model.SubstationCellNourashesSubstationObject{},
+			"substation_object_id = ? OR substation_cell_object_id = ?",
+			object.ID, object.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		if detailTable != "" {
+			if err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, detailTable), object.ObjectDetailedID).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Delete(&model.CustomObjectAttributeValue{}, "custom_object_id = ?", object.ObjectDetailedID).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.CustomObject{}, "id = ?", object.ObjectDetailedID).Error; err != nil {
+				return err
+			}
+		}
+
+		files, err := deleteObjectReferences(tx, object.ObjectDetailedID, object.Type)
+		if err != nil {
+			return err
+		}
+		attachmentFiles = files
+
+		return tx.Delete(&model.Object{}, "id = ?", object.ID).Error
+	})
+
+	if err == nil {
+		removeAttachmentFiles(attachmentFiles)
+	}
+
+	return err
+}
+
+func (repo *objectTypeRepository) CreateObjectsInBatches(projectID uint, objectType string, data []dto.TypedObjectImportData) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range data {
+			objectDetailedID, err := createObjectDetails(tx, row.Details)
+			if err != nil {
+				return err
+			}
+
+			object := model.Object{
+				ObjectDetailedID: objectDetailedID,
+				ProjectID:        projectID,
+				Type:             objectType,
+				Name:             row.Name,
+				Status:           row.Status,
+			}
+			if err := tx.Create(&object).Error; err != nil {
+				return err
+			}
+
+			if err := saveObjectFeeding(tx, object, row.Feeding); err != nil {
+				return err
+			}
+
+			if err := createObjectSupervisorsAndTeams(tx, object.ID, row.Supervisors, row.Teams); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Returns the id of the created details which becomes ObjectDetailedID of the object
+func createObjectDetails(tx *gorm.DB, details dto.ObjectDetailsStorage) (uint, error) {
+	if details.DetailTable == "" {
+		customObject := model.CustomObject{
+			ObjectTypeID: details.ObjectTypeID,
+		}
+		if err := tx.Create(&customObject).Error; err != nil {
+			return 0, err
+		}
+
+		return customObject.ID, createCustomObjectAttributeValues(tx, customObject.ID, details.AttributeValues)
+	}
+
+	columns := []string{}
+	for column := range details.ColumnValues {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var id uint
+	if len(columns) == 0 {
+		err := tx.Raw(fmt.Sprintf(`INSERT INTO %s DEFAULT VALUES RETURNING id`, details.DetailTable)).Scan(&id).Error
+		return id, err
+	}
+
+	values := []interface{}{}
+	placeholders := []string{}
+	for _, column := range columns {
+		values = append(values, details.ColumnValues[column])
+		placeholders = append(placeholders, "?")
+	}
+
+	err := tx.Raw(
+		fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES (%s) RETURNING id`,
+			details.DetailTable, strings.Join(columns, ", "), strings.Join(placeholders, ", "),
+		),
+		values...,
+	).Scan(&id).Error
+
+	return id, err
+}
+
+// Replaces the feeders of the object and the objects fed by it for the lists that are not nil
+func saveObjectFeeding(tx *gorm.DB, object model.Object, feeding dto.ObjectFeeding) error {
+	if feeding.FedBy != nil {
+		switch feeding.FedByTable {
+		case "tp_nourashes_objects":
+			if err := tx.Delete(&model.TPNourashesObjects{}, "target_id = ? AND target_type = ?", object.ID, object.Type).Error; err != nil {
+				return err
+			}
+
+			tpNourashesObjects := []model.TPNourashesObjects{}
+			for _, tpObjectID := range feeding.FedBy {
+				tpNourashesObjects = append(tpNourashesObjects, model.TPNourashesObjects{
+					TP_ObjectID: tpObjectID,
+					TargetID:    object.ID,
+					TargetType:  object.Type,
+				})
+			}
+
+			if err := tx.CreateInBatches(&tpNourashesObjects, 10).Error; err != nil {
+				return err
+			}
+
+		case "substation_cell_nourashes_substation_objects":
+			if err := tx.Delete(&model.SubstationCellNourashesSubstationObject{}, "substation_cell_object_id = ?", object.ID).Error; err != nil {
+				return err
+			}
+
+			nourashes := []model.SubstationCellNourashesSubstationObject{}
+			for _, substationObjectID := range feeding.FedBy {
+				nourashes = append(nourashes, model.SubstationCellNourashesSubstationObject{
+					SubstationObjectID:     substationObjectID,
+					SubstationCellObjectID: object.ID,
+				})
+			}
+
+			if err := tx.CreateInBatches(&nourashes, 10).Error; err != nil {
+				return err
+			}
+
+		default:
+			if err := tx.Delete(&model.NetworkConnection{}, "target_object_id = ?", object.ID).Error; err != nil {
+				return err
+			}
+
+			connections := []model.NetworkConnection{}
+			for _, sourceObjectID := range feeding.FedBy {
+				connections = append(connections, model.NetworkConnection{
+					ProjectID:      object.ProjectID,
+					SourceObjectID: sourceObjectID,
+					TargetObjectID: object.ID,
+				})
+			}
+
+			if err := tx.CreateInBatches(&connections, 10).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if feeding.Feeds != nil {
+		if err := tx.Delete(&model.NetworkConnection{}, "source_object_id = ?", object.ID).Error; err != nil {
+			return err
+		}
+
+		if err := createNetworkConnectionsFromSource(tx, object.ProjectID, object.ID, feeding.Feeds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createCustomObjectAttributeValues(tx *gorm.DB, customObjectID uint, attributeValues map[uint]string) error {
+	values := []model.CustomObjectAttributeValue{}
+	for attributeID, value := range attributeValues {
+		values = append(values, model.CustomObjectAttributeValue{
+			CustomObjectID:        customObjectID,
+			ObjectTypeAttributeID: attributeID,
+			Value:                 value,
+		})
+	}
+
+	return tx.CreateInBatches(&values, 20).Error
+}
+
+func createObjectSupervisorsAndTeams(tx *gorm.DB, objectID uint, supervisors, teams []uint) error {
+	objectSupervisors := []model.ObjectSupervisors{}
+	for _, supervisorWorkerID := range supervisors {
+		objectSupervisors = append(objectSupervisors, model.ObjectSupervisors{
+			ObjectID:           objectID,
+			SupervisorWorkerID: supervisorWorkerID,
+		})
+	}
+
+	if err := tx.CreateInBatches(&objectSupervisors, 5).Error; err != nil {
+		return err
+	}
+
+	objectTeams := []model.ObjectTeams{}
+	for _, teamID := range teams {
+		objectTeams = append(objectTeams, model.ObjectTeams{
+			ObjectID: objectID,
+			TeamID:   teamID,
+		})
+	}
+
+	return tx.CreateInBatches(&objectTeams, 5).Error
+}
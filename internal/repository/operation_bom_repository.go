@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type operationBOMRepository struct {
+	db *gorm.DB
+}
+
+func NewOperationBOMRepository(db *gorm.DB) IOperationBOMRepository {
+	return &operationBOMRepository{
+		db: db,
+	}
+}
+
+type IOperationBOMRepository interface {
+	GetByOperationID(operationID uint) ([]dto.OperationBOMItemView, error)
+	GetByOperationIDs(operationIDs []uint) ([]model.OperationBOMItem, error)
+	Replace(operationID uint, data []model.OperationBOMItem) error
+	GetDeviationsByInvoiceObjectID(invoiceObjectID uint) ([]dto.InvoiceObjectBOMDeviationView, error)
+}
+
+func (repo *operationBOMRepository) GetByOperationID(operationID uint) ([]dto.OperationBOMItemView, error) {
+	data := []dto.OperationBOMItemView{}
+	err := repo.db.Raw(`
+    SELECT
+      operation_bom_items.id as id,
+      operation_bom_items.operation_id as operation_id,
+      materials.id as material_id,
+      materials.name as material_name,
+      materials.unit as material_unit,
+      operation_bom_items.amount as amount
+    FROM operation_bom_items
+      INNER JOIN materials ON materials.id = operation_bom_items.material_id
+    WHERE operation_bom_items.operation_id = ?
+    ORDER BY materials.name
+    `, operationID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *operationBOMRepository) GetByOperationIDs(operationIDs []uint) ([]model.OperationBOMItem, error) {
+	data := []model.OperationBOMItem{}
+	if len(operationIDs) == 0 {
+		return data, nil
+	}
+
+	err := repo.db.Order("id").Find(&data, "operation_id IN ?", operationIDs).Error
+	return data, err
+}
+
+func (repo *operationBOMRepository) Replace(operationID uint, data []model.OperationBOMItem) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.OperationBOMItem{}, "operation_id = ?", operationID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.CreateInBatches(&data, 15).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (repo *operationBOMRepository) GetDeviationsByInvoiceObjectID(invoiceObjectID uint) ([]dto.InvoiceObjectBOMDeviationView, error) {
+	data := []dto.InvoiceObjectBOMDeviationView{}
+	err := repo.db.Raw(`
+    SELECT
+      materials.id as material_id,
+      materials.name as material_name,
+      materials.unit as material_unit,
+      invoice_object_bom_deviations.expected_amount as expected_amount,
+      invoice_object_bom_deviations.actual_amount as actual_amount,
+      invoice_object_bom_deviations.deviation_percent as deviation_percent
+    FROM invoice_object_bom_deviations
+      INNER JOIN materials ON materials.id = invoice_object_bom_deviations.material_id
+    WHERE invoice_object_bom_deviations.invoice_object_id = ?
+    ORDER BY materials.name
+    `, invoiceObjectID).Scan(&data).Error
+
+	return data, err
+}
@@ -142,6 +142,14 @@ func (repo *operationRepository) Delete(id uint) error {
 			return err
 		}
 
+		if err := tx.Delete(&model.OperationBOMItem{}, "operation_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.ObjectPlannedOperation{}, "operation_id = ?", id).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Delete(&model.Operation{}, "id = ?", id).Error; err != nil {
 			return err
 		}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type operatorErrorFoundRepository struct {
+	db *gorm.DB
+}
+
+func NewOperatorErrorFoundRepository(db *gorm.DB) IOperatorErrorFoundRepository {
+	return &operatorErrorFoundRepository{
+		db: db,
+	}
+}
+
+type IOperatorErrorFoundRepository interface {
+	GetDataForReport(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundReportData, error)
+	StatisticsBySupervisor(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+	StatisticsByTeam(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+	StatisticsByMaterial(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+}
+
+func (repo *operatorErrorFoundRepository) GetDataForReport(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundReportData, error) {
+	data := []dto.OperatorErrorFoundReportData{}
+	err := repo.db.Raw(`
+    SELECT
+      invoice_objects.delivery_code AS delivery_code,
+      objects.name AS object_name,
+      objects.type AS object_type,
+      supervisor.name AS supervisor_name,
+      teams.number AS team_number,
+      COALESCE((
+        SELECT STRING_AGG(operator.name, ', ' ORDER BY operator.name)
+        FROM invoice_object_operators
+        INNER JOIN workers AS operator ON operator.id = invoice_object_operators.operator_worker_id
+        WHERE invoice_object_operators.invoice_object_id = invoice_objects.id
+      ), '') AS operator_name,
+      invoice_objects.date_of_invoice AS date_of_invoice,
+      operator_error_founds.date_of_correction AS date_of_correction,
+      materials.name AS material_name,
+      materials.unit AS material_unit,
+      material_costs.cost_m19 AS material_cost_m19,
+      operator_error_founds.expected_amount AS expected_amount,
+      operator_error_founds.corrected_amount AS corrected_amount,
+      operator_error_founds.amount AS amount,
+      operator_error_founds.notes AS notes
+    FROM operator_error_founds
+    INNER JOIN invoice_objects ON invoice_objects.id = operator_error_founds.invoice_object_id
+    INNER JOIN objects ON objects.id = invoice_objects.object_id
+    INNER JOIN workers AS supervisor ON supervisor.id = invoice_objects.supervisor_worker_id
+    INNER JOIN teams ON teams.id = invoice_objects.team_id
+    INNER JOIN material_costs ON material_costs.id = operator_error_founds.material_cost_id
+    INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      operator_error_founds.project_id = ? AND
+      (? = 0 OR invoice_objects.team_id = ?) AND
+      (? = 0 OR invoice_objects.supervisor_worker_id = ?) AND
+      (? = 0 OR materials.id = ?) AND
+      (? OR operator_error_founds.date_of_correction >= ?) AND
+      (? OR operator_error_founds.date_of_correction <= ?)
+    ORDER BY operator_error_founds.id DESC
+    `,
+		filter.ProjectID,
+		filter.TeamID, filter.TeamID,
+		filter.SupervisorWorkerID, filter.SupervisorWorkerID,
+		filter.MaterialID, filter.MaterialID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *operatorErrorFoundRepository) StatisticsBySupervisor(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	data := []dto.OperatorErrorFoundStatistics{}
+	err := repo.db.Raw(`
+    SELECT
+      workers.id AS id,
+      workers.name AS label,
+      COUNT(operator_error_founds.id) AS error_count,
+      COUNT(DISTINCT operator_error_founds.invoice_object_id) AS invoice_count,
+      SUM(ABS(operator_error_founds.amount)) AS discrepancy_amount
+    FROM operator_error_founds
+    INNER JOIN invoice_objects ON invoice_objects.id = operator_error_founds.invoice_object_id
+    INNER JOIN workers ON workers.id = invoice_objects.supervisor_worker_id
+    WHERE
+      operator_error_founds.project_id = ? AND
+      (? OR operator_error_founds.date_of_correction >= ?) AND
+      (? OR operator_error_founds.date_of_correction <= ?)
+    GROUP BY workers.id, workers.name
+    ORDER BY error_count DESC
+    `,
+		filter.ProjectID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *operatorErrorFoundRepository) StatisticsByTeam(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	data := []dto.OperatorErrorFoundStatistics{}
+	err := repo.db.Raw(`
+    SELECT
+      teams.id AS id,
+      teams.number AS label,
+      COUNT(operator_error_founds.id) AS error_count,
+      COUNT(DISTINCT operator_error_founds.invoice_object_id) AS invoice_count,
+      SUM(ABS(operator_error_founds.amount)) AS discrepancy_amount
+    FROM operator_error_founds
+    INNER JOIN invoice_objects ON invoice_objects.id = operator_error_founds.invoice_object_id
+    INNER JOIN teams ON teams.id = invoice_objects.team_id
+    WHERE
+      operator_error_founds.project_id = ? AND
+      (? OR operator_error_founds.date_of_correction >= ?) AND
+      (? OR operator_error_founds.date_of_correction <= ?)
+    GROUP BY teams.id, teams.number
+    ORDER BY error_count DESC
+    `,
+		filter.ProjectID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *operatorErrorFoundRepository) StatisticsByMaterial(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	data := []dto.OperatorErrorFoundStatistics{}
+	err := repo.db.Raw(`
+    SELECT
+      materials.id AS id,
+      materials.name AS label,
+      COUNT(operator_error_founds.id) AS error_count,
+      COUNT(DISTINCT operator_error_founds.invoice_object_id) AS invoice_count,
+      SUM(ABS(operator_error_founds.amount)) AS discrepancy_amount
+    FROM operator_error_founds
+    INNER JOIN material_costs ON material_costs.id = operator_error_founds.material_cost_id
+    INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      operator_error_founds.project_id = ? AND
+      (? OR operator_error_founds.date_of_correction >= ?) AND
+      (? OR operator_error_founds.date_of_correction <= ?)
+    GROUP BY materials.id, materials.name
+    ORDER BY error_count DESC
+    `,
+		filter.ProjectID,
+		filter.DateFrom.IsZero(), filter.DateFrom,
+		filter.DateTo.IsZero(), filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"backend-v2/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type projectCalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectCalendarRepository(db *gorm.DB) IProjectCalendarRepository {
+	return &projectCalendarRepository{
+		db: db,
+	}
+}
+
+type IProjectCalendarRepository interface {
+	GetInRange(projectID uint, dateFrom, dateTo time.Time) ([]model.ProjectCalendarDay, error)
+	GetByDate(projectID uint, date time.Time) (model.ProjectCalendarDay, error)
+	Create(data model.ProjectCalendarDay) (model.ProjectCalendarDay, error)
+	Delete(projectID, id uint) error
+}
+
+func (repo *projectCalendarRepository) GetInRange(projectID uint, dateFrom, dateTo time.Time) ([]model.ProjectCalendarDay, error) {
+	data := []model.ProjectCalendarDay{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM project_calendar_days
+    WHERE
+      project_id = ? AND
+      date >= ? AND
+      date <= ?
+    ORDER BY date
+    `, projectID, dateFrom, dateTo).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *projectCalendarRepository) GetByDate(projectID uint, date time.Time) (model.ProjectCalendarDay, error) {
+	data := model.ProjectCalendarDay{}
+	err := repo.db.Raw(`SELECT * FROM project_calendar_days WHERE project_id = ? AND date = ?`, projectID, date).Scan(&data).Error
+	return data, err
+}
+
+func (repo *projectCalendarRepository) Create(data model.ProjectCalendarDay) (model.ProjectCalendarDay, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *projectCalendarRepository) Delete(projectID, id uint) error {
+	return repo.db.Delete(&model.ProjectCalendarDay{}, "project_id = ? AND id = ?", projectID, id).Error
+}
@@ -26,6 +26,7 @@ type IProjectRepository interface {
 	Delete(id uint) error
 	Count() (int64, error)
 	GetProjectName(projectID uint) (string, error)
+	UpdateCostAllocationStrategy(projectID uint, strategy string) error
 }
 
 func (repo *projectRepository) GetAll() ([]model.Project, error) {
@@ -93,8 +94,9 @@ func (repo *projectRepository) Create(data model.Project) (model.Project, error)
 	return data, err
 }
 
+// Cost allocation strategy is changed only through UpdateCostAllocationStrategy
 func (repo *projectRepository) Update(data model.Project) (model.Project, error) {
-	err := repo.db.Model(&model.Project{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	err := repo.db.Model(&model.Project{}).Select("*").Omit("cost_allocation_strategy").Where("id = ?", data.ID).Updates(&data).Error
 	return data, err
 }
 
@@ -124,3 +126,7 @@ func (repo *projectRepository) GetProjectName(projectID uint) (string, error) {
 	err := repo.db.Raw(`SELECT name FROM projects WHERE id = ?`, projectID).Scan(&projectName).Error
 	return projectName, err
 }
+
+func (repo *projectRepository) UpdateCostAllocationStrategy(projectID uint, strategy string) error {
+	return repo.db.Model(&model.Project{}).Where("id = ?", projectID).Update("cost_allocation_strategy", strategy).Error
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type qualificationRepository struct {
+	db *gorm.DB
+}
+
+func NewQualificationRepository(db *gorm.DB) IQualificationRepository {
+	return &qualificationRepository{
+		db: db,
+	}
+}
+
+type IQualificationRepository interface {
+	GetTypes(projectID uint) ([]model.QualificationType, error)
+	GetTypeByID(id uint) (model.QualificationType, error)
+	CreateType(data model.QualificationType) (model.QualificationType, error)
+	UpdateType(data model.QualificationType) (model.QualificationType, error)
+	DeleteType(projectID, id uint) error
+	CountByType(typeID uint) (int64, error)
+	GetByWorkerID(workerID uint) ([]dto.WorkerQualificationView, error)
+	GetByID(id uint) (model.WorkerQualification, error)
+	Create(data model.WorkerQualification) (model.WorkerQualification, error)
+	Update(data model.WorkerQualification) (model.WorkerQualification, error)
+	Delete(id uint) error
+	GetExpiring(projectID uint, date time.Time) ([]dto.WorkerQualificationView, error)
+}
+
+func (repo *qualificationRepository) GetTypes(projectID uint) ([]model.QualificationType, error) {
+	data := []model.QualificationType{}
+	err := repo.db.Order("name").Find(&data, "project_id = ?", projectID).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) GetTypeByID(id uint) (model.QualificationType, error) {
+	data := model.QualificationType{}
+	err := repo.db.Find(&data, "id = ?", id).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) CreateType(data model.QualificationType) (model.QualificationType, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) UpdateType(data model.QualificationType) (model.QualificationType, error) {
+	err := repo.db.Model(&model.QualificationType{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) DeleteType(projectID, id uint) error {
+	return repo.db.Delete(&model.QualificationType{}, "project_id = ? AND id = ?", projectID, id).Error
+}
+
+func (repo *qualificationRepository) CountByType(typeID uint) (int64, error) {
+	var count int64
+	err := repo.db.Model(&model.WorkerQualification{}).Where("qualification_type_id = ?", typeID).Count(&count).Error
+	return count, err
+}
+
+func (repo *qualificationRepository) GetByWorkerID(workerID uint) ([]dto.WorkerQualificationView, error) {
+	data := []dto.WorkerQualificationView{}
+	err := repo.db.Raw(`
+    SELECT
+      worker_qualifications.id as id,
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id,
+      qualification_types.id as qualification_type_id,
+      qualification_types.name as qualification_type_name,
+      worker_qualifications.number as number,
+      worker_qualifications.grade as grade,
+      worker_qualifications.issued_at as issued_at,
+      worker_qualifications.expires_at as expires_at,
+      worker_qualifications.document_name as document_name
+    FROM worker_qualifications
+      INNER JOIN workers ON workers.id = worker_qualifications.worker_id
+      INNER JOIN qualification_types ON qualification_types.id = worker_qualifications.qualification_type_id
+    WHERE worker_qualifications.worker_id = ?
+    ORDER BY qualification_types.name, worker_qualifications.expires_at DESC
+    `, workerID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *qualificationRepository) GetByID(id uint) (model.WorkerQualification, error) {
+	data := model.WorkerQualification{}
+	err := repo.db.Find(&data, "id = ?", id).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) Create(data model.WorkerQualification) (model.WorkerQualification, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) Update(data model.WorkerQualification) (model.WorkerQualification, error) {
+	err := repo.db.Model(&model.WorkerQualification{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	return data, err
+}
+
+func (repo *qualificationRepository) Delete(id uint) error {
+	return repo.db.Delete(&model.WorkerQualification{}, "id = ?", id).Error
+}
+
+// Latest qualification of every worker and type that expires before the date,
+// the ones already replaced with the newer certificate are left out
+func (repo *qualificationRepository) GetExpiring(projectID uint, date time.Time) ([]dto.WorkerQualificationView, error) {
+	data := []dto.WorkerQualificationView{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM (
+      SELECT DISTINCT ON (worker_qualifications.worker_id, worker_qualifications.qualification_type_id)
+        worker_qualifications.id as id,
+        workers.id as worker_id,
+        workers.name as worker_name,
+        workers.company_worker_id as company_worker_id,
+        qualification_types.id as qualification_type_id,
+        qualification_types.name as qualification_type_name,
+        worker_qualifications.number as number,
+        worker_qualifications.grade as grade,
+        worker_qualifications.issued_at as issued_at,
+        worker_qualifications.expires_at as expires_at,
+        worker_qualifications.document_name as document_name
+      FROM worker_qualifications
+        INNER JOIN workers ON workers.id = worker_qualifications.worker_id
+        INNER JOIN qualification_types ON qualification_types.id = worker_qualifications.qualification_type_id
+      WHERE worker_qualifications.project_id = ?
+      ORDER BY worker_qualifications.worker_id, worker_qualifications.qualification_type_id, worker_qualifications.expires_at DESC
+    ) latest
+    WHERE latest.expires_at < ?
+    ORDER BY latest.expires_at, latest.worker_name
+    `, projectID, date).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) IScheduleRepository {
+	return &scheduleRepository{
+		db: db,
+	}
+}
+
+type IScheduleRepository interface {
+	GetAssignments(filter dto.ScheduleFilter) ([]dto.TeamAssignmentQueryResult, error)
+	GetAssignmentOperations(assignmentIDs []uint) ([]dto.TeamAssignmentOperationQueryResult, error)
+	GetByID(id uint) (model.TeamAssignment, error)
+	GetOverlapping(teamID uint, dateFrom, dateTo time.Time, exceptID uint) ([]dto.TeamAssignmentQueryResult, error)
+	GetActualOperations(assignment model.TeamAssignment) ([]dto.ScheduleActualOperationQueryResult, error)
+	CountInvoices(assignment model.TeamAssignment) (int64, error)
+	Create(data model.TeamAssignment, operations []model.TeamAssignmentOperation) (model.TeamAssignment, error)
+	Update(data model.TeamAssignment, operations []model.TeamAssignmentOperation) (model.TeamAssignment, error)
+	Delete(id uint) error
+}
+
+// Assignments that share at least one day with the period
+func (repo *scheduleRepository) GetAssignments(filter dto.ScheduleFilter) ([]dto.TeamAssignmentQueryResult, error) {
+	data := []dto.TeamAssignmentQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      team_assignments.id as id,
+      teams.id as team_id,
+      teams.number as team_number,
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      team_assignments.district_id as district_id,
+      COALESCE(districts.name, '') as district_name,
+      team_assignments.date_from as date_from,
+      team_assignments.date_to as date_to,
+      team_assignments.notes as notes
+    FROM team_assignments
+      INNER JOIN teams ON teams.id = team_assignments.team_id
+      INNER JOIN objects ON objects.id = team_assignments.object_id
+      LEFT JOIN districts ON districts.id = team_assignments.district_id
+    WHERE
+      team_assignments.project_id = ? AND
+      (nullif(?, 0) IS NULL OR team_assignments.team_id = ?) AND
+      (nullif(?, 0) IS NULL OR team_assignments.object_id = ?) AND
+      (nullif(?, 0) IS NULL OR team_assignments.district_id = ?) AND
+      team_assignments.date_to >= ?::date AND
+      team_assignments.date_from <= ?::date
+    ORDER BY team_assignments.date_from, teams.number, objects.name
+    `,
+		filter.ProjectID,
+		filter.TeamID, filter.TeamID,
+		filter.ObjectID, filter.ObjectID,
+		filter.DistrictID, filter.DistrictID,
+		filter.DateFrom, filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *scheduleRepository) GetAssignmentOperations(assignmentIDs []uint) ([]dto.TeamAssignmentOperationQueryResult, error) {
+	data := []dto.TeamAssignmentOperationQueryResult{}
+	if len(assignmentIDs) == 0 {
+		return data, nil
+	}
+
+	err := repo.db.Raw(`
+    SELECT
+      team_assignment_operations.team_assignment_id as team_assignment_id,
+      operations.id as operation_id,
+      operations.code as code,
+      operations.name as name,
+      team_assignment_operations.amount as amount
+    FROM team_assignment_operations
+      INNER JOIN operations ON operations.id = team_assignment_operations.operation_id
+    WHERE team_assignment_operations.team_assignment_id IN ?
+    ORDER BY team_assignment_operations.team_assignment_id, operations.code
+    `, assignmentIDs).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *scheduleRepository) GetByID(id uint) (model.TeamAssignment, error) {
+	data := model.TeamAssignment{}
+	err := repo.db.Raw(`SELECT * FROM team_assignments WHERE id = ?`, id).Scan(&data).Error
+	return data, err
+}
+
+// Other assignments of the team that share at least one day with the period
+func (repo *scheduleRepository) GetOverlapping(teamID uint, dateFrom, dateTo time.Time, exceptID uint) ([]dto.TeamAssignmentQueryResult, error) {
+	data := []dto.TeamAssignmentQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      team_assignments.id as id,
+      teams.id as team_id,
+      teams.number as team_number,
+      objects.id as object_id,
+      objects.name as object_name,
+      objects.type as object_type,
+      team_assignments.district_id as district_id,
+      team_assignments.date_from as date_from,
+      team_assignments.date_to as date_to,
+      team_assignments.notes as notes
+    FROM team_assignments
+      INNER JOIN teams ON teams.id = team_assignments.team_id
+      INNER JOIN objects ON objects.id = team_assignments.object_id
+    WHERE
+      team_assignments.team_id = ? AND
+      team_assignments.id <> ? AND
+      team_assignments.date_to >= ?::date AND
+      team_assignments.date_from <= ?::date
+    ORDER BY team_assignments.date_from
+    `, teamID, exceptID, dateFrom, dateTo).Scan(&data).Error
+
+	return data, err
+}
+
+// Operations of the object invoices of the team on the object during the assignment
+func (repo *scheduleRepository) GetActualOperations(assignment model.TeamAssignment) ([]dto.ScheduleActualOperationQueryResult, error) {
+	data := []dto.ScheduleActualOperationQueryResult{}
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      operations.id as operation_id,
+      operations.code as code,
+      operations.name as name,
+      SUM(invoice_operations.amount) as amount
+    FROM invoice_objects
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operations ON operations.id = invoice_operations.operation_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      invoice_objects.team_id = ? AND
+      invoice_objects.object_id = ? AND
+      invoice_objects.date_of_invoice::date >= ?::date AND
+      invoice_objects.date_of_invoice::date <= ?::date AND
+      %s
+    GROUP BY operations.id, operations.code, operations.name
+    ORDER BY operations.code
+    `, confirmedInvoiceAmounts("invoice_operations")),
+		assignment.ProjectID, assignment.TeamID, assignment.ObjectID,
+		assignment.DateFrom, assignment.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *scheduleRepository) CountInvoices(assignment model.TeamAssignment) (int64, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM invoice_objects
+    WHERE
+      project_id = ? AND
+      team_id = ? AND
+      object_id = ? AND
+      date_of_invoice::date >= ?::date AND
+      date_of_invoice::date <= ?::date
+    `,
+		assignment.ProjectID, assignment.TeamID, assignment.ObjectID,
+		assignment.DateFrom, assignment.DateTo,
+	).Scan(&count).Error
+
+	return count, err
+}
+
+func (repo *scheduleRepository) Create(data model.TeamAssignment, operations []model.TeamAssignmentOperation) (model.TeamAssignment, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&data).Error; err != nil {
+			return err
+		}
+
+		if len(operations) == 0 {
+			return nil
+		}
+
+		for index := range operations {
+			operations[index].TeamAssignmentID = data.ID
+		}
+
+		return tx.CreateInBatches(&operations, 50).Error
+	})
+
+	return data, err
+}
+
+func (repo *scheduleRepository) Update(data model.TeamAssignment, operations []model.TeamAssignmentOperation) (model.TeamAssignment, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.TeamAssignment{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.TeamAssignmentOperation{}, "team_assignment_id = ?", data.ID).Error; err != nil {
+			return err
+		}
+
+		if len(operations) == 0 {
+			return nil
+		}
+
+		for index := range operations {
+			operations[index].TeamAssignmentID = data.ID
+		}
+
+		return tx.CreateInBatches(&operations, 50).Error
+	})
+
+	return data, err
+}
+
+func (repo *scheduleRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.TeamAssignmentOperation{}, "team_assignment_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&model.TeamAssignment{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type selfServiceRepository struct {
+	db *gorm.DB
+}
+
+func NewSelfServiceRepository(db *gorm.DB) ISelfServiceRepository {
+	return &selfServiceRepository{
+		db: db,
+	}
+}
+
+type ISelfServiceRepository interface {
+	GetTeams(workerID uint, date time.Time) ([]dto.SelfServiceTeam, error)
+	GetInvoices(projectID, workerID uint, dateFrom, dateTo time.Time) ([]dto.SelfServiceInvoice, error)
+	GetAttendance(workerID uint, dateFrom, dateTo time.Time) ([]model.WorkerAttendance, error)
+}
+
+// Teams led by the worker and the team the worker is a member of on the date
+func (repo *selfServiceRepository) GetTeams(workerID uint, date time.Time) ([]dto.SelfServiceTeam, error) {
+	data := []dto.SelfServiceTeam{}
+	err := repo.db.Raw(`
+    SELECT
+      teams.id as team_id,
+      teams.number as team_number,
+      'leader' as role,
+      '0001-01-01'::date as effective_from
+    FROM team_leaders
+      INNER JOIN teams ON teams.id = team_leaders.team_id
+    WHERE team_leaders.leader_worker_id = ?
+    UNION ALL
+    SELECT
+      teams.id as team_id,
+      teams.number as team_number,
+      'member' as role,
+      team_members.effective_from as effective_from
+    FROM team_members
+      INNER JOIN teams ON teams.id = team_members.team_id
+    WHERE
+      team_members.worker_id = ? AND
+      team_members.effective_from <= ?::date AND
+      (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= ?::date)
+    ORDER BY team_number, role
+    `, workerID, workerID, date, date).Scan(&data).Error
+
+	return data, err
+}
+
+// Invoices where the worker is the recipient or the one who released the materials,
+// zero dates mean the period is not limited
+func (repo *selfServiceRepository) GetInvoices(projectID, workerID uint, dateFrom, dateTo time.Time) ([]dto.SelfServiceInvoice, error) {
+	data := []dto.SelfServiceInvoice{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM (
+      SELECT
+        'output' as invoice_type,
+        invoice_outputs.id as id,
+        invoice_outputs.delivery_code as delivery_code,
+        invoice_outputs.date_of_invoice as date_of_invoice,
+        CASE WHEN invoice_outputs.recipient_worker_id = ? THEN 'recipient' ELSE 'released' END as role,
+        invoice_outputs.confirmation as confirmed,
+        COALESCE(teams.number, '') as team_number
+      FROM invoice_outputs
+        LEFT JOIN teams ON teams.id = invoice_outputs.team_id
+      WHERE
+        invoice_outputs.project_id = ? AND
+        (invoice_outputs.recipient_worker_id = ? OR invoice_outputs.released_worker_id = ?)
+      UNION ALL
+      SELECT
+        'input' as invoice_type,
+        invoice_inputs.id as id,
+        invoice_inputs.delivery_code as delivery_code,
+        invoice_inputs.date_of_invoice as date_of_invoice,
+        'released' as role,
+        invoice_inputs.confirmed as confirmed,
+        '' as team_number
+      FROM invoice_inputs
+      WHERE
+        invoice_inputs.project_id = ? AND
+        invoice_inputs.released_worker_id = ?
+      UNION ALL
+      SELECT
+        'output-out-of-project' as invoice_type,
+        invoice_output_out_of_projects.id as id,
+        invoice_output_out_of_projects.delivery_code as delivery_code,
+        invoice_output_out_of_projects.date_of_invoice as date_of_invoice,
+        'released' as role,
+        invoice_output_out_of_projects.confirmation as confirmed,
+        '' as team_number
+      FROM invoice_output_out_of_projects
+      WHERE
+        invoice_output_out_of_projects.project_id = ? AND
+        invoice_output_out_of_projects.released_worker_id = ?
+      UNION ALL
+      SELECT
+        'writeoff' as invoice_type,
+        invoice_write_offs.id as id,
+        invoice_write_offs.delivery_code as delivery_code,
+        invoice_write_offs.date_of_invoice as date_of_invoice,
+        'released' as role,
+        invoice_write_offs.confirmation as confirmed,
+        '' as team_number
+      FROM invoice_write_offs
+      WHERE
+        invoice_write_offs.project_id = ? AND
+        invoice_write_offs.released_worker_id = ?
+    ) AS signed_invoices
+    WHERE
+      (? OR date_of_invoice >= ?) AND
+      (? OR date_of_invoice <= ?)
+    ORDER BY date_of_invoice DESC, id DESC
+    `,
+		workerID,
+		projectID, workerID, workerID,
+		projectID, workerID,
+		projectID, workerID,
+		projectID, workerID,
+		dateFrom.IsZero(), dateFrom,
+		dateTo.IsZero(), dateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *selfServiceRepository) GetAttendance(workerID uint, dateFrom, dateTo time.Time) ([]model.WorkerAttendance, error) {
+	data := []model.WorkerAttendance{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM worker_attendances
+    WHERE
+      worker_id = ? AND
+      start >= ? AND
+      start <= ?
+    ORDER BY start
+    `, workerID, dateFrom, dateTo).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type teamEarningsRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamEarningsRepository(db *gorm.DB) ITeamEarningsRepository {
+	return &teamEarningsRepository{
+		db: db,
+	}
+}
+
+type ITeamEarningsRepository interface {
+	GetOperations(filter dto.TeamEarningsFilter) ([]dto.TeamEarningsOperationQueryResult, error)
+	GetMembers(projectID, teamID uint, dateFrom, dateTo time.Time) ([]dto.TeamEarningsMemberQueryResult, error)
+	GetWorkerHours(projectID, teamID uint, dateFrom, dateTo time.Time) ([]dto.TeamEarningsWorkerHours, error)
+	GetAdjustments(filter dto.TeamEarningsFilter) ([]model.TeamEarningAdjustment, error)
+	GetUnchargedLosses(filter dto.TeamEarningsFilter) ([]dto.TeamEarningsLossQueryResult, error)
+	CreateAdjustments(data []model.TeamEarningAdjustment) ([]model.TeamEarningAdjustment, error)
+	DeleteAdjustment(projectID, id uint) error
+}
+
+// Teams are paid for the operations of the invoices confirmed by operator
+// in the amounts written in the correction
+func (repo *teamEarningsRepository) GetOperations(filter dto.TeamEarningsFilter) ([]dto.TeamEarningsOperationQueryResult, error) {
+	data := []dto.TeamEarningsOperationQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      teams.id as team_id,
+      teams.number as team_number,
+      operations.id as operation_id,
+      operations.code as code,
+      operations.name as name,
+      SUM(invoice_operations.amount) as amount,
+      operations.cost_prime as price,
+      SUM(invoice_operations.amount * operations.cost_prime) as "sum"
+    FROM invoice_objects
+      INNER JOIN teams ON teams.id = invoice_objects.team_id
+      INNER JOIN invoice_operations ON invoice_operations.invoice_id = invoice_objects.id
+      INNER JOIN operations ON operations.id = invoice_operations.operation_id
+    WHERE
+      invoice_objects.project_id = ? AND
+      invoice_objects.confirmed_by_operator = true AND
+      invoice_operations.invoice_type = 'object-correction' AND
+      (nullif(?, 0) IS NULL OR invoice_objects.team_id = ?) AND
+      invoice_objects.date_of_invoice >= ? AND
+      invoice_objects.date_of_invoice <= ?
+    GROUP BY teams.id, teams.number, operations.id, operations.code, operations.name, operations.cost_prime
+    HAVING SUM(invoice_operations.amount) <> 0
+    ORDER BY teams.number, teams.id, operations.code
+    `,
+		filter.ProjectID,
+		filter.TeamID, filter.TeamID,
+		filter.DateFrom, filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Workers that were in the teams at least one day of the period
+func (repo *teamEarningsRepository) GetMembers(projectID, teamID uint, dateFrom, dateTo time.Time) ([]dto.TeamEarningsMemberQueryResult, error) {
+	data := []dto.TeamEarningsMemberQueryResult{}
+	err := repo.db.Raw(`
+    SELECT DISTINCT
+      teams.id as team_id,
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id
+    FROM teams
+      INNER JOIN team_members ON team_members.team_id = teams.id
+      INNER JOIN workers ON workers.id = team_members.worker_id
+    WHERE
+      teams.project_id = ? AND
+      (nullif(?, 0) IS NULL OR teams.id = ?) AND
+      team_members.effective_from <= ?::date AND
+      (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= ?::date)
+    ORDER BY teams.id, workers.name
+    `, projectID, teamID, teamID, dateTo, dateFrom).Scan(&data).Error
+
+	return data, err
+}
+
+// Hours of the workers counted for the team they were members of on the day of attendance
+func (repo *teamEarningsRepository) GetWorkerHours(projectID, teamID uint, dateFrom, dateTo time.Time) ([]dto.TeamEarningsWorkerHours, error) {
+	data := []dto.TeamEarningsWorkerHours{}
+	err := repo.db.Raw(`
+    SELECT
+      team_members.team_id as team_id,
+      worker_attendances.worker_id as worker_id,
+      SUM(EXTRACT(EPOCH FROM (worker_attendances.end - worker_attendances.start)) / 3600) as hours
+    FROM worker_attendances
+      INNER JOIN team_members ON
+        team_members.worker_id = worker_attendances.worker_id AND
+        team_members.effective_from <= worker_attendances.start::date AND
+        (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= worker_attendances.start::date)
+      INNER JOIN teams ON teams.id = team_members.team_id
+    WHERE
+      worker_attendances.project_id = ? AND
+      teams.project_id = ? AND
+      (nullif(?, 0) IS NULL OR teams.id = ?) AND
+      worker_attendances.start >= ? AND
+      worker_attendances.start <= ? AND
+      worker_attendances.end > worker_attendances.start
+    GROUP BY team_members.team_id, worker_attendances.worker_id
+    `, projectID, projectID, teamID, teamID, dateFrom, dateTo).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamEarningsRepository) GetAdjustments(filter dto.TeamEarningsFilter) ([]model.TeamEarningAdjustment, error) {
+	data := []model.TeamEarningAdjustment{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM team_earning_adjustments
+    WHERE
+      project_id = ? AND
+      (nullif(?, 0) IS NULL OR team_id = ?) AND
+      date >= ? AND
+      date <= ?
+    ORDER BY date, id
+    `,
+		filter.ProjectID,
+		filter.TeamID, filter.TeamID,
+		filter.DateFrom, filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+// Confirmed losses of the team valued at CostM19 that were not charged yet
+func (repo *teamEarningsRepository) GetUnchargedLosses(filter dto.TeamEarningsFilter) ([]dto.TeamEarningsLossQueryResult, error) {
+	data := []dto.TeamEarningsLossQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      invoice_write_offs.id as invoice_write_off_id,
+      invoice_write_offs.write_off_location_id as team_id,
+      invoice_write_offs.delivery_code as delivery_code,
+      invoice_write_offs.date_of_invoice as date_of_invoice,
+      SUM(invoice_materials.amount * material_costs.cost_m19) as "sum"
+    FROM invoice_write_offs
+      INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_write_offs.id
+      INNER JOIN material_costs ON material_costs.id = invoice_materials.material_cost_id
+    WHERE
+      invoice_write_offs.project_id = ? AND
+      invoice_write_offs.write_off_type = 'loss-team' AND
+      invoice_write_offs.confirmation = true AND
+      invoice_materials.invoice_type = 'writeoff' AND
+      (nullif(?, 0) IS NULL OR invoice_write_offs.write_off_location_id = ?) AND
+      invoice_write_offs.date_of_invoice >= ? AND
+      invoice_write_offs.date_of_invoice <= ? AND
+      NOT EXISTS (
+        SELECT 1
+        FROM team_earning_adjustments
+        WHERE team_earning_adjustments.invoice_write_off_id = invoice_write_offs.id
+      )
+    GROUP BY invoice_write_offs.id, invoice_write_offs.write_off_location_id, invoice_write_offs.delivery_code, invoice_write_offs.date_of_invoice
+    ORDER BY invoice_write_offs.date_of_invoice, invoice_write_offs.id
+    `,
+		filter.ProjectID,
+		filter.TeamID, filter.TeamID,
+		filter.DateFrom, filter.DateTo,
+	).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamEarningsRepository) CreateAdjustments(data []model.TeamEarningAdjustment) ([]model.TeamEarningAdjustment, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	err := repo.db.CreateInBatches(&data, 50).Error
+	return data, err
+}
+
+func (repo *teamEarningsRepository) DeleteAdjustment(projectID, id uint) error {
+	return repo.db.Delete(&model.TeamEarningAdjustment{}, "project_id = ? AND id = ?", projectID, id).Error
+}
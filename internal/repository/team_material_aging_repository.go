@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type teamMaterialAgingRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamMaterialAgingRepository(db *gorm.DB) ITeamMaterialAgingRepository {
+	return &teamMaterialAgingRepository{
+		db: db,
+	}
+}
+
+type ITeamMaterialAgingRepository interface {
+	GetHeld(filter dto.TeamMaterialAgingFilter) ([]dto.TeamMaterialHeldQueryResult, error)
+	GetIssues(filter dto.TeamMaterialAgingFilter) ([]dto.TeamMaterialIssueQueryResult, error)
+	GetSerialNumbers(filter dto.TeamMaterialAgingFilter) ([]dto.TeamSerialNumberHeldQueryResult, error)
+	GetLeaderNames(teamID uint) ([]string, error)
+	GetStatements(projectID, teamID uint) ([]model.TeamAccountabilityStatement, error)
+	CountStatements(projectID uint) (int64, error)
+	CreateStatement(data model.TeamAccountabilityStatement) (model.TeamAccountabilityStatement, error)
+}
+
+func (repo *teamMaterialAgingRepository) GetHeld(filter dto.TeamMaterialAgingFilter) ([]dto.TeamMaterialHeldQueryResult, error) {
+	data := []dto.TeamMaterialHeldQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      teams.id as team_id,
+      teams.number as team_number,
+      material_costs.id as material_cost_id,
+      materials.id as material_id,
+      materials.code as code,
+      materials.name as name,
+      materials.unit as unit,
+      material_costs.cost_m19 as cost_m19,
+      material_locations.amount as amount
+    FROM material_locations
+      INNER JOIN teams ON teams.id = material_locations.location_id
+      INNER JOIN material_costs ON material_costs.id = material_locations.material_cost_id
+      INNER JOIN materials ON materials.id = material_costs.material_id
+    WHERE
+      material_locations.project_id = ? AND
+      material_locations.location_type = 'team' AND
+      material_locations.amount > 0 AND
+      (nullif(?, 0) IS NULL OR teams.id = ?)
+    ORDER BY teams.number, teams.id, materials.code, material_costs.id
+    `, filter.ProjectID, filter.TeamID, filter.TeamID).Scan(&data).Error
+
+	return data, err
+}
+
+// Confirmed output invoices of the teams from the latest to the earliest
+func (repo *teamMaterialAgingRepository) GetIssues(filter dto.TeamMaterialAgingFilter) ([]dto.TeamMaterialIssueQueryResult, error) {
+	data := []dto.TeamMaterialIssueQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      invoice_outputs.team_id as team_id,
+      invoice_materials.material_cost_id as material_cost_id,
+      invoice_outputs.delivery_code as delivery_code,
+      invoice_outputs.date_of_invoice as date_of_invoice,
+      SUM(invoice_materials.amount) as amount
+    FROM invoice_outputs
+      INNER JOIN invoice_materials ON invoice_materials.invoice_id = invoice_outputs.id
+    WHERE
+      invoice_outputs.project_id = ? AND
+      invoice_outputs.confirmation = true AND
+      invoice_materials.invoice_type = 'output' AND
+      (nullif(?, 0) IS NULL OR invoice_outputs.team_id = ?)
+    GROUP BY invoice_outputs.id, invoice_outputs.team_id, invoice_materials.material_cost_id, invoice_outputs.delivery_code, invoice_outputs.date_of_invoice
+    ORDER BY invoice_outputs.date_of_invoice DESC, invoice_outputs.id DESC
+    `, filter.ProjectID, filter.TeamID, filter.TeamID).Scan(&data).Error
+
+	return data, err
+}
+
+// Serial numbers held by the teams with the latest confirmed output invoice that brought them
+func (repo *teamMaterialAgingRepository) GetSerialNumbers(filter dto.TeamMaterialAgingFilter) ([]dto.TeamSerialNumberHeldQueryResult, error) {
+	data := []dto.TeamSerialNumberHeldQueryResult{}
+	err := repo.db.Raw(`
+    SELECT DISTINCT ON (serial_numbers.id)
+      serial_number_locations.location_id as team_id,
+      serial_numbers.material_cost_id as material_cost_id,
+      serial_numbers.code as code,
+      invoice_outputs.delivery_code as delivery_code,
+      invoice_outputs.date_of_invoice as date_of_invoice
+    FROM serial_number_locations
+      INNER JOIN serial_numbers ON serial_numbers.id = serial_number_locations.serial_number_id
+      LEFT JOIN serial_number_movements ON
+        serial_number_movements.serial_number_id = serial_numbers.id AND
+        serial_number_movements.invoice_type = 'output'
+      LEFT JOIN invoice_outputs ON
+        invoice_outputs.id = serial_number_movements.invoice_id AND
+        invoice_outputs.team_id = serial_number_locations.location_id AND
+        invoice_outputs.confirmation = true
+    WHERE
+      serial_number_locations.project_id = ? AND
+      serial_number_locations.location_type = 'team' AND
+      (nullif(?, 0) IS NULL OR serial_number_locations.location_id = ?)
+    ORDER BY serial_numbers.id, invoice_outputs.date_of_invoice DESC NULLS LAST
+    `, filter.ProjectID, filter.TeamID, filter.TeamID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMaterialAgingRepository) GetLeaderNames(teamID uint) ([]string, error) {
+	data := []string{}
+	err := repo.db.Raw(`
+    SELECT workers.name
+    FROM team_leaders
+      INNER JOIN workers ON workers.id = team_leaders.leader_worker_id
+    WHERE team_leaders.team_id = ?
+    ORDER BY workers.name
+    `, teamID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMaterialAgingRepository) GetStatements(projectID, teamID uint) ([]model.TeamAccountabilityStatement, error) {
+	data := []model.TeamAccountabilityStatement{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM team_accountability_statements
+    WHERE
+      project_id = ? AND
+      (nullif(?, 0) IS NULL OR team_id = ?)
+    ORDER BY id DESC
+    `, projectID, teamID, teamID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMaterialAgingRepository) CountStatements(projectID uint) (int64, error) {
+	var count int64
+	err := repo.db.Model(&model.TeamAccountabilityStatement{}).Where("project_id = ?", projectID).Count(&count).Error
+	return count, err
+}
+
+func (repo *teamMaterialAgingRepository) CreateStatement(data model.TeamAccountabilityStatement) (model.TeamAccountabilityStatement, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
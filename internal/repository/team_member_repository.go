@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type teamMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamMemberRepository(db *gorm.DB) ITeamMemberRepository {
+	return &teamMemberRepository{
+		db: db,
+	}
+}
+
+type ITeamMemberRepository interface {
+	GetOnDate(projectID, teamID uint, date time.Time) ([]dto.TeamMemberView, error)
+	GetByTeamID(teamID uint) ([]dto.TeamMemberView, error)
+	GetByWorkerID(workerID uint) ([]dto.TeamMemberView, error)
+	GetByID(id uint) (model.TeamMember, error)
+	GetByWorkerOnDate(workerID uint, date time.Time) (model.TeamMember, error)
+	CountOverlapping(workerID uint, dateFrom, dateTo time.Time, exceptID uint) (int64, error)
+	Create(data []model.TeamMember) ([]model.TeamMember, error)
+	Update(data model.TeamMember) (model.TeamMember, error)
+	Transfer(current, next model.TeamMember) (model.TeamMember, error)
+	Delete(id uint) error
+}
+
+// Members of the team (or of every team of the project when teamID is 0) on the date
+func (repo *teamMemberRepository) GetOnDate(projectID, teamID uint, date time.Time) ([]dto.TeamMemberView, error) {
+	data := []dto.TeamMemberView{}
+	err := repo.db.Raw(`
+    SELECT
+      team_members.id as id,
+      teams.id as team_id,
+      teams.number as team_number,
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id,
+      workers.job_title_in_project as job_title,
+      team_members.effective_from as effective_from,
+      team_members.effective_to as effective_to
+    FROM team_members
+      INNER JOIN teams ON teams.id = team_members.team_id
+      INNER JOIN workers ON workers.id = team_members.worker_id
+    WHERE
+      team_members.project_id = ? AND
+      (nullif(?, 0) IS NULL OR team_members.team_id = ?) AND
+      team_members.effective_from <= ?::date AND
+      (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= ?::date)
+    ORDER BY teams.number, workers.name
+    `, projectID, teamID, teamID, date, date).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMemberRepository) GetByTeamID(teamID uint) ([]dto.TeamMemberView, error) {
+	data := []dto.TeamMemberView{}
+	err := repo.db.Raw(`
+    SELECT
+      team_members.id as id,
+      teams.id as team_id,
+      teams.number as team_number,
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id,
+      workers.job_title_in_project as job_title,
+      team_members.effective_from as effective_from,
+      team_members.effective_to as effective_to
+    FROM team_members
+      INNER JOIN teams ON teams.id = team_members.team_id
+      INNER JOIN workers ON workers.id = team_members.worker_id
+    WHERE team_members.team_id = ?
+    ORDER BY team_members.effective_from DESC, workers.name
+    `, teamID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMemberRepository) GetByWorkerID(workerID uint) ([]dto.TeamMemberView, error) {
+	data := []dto.TeamMemberView{}
+	err := repo.db.Raw(`
+    SELECT
+      team_members.id as id,
+      teams.id as team_id,
+      teams.number as team_number,
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id,
+      workers.job_title_in_project as job_title,
+      team_members.effective_from as effective_from,
+      team_members.effective_to as effective_to
+    FROM team_members
+      INNER JOIN teams ON teams.id = team_members.team_id
+      INNER JOIN workers ON workers.id = team_members.worker_id
+    WHERE team_members.worker_id = ?
+    ORDER BY team_members.effective_from DESC
+    `, workerID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *teamMemberRepository) GetByID(id uint) (model.TeamMember, error) {
+	data := model.TeamMember{}
+	err := repo.db.Raw(`SELECT * FROM team_members WHERE id = ?`, id).Scan(&data).Error
+	return data, err
+}
+
+// Membership of the worker that covers the date, empty when the worker was in no team on the date
+func (repo *teamMemberRepository) GetByWorkerOnDate(workerID uint, date time.Time) (model.TeamMember, error) {
+	data := model.TeamMember{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM team_members
+    WHERE
+      worker_id = ? AND
+      effective_from <= ?::date AND
+      (effective_to = '0001-01-01' OR effective_to >= ?::date)
+    ORDER BY effective_from DESC
+    LIMIT 1
+    `, workerID, date, date).Scan(&data).Error
+
+	return data, err
+}
+
+// Memberships of the worker that intersect the period, zero dateTo means the period has no end
+func (repo *teamMemberRepository) CountOverlapping(workerID uint, dateFrom, dateTo time.Time, exceptID uint) (int64, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM team_members
+    WHERE
+      worker_id = ? AND
+      id <> ? AND
+      (effective_to = '0001-01-01' OR effective_to >= ?::date) AND
+      (?::date = '0001-01-01' OR effective_from <= ?::date)
+    `, workerID, exceptID, dateFrom, dateTo, dateTo).Scan(&count).Error
+
+	return count, err
+}
+
+func (repo *teamMemberRepository) Create(data []model.TeamMember) ([]model.TeamMember, error) {
+	err := repo.db.CreateInBatches(&data, 50).Error
+	return data, err
+}
+
+func (repo *teamMemberRepository) Update(data model.TeamMember) (model.TeamMember, error) {
+	err := repo.db.Model(&model.TeamMember{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	return data, err
+}
+
+// Closes the current membership of the worker and opens the membership in the new team in one transaction
+func (repo *teamMemberRepository) Transfer(current, next model.TeamMember) (model.TeamMember, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.TeamMember{}).Where("id = ?", current.ID).Update("effective_to", current.EffectiveTo).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&next).Error
+	})
+
+	return next, err
+}
+
+func (repo *teamMemberRepository) Delete(id uint) error {
+	return repo.db.Delete(&model.TeamMember{}, "id = ?", id).Error
+}
@@ -156,6 +156,21 @@ func (repo *teamRepository) Delete(id uint) error {
 			return err
 		}
 
+		if err := tx.Exec(`
+      DELETE FROM team_assignment_operations
+      WHERE team_assignment_id IN (
+        SELECT id
+        FROM team_assignments
+        WHERE team_id = ?
+      )
+    `, id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.TeamAssignment{}, "team_id = ?", id).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Delete(&model.Team{}, "id = ?", id).Error; err != nil {
 			return err
 		}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"backend-v2/model"
+
+	"gorm.io/gorm"
+)
+
+type unitRepository struct {
+	db *gorm.DB
+}
+
+func NewUnitRepository(db *gorm.DB) IUnitRepository {
+	return &unitRepository{
+		db: db,
+	}
+}
+
+type IUnitRepository interface {
+	GetAll(projectID uint) ([]model.Unit, error)
+	GetByID(id uint) (model.Unit, error)
+	Create(data model.Unit) (model.Unit, error)
+	Update(data model.Unit) (model.Unit, error)
+	Delete(id uint) error
+	IsUsedByMaterials(id uint) (bool, error)
+}
+
+func (repo *unitRepository) GetAll(projectID uint) ([]model.Unit, error) {
+	data := []model.Unit{}
+	err := repo.db.Order("id desc").Find(&data, "project_id = ?", projectID).Error
+	return data, err
+}
+
+func (repo *unitRepository) GetByID(id uint) (model.Unit, error) {
+	data := model.Unit{}
+	err := repo.db.Find(&data, "id = ?", id).Error
+	return data, err
+}
+
+func (repo *unitRepository) Create(data model.Unit) (model.Unit, error) {
+	err := repo.db.Create(&data).Error
+	return data, err
+}
+
+func (repo *unitRepository) Update(data model.Unit) (model.Unit, error) {
+	err := repo.db.Model(&model.Unit{}).Select("*").Where("id = ?", data.ID).Updates(&data).Error
+	return data, err
+}
+
+func (repo *unitRepository) Delete(id uint) error {
+	return repo.db.Delete(&model.Unit{}, "id = ?", id).Error
+}
+
+func (repo *unitRepository) IsUsedByMaterials(id uint) (bool, error) {
+	var count int64
+	err := repo.db.Raw(`SELECT COUNT(*) FROM material_units WHERE unit_id = ?`, id).Scan(&count).Error
+	return count != 0, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"backend-v2/internal/dto"
 	"backend-v2/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +16,9 @@ type IWorkerAttendanceRepository interface {
 	CreateBatch(data []model.WorkerAttendance) error
   GetPaginated(projectID uint) ([]dto.WorkerAttendancePaginated, error)
   Count(projectID uint) (int64, error)
+	GetInRange(projectID uint, dateFrom, dateTo time.Time) ([]model.WorkerAttendance, error)
+	SaveImport(created, updated []model.WorkerAttendance) error
+	GetForTimesheet(filter dto.TimesheetFilter) ([]dto.TimesheetQueryResult, error)
 }
 
 func InitWorkerAttendanceRepository(db *gorm.DB) IWorkerAttendanceRepository {
@@ -35,9 +39,21 @@ func (repo *workerAttendanceRepository) GetPaginated(projectID uint) ([]dto.Work
       workers.name as worker_name,
       workers.company_worker_id  as company_worker_id,
       worker_attendances.start as "start",
-      worker_attendances.end as "end"
+      worker_attendances.end as "end",
+      COALESCE(teams.number, '') as team_number
     FROM worker_attendances
     INNER JOIN workers ON workers.id = worker_attendances.worker_id
+    LEFT JOIN LATERAL (
+      SELECT team_members.team_id
+      FROM team_members
+      WHERE
+        team_members.worker_id = worker_attendances.worker_id AND
+        team_members.effective_from <= worker_attendances.start::date AND
+        (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= worker_attendances.start::date)
+      ORDER BY team_members.effective_from DESC
+      LIMIT 1
+    ) AS active_member ON true
+    LEFT JOIN teams ON teams.id = active_member.team_id
     WHERE worker_attendances.project_id = ?
     `, projectID).Scan(&result).Error
 
@@ -49,3 +65,75 @@ func (repo *workerAttendanceRepository) Count(projectID uint) (int64, error) {
   err := repo.db.Raw(`SELECT COUNT(*) FROM worker_attendances WHERE project_id = ?`, projectID).Scan(&count).Error
   return count, err
 }
+
+func (repo *workerAttendanceRepository) GetInRange(projectID uint, dateFrom, dateTo time.Time) ([]model.WorkerAttendance, error) {
+	data := []model.WorkerAttendance{}
+	err := repo.db.Raw(`
+    SELECT *
+    FROM worker_attendances
+    WHERE
+      project_id = ? AND
+      start >= ? AND
+      start <= ?
+    `, projectID, dateFrom, dateTo).Scan(&data).Error
+
+	return data, err
+}
+
+// New working days are created and the days that got new punches are updated in one transaction
+func (repo *workerAttendanceRepository) SaveImport(created, updated []model.WorkerAttendance) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if len(created) != 0 {
+			if err := tx.CreateInBatches(&created, 50).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, attendance := range updated {
+			if err := tx.Model(&model.WorkerAttendance{}).Where("id = ?", attendance.ID).Updates(map[string]interface{}{
+				"start": attendance.Start,
+				"end":   attendance.End,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (repo *workerAttendanceRepository) GetForTimesheet(filter dto.TimesheetFilter) ([]dto.TimesheetQueryResult, error) {
+	data := []dto.TimesheetQueryResult{}
+	err := repo.db.Raw(`
+    SELECT
+      workers.id as worker_id,
+      workers.name as worker_name,
+      workers.company_worker_id as company_worker_id,
+      workers.job_title_in_project as job_title,
+      worker_attendances.start as "start",
+      worker_attendances.end as "end"
+    FROM worker_attendances
+      INNER JOIN workers ON workers.id = worker_attendances.worker_id
+    WHERE
+      worker_attendances.project_id = ? AND
+      worker_attendances.start >= ? AND
+      worker_attendances.start <= ? AND
+      (? = '' OR workers.job_title_in_project = ? OR workers.job_title_in_company = ?) AND
+      (nullif(?, 0) IS NULL OR EXISTS (
+        SELECT 1
+        FROM team_members
+        WHERE
+          team_members.team_id = ? AND
+          team_members.worker_id = workers.id AND
+          team_members.effective_from <= worker_attendances.start::date AND
+          (team_members.effective_to = '0001-01-01' OR team_members.effective_to >= worker_attendances.start::date)
+      ))
+    ORDER BY workers.name, workers.id, worker_attendances.start
+    `,
+		filter.ProjectID, filter.DateFrom, filter.DateTo,
+		filter.JobTitle, filter.JobTitle, filter.JobTitle,
+		filter.TeamID, filter.TeamID,
+	).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/model"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type workerMergeRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkerMergeRepository(db *gorm.DB) IWorkerMergeRepository {
+	return &workerMergeRepository{
+		db: db,
+	}
+}
+
+type IWorkerMergeRepository interface {
+	GetCandidates(projectID uint) ([]dto.WorkerDuplicateCandidate, error)
+	GetReferenceCounts(workerIDs []uint) ([]dto.WorkerReferenceCount, error)
+	CountMembershipOverlaps(keptWorkerID, mergedWorkerID uint) (int64, error)
+	GetAll(projectID uint) ([]dto.WorkerMergeView, error)
+	GetByID(id uint) (model.WorkerMerge, error)
+	Merge(projectID, userID uint, kept model.Worker, merged []model.Worker) ([]model.WorkerMerge, error)
+	Undo(data model.WorkerMerge, userID uint) (model.WorkerMerge, error)
+}
+
+// Membership of the merged worker lies inside the membership of the kept worker in the same team
+const workerMembershipCovered = `
+  kept.team_id = merged.team_id AND
+  kept.effective_from <= merged.effective_from AND
+  (kept.effective_to = '0001-01-01' OR (merged.effective_to <> '0001-01-01' AND kept.effective_to >= merged.effective_to))`
+
+// Columns that point to workers. Repeated is the condition on the rows of the merged
+// and the kept workers under which the row of the merged worker only repeats the row
+// of the kept worker, such rows are removed instead of being pointed to the kept worker
+var workerReferenceColumns = []struct {
+	Table    string
+	Column   string
+	Repeated string
+}{
+	{"invoice_inputs", "warehouse_manager_worker_id", ""},
+	{"invoice_inputs", "released_worker_id", ""},
+	{"invoice_objects", "supervisor_worker_id", ""},
+	{"invoice_object_operators", "operator_worker_id", "kept.invoice_object_id = merged.invoice_object_id"},
+	{"invoice_outputs", "warehouse_manager_worker_id", ""},
+	{"invoice_outputs", "released_worker_id", ""},
+	{"invoice_outputs", "recipient_worker_id", ""},
+	{"invoice_output_out_of_projects", "released_worker_id", ""},
+	{"invoice_returns", "accepted_by_worker_id", ""},
+	{"invoice_write_offs", "released_worker_id", ""},
+	{"object_supervisors", "supervisor_worker_id", "kept.object_id = merged.object_id"},
+	{"team_leaders", "leader_worker_id", "kept.team_id = merged.team_id"},
+	{"team_members", "worker_id", workerMembershipCovered},
+	{"team_earning_adjustments", "worker_id", ""},
+	{"users", "worker_id", ""},
+	{"worker_attendances", "worker_id", "kept.start::date = merged.start::date"},
+	{"worker_qualifications", "worker_id", ""},
+}
+
+// Workers of the project, the system worker with ID 1 is skipped
+func (repo *workerMergeRepository) GetCandidates(projectID uint) ([]dto.WorkerDuplicateCandidate, error) {
+	data := []dto.WorkerDuplicateCandidate{}
+	err := repo.db.Raw(`
+    SELECT
+      workers.id as id,
+      workers.name as name,
+      workers.company_worker_id as company_worker_id,
+      workers.job_title_in_project as job_title_in_project,
+      workers.mobile_number as mobile_number
+    FROM workers
+    WHERE
+      workers.id <> 1 AND
+      workers.project_id = ?
+    ORDER BY workers.id
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+// Number of rows in every table that point to each of the workers
+func (repo *workerMergeRepository) GetReferenceCounts(workerIDs []uint) ([]dto.WorkerReferenceCount, error) {
+	data := []dto.WorkerReferenceCount{}
+	if len(workerIDs) == 0 {
+		return data, nil
+	}
+
+	queries := []string{}
+	values := []interface{}{}
+	for _, reference := range workerReferenceColumns {
+		queries = append(queries, fmt.Sprintf(`SELECT %s as worker_id FROM %s WHERE %s IN ?`, reference.Column, reference.Table, reference.Column))
+		values = append(values, workerIDs)
+	}
+
+	err := repo.db.Raw(fmt.Sprintf(`
+    SELECT
+      worker_id,
+      COUNT(*) as amount
+    FROM (%s) AS worker_references
+    GROUP BY worker_id
+    `, strings.Join(queries, " UNION ALL ")), values...).Scan(&data).Error
+
+	return data, err
+}
+
+// Memberships of the merged worker that overlap memberships of the kept worker
+// and are not removed by the merge as repeated
+func (repo *workerMergeRepository) CountMembershipOverlaps(keptWorkerID, mergedWorkerID uint) (int64, error) {
+	var count int64
+	err := repo.db.Raw(`
+    SELECT COUNT(*)
+    FROM team_members AS merged
+      INNER JOIN team_members AS kept ON
+        kept.worker_id = ? AND
+        (kept.effective_to = '0001-01-01' OR kept.effective_to >= merged.effective_from) AND
+        (merged.effective_to = '0001-01-01' OR merged.effective_to >= kept.effective_from)
+    WHERE
+      merged.worker_id = ? AND
+      NOT EXISTS (
+        SELECT 1
+        FROM team_members AS kept
+        WHERE
+          kept.worker_id = ? AND`+workerMembershipCovered+`
+      )
+    `, keptWorkerID, mergedWorkerID, keptWorkerID).Scan(&count).Error
+
+	return count, err
+}
+
+// Merges made from the project or involving workers of the project
+func (repo *workerMergeRepository) GetAll(projectID uint) ([]dto.WorkerMergeView, error) {
+	data := []dto.WorkerMergeView{}
+	err := repo.db.Raw(`
+    SELECT
+      worker_merges.id as id,
+      worker_merges.kept_worker_id as kept_worker_id,
+      COALESCE(workers.name, '') as kept_worker_name,
+      worker_merges.merged_worker_id as merged_worker_id,
+      worker_merges.name as merged_worker_name,
+      worker_merges.company_worker_id as company_worker_id,
+      COALESCE(users.username, '') as merged_by_username,
+      worker_merges.merged_at as merged_at,
+      worker_merges.undone_at as undone_at,
+      (
+        SELECT COUNT(*)
+        FROM worker_merge_references
+        WHERE worker_merge_references.worker_merge_id = worker_merges.id
+      ) as reference_count
+    FROM worker_merges
+      LEFT JOIN workers ON workers.id = worker_merges.kept_worker_id
+      LEFT JOIN users ON users.id = worker_merges.merged_by_user_id
+    WHERE worker_merges.project_id = ?
+    ORDER BY worker_merges.id DESC
+    `, projectID).Scan(&data).Error
+
+	return data, err
+}
+
+func (repo *workerMergeRepository) GetByID(id uint) (model.WorkerMerge, error) {
+	data := model.WorkerMerge{}
+	if err := repo.db.First(&data, "id = ?", id).Error; err != nil {
+		return data, err
+	}
+
+	err := repo.db.Order("id").Find(&data.WorkerMergeReferences, "worker_merge_id = ?", id).Error
+	return data, err
+}
+
+// Points every reference of the merged workers to the kept worker, logs the changed
+// rows and deletes the merged workers in one transaction
+func (repo *workerMergeRepository) Merge(projectID, userID uint, kept model.Worker, merged []model.Worker) ([]model.WorkerMerge, error) {
+	result := []model.WorkerMerge{}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		for _, worker := range merged {
+			log := model.WorkerMerge{
+				ProjectID:         projectID,
+				KeptWorkerID:      kept.ID,
+				MergedWorkerID:    worker.ID,
+				Name:              worker.Name,
+				CompanyWorkerID:   worker.CompanyWorkerID,
+				JobTitleInCompany: worker.JobTitleInCompany,
+				JobTitleInProject: worker.JobTitleInProject,
+				MobileNumber:      worker.MobileNumber,
+				MergedByUserID:    userID,
+				MergedAt:          time.Now(),
+			}
+			if err := tx.Create(&log).Error; err != nil {
+				return err
+			}
+
+			references := []model.WorkerMergeReference{}
+			for _, column := range workerReferenceColumns {
+				if column.Repeated != "" {
+					repeated := []struct {
+						ID      uint
+						RowData string
+					}{}
+					if err := tx.Raw(fmt.Sprintf(`
+            SELECT
+              merged.id as id,
+              row_to_json(merged)::text as row_data
+            FROM %s AS merged
+            WHERE
+              merged.%s = ? AND
+              EXISTS (
+                SELECT 1
+                FROM %s AS kept
+                WHERE
+                  kept.%s = ? AND %s
+              )
+            `, column.Table, column.Column, column.Table, column.Column, column.Repeated),
+						worker.ID, kept.ID,
+					).Scan(&repeated).Error; err != nil {
+						return err
+					}
+
+					removedIDs := []uint{}
+					for _, row := range repeated {
+						removedIDs = append(removedIDs, row.ID)
+						references = append(references, model.WorkerMergeReference{
+							WorkerMergeID:   log.ID,
+							ReferenceTable:  column.Table,
+							ReferenceColumn: column.Column,
+							RowID:           row.ID,
+							Removed:         true,
+							RowData:         row.RowData,
+						})
+					}
+
+					if len(removedIDs) != 0 {
+						if err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE id IN ?`, column.Table), removedIDs).Error; err != nil {
+							return err
+						}
+					}
+				}
+
+				rowIDs := []uint{}
+				if err := tx.Raw(fmt.Sprintf(`SELECT id FROM %s WHERE %s = ?`, column.Table, column.Column), worker.ID).Scan(&rowIDs).Error; err != nil {
+					return err
+				}
+
+				if len(rowIDs) == 0 {
+					continue
+				}
+
+				for _, rowID := range rowIDs {
+					references = append(references, model.WorkerMergeReference{
+						WorkerMergeID:   log.ID,
+						ReferenceTable:  column.Table,
+						ReferenceColumn: column.Column,
+						RowID:           rowID,
+					})
+				}
+
+				if err := tx.Exec(fmt.Sprintf(`UPDATE %s SET %s = ? WHERE id IN ?`, column.Table, column.Column), kept.ID, rowIDs).Error; err != nil {
+					return err
+				}
+			}
+
+			if len(references) != 0 {
+				if err := tx.CreateInBatches(&references, 100).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Delete(&model.Worker{}, "id = ?", worker.ID).Error; err != nil {
+				return err
+			}
+
+			result = append(result, log)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Restores the merged worker with its former ID, brings back the removed
+// rows and points the logged rows back to it. Rows that were pointed to the kept
+// worker after the merge stay with the kept worker
+func (repo *workerMergeRepository) Undo(data model.WorkerMerge, userID uint) (model.WorkerMerge, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		worker := model.Worker{
+			ID:                data.MergedWorkerID,
+			ProjectID:         data.ProjectID,
+			Name:              data.Name,
+			CompanyWorkerID:   data.CompanyWorkerID,
+			JobTitleInCompany: data.JobTitleInCompany,
+			JobTitleInProject: data.JobTitleInProject,
+			MobileNumber:      data.MobileNumber,
+		}
+		if err := tx.Create(&worker).Error; err != nil {
+			return err
+		}
+
+		updated := map[string][]uint{}
+		for _, reference := range data.WorkerMergeReferences {
+			if reference.Removed {
+				if err := tx.Exec(
+					fmt.Sprintf(`INSERT INTO %s SELECT * FROM json_populate_record(NULL::%s, ?::json)`, reference.ReferenceTable, reference.ReferenceTable),
+					reference.RowData,
+				).Error; err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			key := reference.ReferenceTable + "." + reference.ReferenceColumn
+			updated[key] = append(updated[key], reference.RowID)
+		}
+
+		for key, rowIDs := range updated {
+			tableAndColumn := strings.SplitN(key, ".", 2)
+			if err := tx.Exec(
+				fmt.Sprintf(`UPDATE %s SET %s = ? WHERE id IN ? AND %s = ?`, tableAndColumn[0], tableAndColumn[1], tableAndColumn[1]),
+				data.MergedWorkerID, rowIDs, data.KeptWorkerID,
+			).Error; err != nil {
+				return err
+			}
+		}
+
+		data.UndoneByUserID = userID
+		data.UndoneAt = time.Now()
+		return tx.Model(&model.WorkerMerge{}).Where("id = ?", data.ID).Updates(map[string]interface{}{
+			"undone_by_user_id": data.UndoneByUserID,
+			"undone_at":         data.UndoneAt,
+		}).Error
+	})
+
+	return data, err
+}
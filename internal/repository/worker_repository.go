@@ -3,6 +3,7 @@ package repository
 import (
 	"backend-v2/internal/dto"
 	"backend-v2/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -31,6 +32,7 @@ type IWorkerRepository interface {
 	Delete(id uint) error
 	Count(fitler dto.WorkerSearchParameters) (int64, error)
 	GetFullWorkerInformationForSearch(projectID uint) (dto.WorkerInformationForSearch, error)
+	GetClearanceIssues(workerIDs []uint, role string, date time.Time) ([]dto.WorkerClearanceIssue, error)
 }
 
 func (repo *workerRepository) GetAll(projectID uint) ([]model.Worker, error) {
@@ -168,3 +170,36 @@ func (repo *workerRepository) GetFullWorkerInformationForSearch(projectID uint)
 
 	return result, err
 }
+
+// Qualifications required for the role ("supervisor" or "team-leader") that the workers
+// do not hold valid on the date, the latest expiry date is zero when the worker never had one
+func (repo *workerRepository) GetClearanceIssues(workerIDs []uint, role string, date time.Time) ([]dto.WorkerClearanceIssue, error) {
+	data := []dto.WorkerClearanceIssue{}
+	if len(workerIDs) == 0 {
+		return data, nil
+	}
+
+	err := repo.db.Raw(`
+    SELECT
+      workers.id as worker_id,
+      workers.name as worker_name,
+      qualification_types.name as qualification_type_name,
+      COALESCE(MAX(worker_qualifications.expires_at), '0001-01-01') as expires_at
+    FROM workers
+      INNER JOIN qualification_types ON qualification_types.project_id = workers.project_id
+      LEFT JOIN worker_qualifications ON
+        worker_qualifications.worker_id = workers.id AND
+        worker_qualifications.qualification_type_id = qualification_types.id
+    WHERE
+      workers.id IN ? AND
+      (
+        (? = 'supervisor' AND qualification_types.required_for_supervisor = true) OR
+        (? = 'team-leader' AND qualification_types.required_for_team_leader = true)
+      )
+    GROUP BY workers.id, workers.name, qualification_types.id, qualification_types.name
+    HAVING COALESCE(MAX(worker_qualifications.expires_at), '0001-01-01') < ?
+    ORDER BY workers.name, qualification_types.name
+    `, workerIDs, role, role, date).Scan(&data).Error
+
+	return data, err
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+)
+
+const exifDateLayout = "2006:01:02 15:04:05"
+
+// Metadata written by the camera into the EXIF block of the photo
+type exifMetadata struct {
+	takenAt     time.Time
+	hasLocation bool
+	latitude    float64
+	longitude   float64
+}
+
+type tiffEntry struct {
+	dataType uint16
+	count    uint32
+	value    []byte
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// Reads the shooting time and GPS position from the EXIF block of the JPEG file.
+// Photos without EXIF or with the damaged one give empty metadata, it is not an error
+func readExifMetadata(data []byte) exifMetadata {
+	tiff := jpegExifBlock(data)
+	if tiff == nil {
+		return exifMetadata{}
+	}
+
+	reader := tiffReader{data: tiff}
+	switch string(tiff[:2]) {
+	case "II":
+		reader.order = binary.LittleEndian
+	case "MM":
+		reader.order = binary.BigEndian
+	default:
+		return exifMetadata{}
+	}
+
+	if reader.order.Uint16(tiff[2:4]) != 42 {
+		return exifMetadata{}
+	}
+
+	result := exifMetadata{}
+	mainIFD := reader.ifd(reader.order.Uint32(tiff[4:8]))
+	if entry, exists := mainIFD[0x0132]; exists {
+		result.takenAt, _ = time.Parse(exifDateLayout, reader.ascii(entry))
+	}
+
+	if entry, exists := mainIFD[0x8769]; exists {
+		exifIFD := reader.ifd(reader.uint32(entry))
+		if entry, exists := exifIFD[0x9003]; exists {
+			if takenAt, err := time.Parse(exifDateLayout, reader.ascii(entry)); err == nil {
+				result.takenAt = takenAt
+			}
+		}
+	}
+
+	if entry, exists := mainIFD[0x8825]; exists {
+		gpsIFD := reader.ifd(reader.uint32(entry))
+		latitude, latitudeFound := reader.gpsCoordinate(gpsIFD, 0x0001, 0x0002, "S")
+		longitude, longitudeFound := reader.gpsCoordinate(gpsIFD, 0x0003, 0x0004, "W")
+		if latitudeFound && longitudeFound && validateCoordinates(latitude, longitude) == nil {
+			result.hasLocation = true
+			result.latitude = latitude
+			result.longitude = longitude
+		}
+	}
+
+	return result
+}
+
+// Returns the TIFF structure from the APP1 segment of the JPEG file
+func jpegExifBlock(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+
+	position := 2
+	for position+4 <= len(data) {
+		if data[position] != 0xFF {
+			return nil
+		}
+
+		marker := data[position+1]
+		// Start of the image data, metadata segments can not follow it
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[position+2 : position+4]))
+		if length < 2 || position+2+length > len(data) {
+			return nil
+		}
+
+		segment := data[position+4 : position+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) && len(segment) >= 14 {
+			return segment[6:]
+		}
+
+		position += 2 + length
+	}
+
+	return nil
+}
+
+func (reader tiffReader) ifd(offset uint32) map[uint16]tiffEntry {
+	result := map[uint16]tiffEntry{}
+	if int(offset)+2 > len(reader.data) {
+		return result
+	}
+
+	count := int(reader.order.Uint16(reader.data[offset : offset+2]))
+	for index := 0; index < count; index++ {
+		start := int(offset) + 2 + index*12
+		if start+12 > len(reader.data) {
+			break
+		}
+
+		raw := reader.data[start : start+12]
+		entry := tiffEntry{
+			dataType: reader.order.Uint16(raw[2:4]),
+			count:    reader.order.Uint32(raw[4:8]),
+		}
+
+		size := int(entry.count) * tiffTypeSize(entry.dataType)
+		if size <= 4 {
+			entry.value = raw[8 : 8+size]
+		} else {
+			valueOffset := int(reader.order.Uint32(raw[8:12]))
+			if valueOffset+size > len(reader.data) || valueOffset+size < valueOffset {
+				continue
+			}
+			entry.value = reader.data[valueOffset : valueOffset+size]
+		}
+
+		result[reader.order.Uint16(raw[0:2])] = entry
+	}
+
+	return result
+}
+
+func (reader tiffReader) uint32(entry tiffEntry) uint32 {
+	switch {
+	case entry.dataType == 4 && len(entry.value) >= 4:
+		return reader.order.Uint32(entry.value)
+	case entry.dataType == 3 && len(entry.value) >= 2:
+		return uint32(reader.order.Uint16(entry.value))
+	default:
+		return 0
+	}
+}
+
+func (reader tiffReader) ascii(entry tiffEntry) string {
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+func (reader tiffReader) rationals(entry tiffEntry) []float64 {
+	result := []float64{}
+	if entry.dataType != 5 {
+		return result
+	}
+
+	for index := 0; index+8 <= len(entry.value); index += 8 {
+		numerator := reader.order.Uint32(entry.value[index : index+4])
+		denominator := reader.order.Uint32(entry.value[index+4 : index+8])
+		if denominator == 0 {
+			result = append(result, 0)
+			continue
+		}
+
+		result = append(result, float64(numerator)/float64(denominator))
+	}
+
+	return result
+}
+
+// GPS coordinate is stored as degrees, minutes and seconds with the reference to the hemisphere
+func (reader tiffReader) gpsCoordinate(gpsIFD map[uint16]tiffEntry, referenceTag, valueTag uint16, negativeReference string) (float64, bool) {
+	valueEntry, exists := gpsIFD[valueTag]
+	if !exists {
+		return 0, false
+	}
+
+	parts := reader.rationals(valueEntry)
+	if len(parts) != 3 {
+		return 0, false
+	}
+
+	coordinate := parts[0] + parts[1]/60 + parts[2]/3600
+	if referenceEntry, exists := gpsIFD[referenceTag]; exists && reader.ascii(referenceEntry) == negativeReference {
+		coordinate = -coordinate
+	}
+
+	return coordinate, true
+}
+
+func tiffTypeSize(dataType uint16) int {
+	switch dataType {
+	case 3:
+		return 2
+	case 4, 9:
+		return 4
+	case 5, 10:
+		return 8
+	default:
+		return 1
+	}
+}
+
+// Scales the image down so that its longest side fits into the given size,
+// every pixel of the thumbnail is the average of the pixels it covers
+func thumbnailImage(source image.Image, maxSide int) image.Image {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return source
+	}
+
+	thumbnailWidth, thumbnailHeight := maxSide, height*maxSide/width
+	if height > width {
+		thumbnailWidth, thumbnailHeight = width*maxSide/height, maxSide
+	}
+	if thumbnailWidth == 0 {
+		thumbnailWidth = 1
+	}
+	if thumbnailHeight == 0 {
+		thumbnailHeight = 1
+	}
+
+	result := image.NewRGBA(image.Rect(0, 0, thumbnailWidth, thumbnailHeight))
+	for y := 0; y < thumbnailHeight; y++ {
+		fromY, toY := bounds.Min.Y+y*height/thumbnailHeight, bounds.Min.Y+(y+1)*height/thumbnailHeight
+		for x := 0; x < thumbnailWidth; x++ {
+			fromX, toX := bounds.Min.X+x*width/thumbnailWidth, bounds.Min.X+(x+1)*width/thumbnailWidth
+
+			var red, green, blue, alpha, count uint64
+			for sourceY := fromY; sourceY < toY; sourceY++ {
+				for sourceX := fromX; sourceX < toX; sourceX++ {
+					r, g, b, a := source.At(sourceX, sourceY).RGBA()
+					red, green, blue, alpha = red+uint64(r), green+uint64(g), blue+uint64(b), alpha+uint64(a)
+					count++
+				}
+			}
+
+			if count == 0 {
+				continue
+			}
+
+			result.SetRGBA(x, y, color.RGBA{
+				R: uint8(red / count >> 8),
+				G: uint8(green / count >> 8),
+				B: uint8(blue / count >> 8),
+				A: uint8(alpha / count >> 8),
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	attachmentStorageDirectory = "./pkg/attachments/"
+	attachmentMaxSize          = 20 << 20
+	attachmentThumbnailSize    = 320
+	attachmentMaxPixels        = 50_000_000
+)
+
+// Extensions of the stored files by the allowed MIME types
+var attachmentMimeTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type attachmentService struct {
+	attachmentRepo    repository.IAttachmentRepository
+	objectRepo        repository.IObjectRepository
+	invoiceObjectRepo repository.IInvoiceObjectRepository
+	userInProjectRepo repository.IUserInProjectRepository
+}
+
+func NewAttachmentService(
+	attachmentRepo repository.IAttachmentRepository,
+	objectRepo repository.IObjectRepository,
+	invoiceObjectRepo repository.IInvoiceObjectRepository,
+	userInProjectRepo repository.IUserInProjectRepository,
+) IAttachmentService {
+	return &attachmentService{
+		attachmentRepo:    attachmentRepo,
+		objectRepo:        objectRepo,
+		invoiceObjectRepo: invoiceObjectRepo,
+		userInProjectRepo: userInProjectRepo,
+	}
+}
+
+type IAttachmentService interface {
+	Upload(projectID, userID uint, attachedTo string, attachedToID uint, files []*multipart.FileHeader) ([]model.Attachment, error)
+	GetByTarget(projectID, userID uint, attachedTo string, attachedToID uint) ([]model.Attachment, error)
+	GetFile(projectID, userID, id uint) (dto.AttachmentFile, error)
+	GetThumbnail(projectID, userID, id uint) (dto.AttachmentFile, error)
+	Delete(projectID, userID, id uint) error
+}
+
+type attachmentUpload struct {
+	attachment model.Attachment
+	content    []byte
+	thumbnail  []byte
+}
+
+func (service *attachmentService) Upload(projectID, userID uint, attachedTo string, attachedToID uint, files []*multipart.FileHeader) ([]model.Attachment, error) {
+	if err := service.checkTarget(projectID, userID, attachedTo, attachedToID); err != nil {
+		return []model.Attachment{}, err
+	}
+
+	if len(files) == 0 {
+		return []model.Attachment{}, fmt.Errorf("Не выбрано ни одного файла")
+	}
+
+	// Every file is validated before any of them is stored
+	uploads := []attachmentUpload{}
+	uploadedAt := time.Now()
+	for _, file := range files {
+		upload, err := attachmentUploadFromFile(file)
+		if err != nil {
+			return []model.Attachment{}, err
+		}
+
+		upload.attachment.ProjectID = projectID
+		upload.attachment.AttachedTo = attachedTo
+		upload.attachment.AttachedToID = attachedToID
+		upload.attachment.UploadedByUserID = userID
+		upload.attachment.UploadedAt = uploadedAt
+		uploads = append(uploads, upload)
+	}
+
+	directory := filepath.Join(attachmentStorageDirectory, fmt.Sprint(projectID))
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return []model.Attachment{}, fmt.Errorf("Не удалось создать папку для файлов: %v", err)
+	}
+
+	attachments := []model.Attachment{}
+	storedFiles := []string{}
+	removeStoredFiles := func() {
+		for _, storedFile := range storedFiles {
+			os.Remove(storedFile)
+		}
+	}
+
+	for index, upload := range uploads {
+		baseName := fmt.Sprintf("%d-%d", uploadedAt.UnixNano(), index)
+		upload.attachment.FilePath = filepath.Join(directory, baseName+attachmentMimeTypes[upload.attachment.MimeType])
+		if err := os.WriteFile(upload.attachment.FilePath, upload.content, 0644); err != nil {
+			removeStoredFiles()
+			return []model.Attachment{}, fmt.Errorf("Файл %s не может быть сохранен на сервере: %v", upload.attachment.FileName, err)
+		}
+		storedFiles = append(storedFiles, upload.attachment.FilePath)
+
+		if upload.thumbnail != nil {
+			upload.attachment.ThumbnailPath = filepath.Join(directory, baseName+"-thumbnail.jpg")
+			if err := os.WriteFile(upload.attachment.ThumbnailPath, upload.thumbnail, 0644); err != nil {
+				removeStoredFiles()
+				return []model.Attachment{}, fmt.Errorf("Миниатюра файла %s не может быть сохранена на сервере: %v", upload.attachment.FileName, err)
+			}
+			storedFiles = append(storedFiles, upload.attachment.ThumbnailPath)
+		}
+
+		attachments = append(attachments, upload.attachment)
+	}
+
+	attachments, err := service.attachmentRepo.CreateInBatches(attachments)
+	if err != nil {
+		removeStoredFiles()
+		return []model.Attachment{}, err
+	}
+
+	return attachments, nil
+}
+
+func (service *attachmentService) GetByTarget(projectID, userID uint, attachedTo string, attachedToID uint) ([]model.Attachment, error) {
+	if err := service.checkTarget(projectID, userID, attachedTo, attachedToID); err != nil {
+		return []model.Attachment{}, err
+	}
+
+	return service.attachmentRepo.GetByTarget(attachedTo, attachedToID)
+}
+
+func (service *attachmentService) GetFile(projectID, userID, id uint) (dto.AttachmentFile, error) {
+	attachment, err := service.getAccessible(projectID, userID, id)
+	if err != nil {
+		return dto.AttachmentFile{}, err
+	}
+
+	return dto.AttachmentFile{
+		FilePath: attachment.FilePath,
+		FileName: attachment.FileName,
+	}, nil
+}
+
+func (service *attachmentService) GetThumbnail(projectID, userID, id uint) (dto.AttachmentFile, error) {
+	attachment, err := service.getAccessible(projectID, userID, id)
+	if err != nil {
+		return dto.AttachmentFile{}, err
+	}
+
+	if attachment.ThumbnailPath == "" {
+		return dto.AttachmentFile{}, fmt.Errorf("У файла %s нет миниатюры", attachment.FileName)
+	}
+
+	return dto.AttachmentFile{
+		FilePath: attachment.ThumbnailPath,
+		FileName: "thumbnail-" + attachment.FileName,
+	}, nil
+}
+
+func (service *attachmentService) Delete(projectID, userID, id uint) error {
+	attachment, err := service.getAccessible(projectID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := service.attachmentRepo.Delete(id); err != nil {
+		return err
+	}
+
+	os.Remove(attachment.FilePath)
+	if attachment.ThumbnailPath != "" {
+		os.Remove(attachment.ThumbnailPath)
+	}
+
+	return nil
+}
+
+// Attachments are available only to the members of the project they were uploaded to
+func (service *attachmentService) checkMembership(projectID, userID uint) error {
+	userInProjects, err := service.userInProjectRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, userInProject := range userInProjects {
+		if userInProject.ProjectID == projectID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Пользователь не является участником проекта")
+}
+
+func (service *attachmentService) checkTarget(projectID, userID uint, attachedTo string, attachedToID uint) error {
+	if err := service.checkMembership(projectID, userID); err != nil {
+		return err
+	}
+
+	switch attachedTo {
+	case "object":
+		object, err := service.objectRepo.GetByID(attachedToID)
+		if err != nil {
+			return err
+		}
+
+		if object.ID == 0 || object.ProjectID != projectID {
+			return fmt.Errorf("Объект с ID %d не найден", attachedToID)
+		}
+
+	case "invoice-object":
+		invoiceObject, err := service.invoiceObjectRepo.GetByID(attachedToID)
+		if err != nil {
+			return err
+		}
+
+		if invoiceObject.ID == 0 || invoiceObject.ProjectID != projectID {
+			return fmt.Errorf("Накладная объекта с ID %d не найдена", attachedToID)
+		}
+
+	default:
+		return fmt.Errorf("Неизвестный тип вложения: %s", attachedTo)
+	}
+
+	return nil
+}
+
+func (service *attachmentService) getAccessible(projectID, userID, id uint) (model.Attachment, error) {
+	if err := service.checkMembership(projectID, userID); err != nil {
+		return model.Attachment{}, err
+	}
+
+	attachment, err := service.attachmentRepo.GetByID(id)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	if attachment.ID == 0 || attachment.ProjectID != projectID {
+		return model.Attachment{}, fmt.Errorf("Файл с ID %d не найден", id)
+	}
+
+	return attachment, nil
+}
+
+// Reads the uploaded file, checks its size and real content type,
+// extracts EXIF metadata and prepares the thumbnail of the photo
+func attachmentUploadFromFile(file *multipart.FileHeader) (attachmentUpload, error) {
+	if file.Size > attachmentMaxSize {
+		return attachmentUpload{}, fmt.Errorf("Файл %s превышает допустимый размер в %d МБ", file.Filename, attachmentMaxSize>>20)
+	}
+
+	source, err := file.Open()
+	if err != nil {
+		return attachmentUpload{}, fmt.Errorf("Файл %s не может быть прочитан: %v", file.Filename, err)
+	}
+	defer source.Close()
+
+	content, err := io.ReadAll(io.LimitReader(source, attachmentMaxSize+1))
+	if err != nil {
+		return attachmentUpload{}, fmt.Errorf("Файл %s не может быть прочитан: %v", file.Filename, err)
+	}
+
+	if len(content) > attachmentMaxSize {
+		return attachmentUpload{}, fmt.Errorf("Файл %s превышает допустимый размер в %d МБ", file.Filename, attachmentMaxSize>>20)
+	}
+
+	mimeType := http.DetectContentType(content)
+	if _, allowed := attachmentMimeTypes[mimeType]; !allowed {
+		return attachmentUpload{}, fmt.Errorf("Файл %s имеет недопустимый тип %s, разрешены JPEG, PNG и PDF", file.Filename, mimeType)
+	}
+
+	upload := attachmentUpload{
+		attachment: model.Attachment{
+			FileName: filepath.Base(file.Filename),
+			MimeType: mimeType,
+			Size:     int64(len(content)),
+		},
+		content: content,
+	}
+
+	if mimeType == "application/pdf" {
+		return upload, nil
+	}
+
+	if mimeType == "image/jpeg" {
+		metadata := readExifMetadata(content)
+		upload.attachment.TakenAt = metadata.takenAt
+		upload.attachment.HasLocation = metadata.hasLocation
+		upload.attachment.Latitude = metadata.latitude
+		upload.attachment.Longitude = metadata.longitude
+	}
+
+	// Dimensions are checked before decoding, so that a small file of huge
+	// dimensions does not allocate gigabytes of memory for its pixels
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return attachmentUpload{}, fmt.Errorf("Изображение %s повреждено: %v", file.Filename, err)
+	}
+
+	if config.Width*config.Height > attachmentMaxPixels {
+		return attachmentUpload{}, fmt.Errorf("Изображение %s превышает допустимое разрешение в %d мегапикселей", file.Filename, attachmentMaxPixels/1_000_000)
+	}
+
+	picture, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return attachmentUpload{}, fmt.Errorf("Изображение %s повреждено: %v", file.Filename, err)
+	}
+
+	thumbnail := bytes.Buffer{}
+	if err := jpeg.Encode(&thumbnail, thumbnailImage(picture, attachmentThumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		return attachmentUpload{}, fmt.Errorf("Не удалось создать миниатюру изображения %s: %v", file.Filename, err)
+	}
+	upload.thumbnail = thumbnail.Bytes()
+
+	return upload, nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formats of the terminals used from the start, available in every project
+var builtinAttendanceImportFormats = []model.AttendanceImportFormat{
+	{
+		Code:           "morpho",
+		Name:           "Морфо (Excel)",
+		FileType:       "xlsx",
+		SheetName:      "морфо",
+		HeaderRows:     1,
+		WorkerIDColumn: "E",
+		DateColumn:     "A",
+		TimeColumn:     "B",
+		DateTimeLayout: "MM-DD-YY h:mm:ss A",
+	},
+	{
+		Code:           "zkteco",
+		Name:           "ZKTeco (CSV)",
+		FileType:       "csv",
+		CSVDelimiter:   "\t",
+		WorkerIDColumn: "A",
+		DateColumn:     "B",
+		DateTimeLayout: "YYYY-MM-DD HH:mm:ss",
+	},
+}
+
+var attendanceDateTimeTokens = regexp.MustCompile(`YYYY|YY|MM|DD|HH|hh|h|mm|ss|A`)
+
+var attendanceDateTimeLayouts = map[string]string{
+	"YYYY": "2006",
+	"YY":   "06",
+	"MM":   "01",
+	"DD":   "02",
+	"HH":   "15",
+	"hh":   "03",
+	"h":    "3",
+	"mm":   "04",
+	"ss":   "05",
+	"A":    "PM",
+}
+
+// Builtin formats followed by the formats added in the project
+func attendanceImportFormats(formatRepo repository.IAttendanceImportFormatRepository, projectID uint) ([]model.AttendanceImportFormat, error) {
+	formats, err := formatRepo.GetAll(projectID)
+	if err != nil {
+		return []model.AttendanceImportFormat{}, err
+	}
+
+	return append(append([]model.AttendanceImportFormat{}, builtinAttendanceImportFormats...), formats...), nil
+}
+
+func attendanceImportFormatByCode(formatRepo repository.IAttendanceImportFormatRepository, projectID uint, code string) (model.AttendanceImportFormat, error) {
+	formats, err := attendanceImportFormats(formatRepo, projectID)
+	if err != nil {
+		return model.AttendanceImportFormat{}, err
+	}
+
+	for _, format := range formats {
+		if format.Code == code {
+			return format, nil
+		}
+	}
+
+	return model.AttendanceImportFormat{}, fmt.Errorf("Формат импорта '%s' не найден", code)
+}
+
+func isBuiltinAttendanceImportFormat(code string) bool {
+	for _, format := range builtinAttendanceImportFormats {
+		if format.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Converts the layout written with YYYY, MM, DD... tokens into the layout of the time package
+func attendanceDateTimeLayout(layout string) string {
+	return attendanceDateTimeTokens.ReplaceAllStringFunc(layout, func(token string) string {
+		return attendanceDateTimeLayouts[token]
+	})
+}
+
+func validateAttendanceImportFormat(format model.AttendanceImportFormat) error {
+	if !objectTypeCodePattern.MatchString(format.Code) {
+		return fmt.Errorf("Код формата должен начинаться с латинской буквы и содержать только строчные латинские буквы, цифры и '_'")
+	}
+
+	if strings.TrimSpace(format.Name) == "" {
+		return fmt.Errorf("Название формата не указано")
+	}
+
+	switch format.FileType {
+	case "xlsx":
+	case "csv":
+		if utf8.RuneCountInString(format.CSVDelimiter) != 1 {
+			return fmt.Errorf("Разделитель CSV должен состоять из одного символа")
+		}
+	default:
+		return fmt.Errorf("Неизвестный тип файла: %s, допустимы xlsx и csv", format.FileType)
+	}
+
+	for _, column := range []string{format.WorkerIDColumn, format.DateColumn} {
+		if _, err := excelize.ColumnNameToNumber(column); err != nil {
+			return fmt.Errorf("Неправильная колонка '%s'", column)
+		}
+	}
+
+	if format.TimeColumn != "" {
+		if _, err := excelize.ColumnNameToNumber(format.TimeColumn); err != nil {
+			return fmt.Errorf("Неправильная колонка '%s'", format.TimeColumn)
+		}
+	}
+
+	if !attendanceDateTimeTokens.MatchString(format.DateTimeLayout) {
+		return fmt.Errorf("Формат даты '%s' не содержит ни одного элемента даты", format.DateTimeLayout)
+	}
+
+	return nil
+}
+
+// Reads the punches from the file in the given format, empty rows are skipped
+func readAttendancePunches(format model.AttendanceImportFormat, filePath string) ([]dto.WorkerAttendancePunch, error) {
+	rows, err := attendanceFileRows(format, filePath)
+	if err != nil {
+		return []dto.WorkerAttendancePunch{}, err
+	}
+
+	if len(rows) <= int(format.HeaderRows) {
+		return []dto.WorkerAttendancePunch{}, fmt.Errorf("Файл не имеет данных")
+	}
+
+	workerIDColumn, _ := excelize.ColumnNameToNumber(format.WorkerIDColumn)
+	dateColumn, _ := excelize.ColumnNameToNumber(format.DateColumn)
+	timeColumn := 0
+	if format.TimeColumn != "" {
+		timeColumn, _ = excelize.ColumnNameToNumber(format.TimeColumn)
+	}
+
+	cell := func(row []string, column int) string {
+		if column == 0 || column > len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[column-1])
+	}
+
+	layout := attendanceDateTimeLayout(format.DateTimeLayout)
+	result := []dto.WorkerAttendancePunch{}
+	for index := int(format.HeaderRows); index < len(rows); index++ {
+		row := rows[index]
+		companyWorkerID := cell(row, workerIDColumn)
+		dateValue := cell(row, dateColumn)
+		if companyWorkerID == "" && dateValue == "" {
+			continue
+		}
+
+		if timeColumn != 0 {
+			dateValue += " " + cell(row, timeColumn)
+		}
+
+		date, err := time.Parse(layout, dateValue)
+		if err != nil {
+			return []dto.WorkerAttendancePunch{}, fmt.Errorf("Ошибка в файле, строка %d: дата '%s' не соответствует формату '%s'", index+1, dateValue, format.DateTimeLayout)
+		}
+
+		result = append(result, dto.WorkerAttendancePunch{
+			CompanyWorkerID: companyWorkerID,
+			Date:            date,
+		})
+	}
+
+	return result, nil
+}
+
+func attendanceFileRows(format model.AttendanceImportFormat, filePath string) ([][]string, error) {
+	if format.FileType == "csv" {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return [][]string{}, fmt.Errorf("Не смог открыть файл: %v", err)
+		}
+		defer file.Close()
+
+		reader := csv.NewReader(file)
+		reader.Comma, _ = utf8.DecodeRuneInString(format.CSVDelimiter)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return [][]string{}, fmt.Errorf("Не смог прочитать CSV файл: %v", err)
+		}
+
+		return rows, nil
+	}
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return [][]string{}, fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	sheetName := format.SheetName
+	if sheetName == "" {
+		sheetName = f.GetSheetName(0)
+	}
+
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return [][]string{}, fmt.Errorf("Не смог найти таблицу '%s': %v", sheetName, err)
+	}
+
+	return rows, nil
+}
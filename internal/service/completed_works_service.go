@@ -0,0 +1,356 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+// First row of the tables in KS-2 and KS-3 templates
+const completedWorksTableStartRow = 15
+
+type completedWorksService struct {
+	completedWorksRepo repository.ICompletedWorksRepository
+	projectRepo        repository.IProjectRepository
+	objectTypeRepo     repository.IObjectTypeRepository
+}
+
+func NewCompletedWorksService(
+	completedWorksRepo repository.ICompletedWorksRepository,
+	projectRepo repository.IProjectRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) ICompletedWorksService {
+	return &completedWorksService{
+		completedWorksRepo: completedWorksRepo,
+		projectRepo:        projectRepo,
+		objectTypeRepo:     objectTypeRepo,
+	}
+}
+
+type ICompletedWorksService interface {
+	GetKS2(filter dto.CompletedWorksFilter) (dto.KS2Report, error)
+	GetKS3(filter dto.CompletedWorksFilter) (dto.KS3Report, error)
+	ExportKS2(filter dto.CompletedWorksFilter, format string) (string, error)
+	ExportKS3(filter dto.CompletedWorksFilter, format string) (string, error)
+}
+
+func (service *completedWorksService) GetKS2(filter dto.CompletedWorksFilter) (dto.KS2Report, error) {
+	header, err := service.header(filter, "КС2")
+	if err != nil {
+		return dto.KS2Report{}, err
+	}
+
+	operations, err := service.completedWorksRepo.GetConfirmedOperations(filter)
+	if err != nil {
+		return dto.KS2Report{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return dto.KS2Report{}, err
+	}
+
+	result := dto.KS2Report{
+		Header:  header,
+		Objects: []dto.KS2Object{},
+		Sum:     decimal.Zero,
+	}
+
+	// Operations come ordered by object so every object is one continuous block
+	for _, operation := range operations {
+		if len(result.Objects) == 0 || result.Objects[len(result.Objects)-1].ObjectID != operation.ObjectID {
+			result.Objects = append(result.Objects, dto.KS2Object{
+				ObjectID:   operation.ObjectID,
+				ObjectName: operation.ObjectName,
+				ObjectType: typeNames[operation.ObjectType],
+				Rows:       []dto.KS2Row{},
+				Sum:        decimal.Zero,
+			})
+		}
+
+		object := &result.Objects[len(result.Objects)-1]
+		object.Rows = append(object.Rows, dto.KS2Row{
+			OperationID: operation.OperationID,
+			Code:        operation.Code,
+			Name:        operation.Name,
+			Amount:      operation.Amount,
+			Price:       operation.Price,
+			Sum:         operation.Sum,
+		})
+		object.Sum = object.Sum.Add(operation.Sum)
+		result.Sum = result.Sum.Add(operation.Sum)
+	}
+
+	return result, nil
+}
+
+// Costs of the period are accompanied with the cumulative costs since the start of the year
+// and since the contract was signed
+func (service *completedWorksService) GetKS3(filter dto.CompletedWorksFilter) (dto.KS3Report, error) {
+	header, err := service.header(filter, "КС3")
+	if err != nil {
+		return dto.KS3Report{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return dto.KS3Report{}, err
+	}
+
+	yearStart := time.Date(filter.DateTo.Year(), time.January, 1, 0, 0, 0, 0, filter.DateTo.Location())
+	if header.ContractDate.After(yearStart) {
+		yearStart = header.ContractDate
+	}
+
+	rows := map[uint]*dto.KS3Row{}
+	order := []uint{}
+	total := dto.KS3Row{
+		ObjectName:        "Всего работ и затрат",
+		SumSinceStart:     decimal.Zero,
+		SumSinceYearStart: decimal.Zero,
+		SumForPeriod:      decimal.Zero,
+	}
+
+	for index, dateFrom := range []time.Time{header.ContractDate, yearStart, filter.DateFrom} {
+		operations, err := service.completedWorksRepo.GetConfirmedOperations(dto.CompletedWorksFilter{
+			ProjectID: filter.ProjectID,
+			DateFrom:  dateFrom,
+			DateTo:    filter.DateTo,
+		})
+		if err != nil {
+			return dto.KS3Report{}, err
+		}
+
+		for _, operation := range operations {
+			if _, exists := rows[operation.ObjectID]; !exists {
+				rows[operation.ObjectID] = &dto.KS3Row{
+					ObjectID:          operation.ObjectID,
+					ObjectName:        operation.ObjectName,
+					ObjectType:        typeNames[operation.ObjectType],
+					SumSinceStart:     decimal.Zero,
+					SumSinceYearStart: decimal.Zero,
+					SumForPeriod:      decimal.Zero,
+				}
+				order = append(order, operation.ObjectID)
+			}
+
+			row := rows[operation.ObjectID]
+			switch index {
+			case 0:
+				row.SumSinceStart = row.SumSinceStart.Add(operation.Sum)
+				total.SumSinceStart = total.SumSinceStart.Add(operation.Sum)
+			case 1:
+				row.SumSinceYearStart = row.SumSinceYearStart.Add(operation.Sum)
+				total.SumSinceYearStart = total.SumSinceYearStart.Add(operation.Sum)
+			case 2:
+				row.SumForPeriod = row.SumForPeriod.Add(operation.Sum)
+				total.SumForPeriod = total.SumForPeriod.Add(operation.Sum)
+			}
+		}
+	}
+
+	result := dto.KS3Report{
+		Header: header,
+		Rows:   []dto.KS3Row{},
+		Total:  total,
+	}
+
+	for _, objectID := range order {
+		result.Rows = append(result.Rows, *rows[objectID])
+	}
+
+	return result, nil
+}
+
+func (service *completedWorksService) ExportKS2(filter dto.CompletedWorksFilter, format string) (string, error) {
+	if err := validateCompletedWorksFormat(format); err != nil {
+		return "", err
+	}
+
+	report, err := service.GetKS2(filter)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "KS-2.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	totalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "КС-2"
+	setCompletedWorksHeader(f, sheetName, report.Header)
+
+	rowCount := completedWorksTableStartRow
+	number := 1
+	for _, object := range report.Objects {
+		f.MergeCell(sheetName, "A"+fmt.Sprint(rowCount), "F"+fmt.Sprint(rowCount))
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), fmt.Sprintf("Объект: %s (%s)", object.ObjectName, object.ObjectType))
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "F"+fmt.Sprint(rowCount), totalStyle)
+		rowCount++
+
+		for _, row := range object.Rows {
+			price, _ := row.Price.Float64()
+			sum, _ := row.Sum.Float64()
+			f.SetCellInt(sheetName, "A"+fmt.Sprint(rowCount), number)
+			f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), row.Code)
+			f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), row.Name)
+			f.SetCellFloat(sheetName, "D"+fmt.Sprint(rowCount), row.Amount, 2, 64)
+			f.SetCellFloat(sheetName, "E"+fmt.Sprint(rowCount), price, 2, 64)
+			f.SetCellFloat(sheetName, "F"+fmt.Sprint(rowCount), sum, 2, 64)
+			number++
+			rowCount++
+		}
+
+		objectSum, _ := object.Sum.Float64()
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), "Итого по объекту")
+		f.SetCellFloat(sheetName, "F"+fmt.Sprint(rowCount), objectSum, 2, 64)
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "F"+fmt.Sprint(rowCount), totalStyle)
+		rowCount++
+	}
+
+	reportSum, _ := report.Sum.Float64()
+	f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), "Всего по акту")
+	f.SetCellFloat(sheetName, "F"+fmt.Sprint(rowCount), reportSum, 2, 64)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "F"+fmt.Sprint(rowCount), totalStyle)
+	setCompletedWorksSignatures(f, sheetName, rowCount+2, totalStyle)
+
+	return saveCompletedWorksDocument(f, fmt.Sprintf("КС-2 %s.xlsx", report.Header.DocumentNumber), format)
+}
+
+func (service *completedWorksService) ExportKS3(filter dto.CompletedWorksFilter, format string) (string, error) {
+	if err := validateCompletedWorksFormat(format); err != nil {
+		return "", err
+	}
+
+	report, err := service.GetKS3(filter)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "KS-3.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	totalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "КС-3"
+	setCompletedWorksHeader(f, sheetName, report.Header)
+
+	rowCount := completedWorksTableStartRow
+	for index, row := range report.Rows {
+		f.SetCellInt(sheetName, "A"+fmt.Sprint(rowCount), index+1)
+		setKS3Row(f, sheetName, rowCount, row)
+		rowCount++
+	}
+
+	setKS3Row(f, sheetName, rowCount, report.Total)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "F"+fmt.Sprint(rowCount), totalStyle)
+	setCompletedWorksSignatures(f, sheetName, rowCount+2, totalStyle)
+
+	return saveCompletedWorksDocument(f, fmt.Sprintf("КС-3 %s.xlsx", report.Header.DocumentNumber), format)
+}
+
+// Both forms are built for the whole period, so the number of the document is made of the project and the period end
+func (service *completedWorksService) header(filter dto.CompletedWorksFilter, documentPrefix string) (dto.CompletedWorksHeader, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return dto.CompletedWorksHeader{}, fmt.Errorf("Отчетный период должен быть указан полностью")
+	}
+
+	if filter.DateFrom.After(filter.DateTo) {
+		return dto.CompletedWorksHeader{}, fmt.Errorf("Начало отчетного периода позже его окончания")
+	}
+
+	project, err := service.projectRepo.GetByID(filter.ProjectID)
+	if err != nil {
+		return dto.CompletedWorksHeader{}, err
+	}
+
+	return dto.CompletedWorksHeader{
+		ProjectName:    project.Name,
+		Client:         project.Client,
+		ContractDate:   project.SignedDateOfContract,
+		DocumentNumber: fmt.Sprintf("%s-%02d-%s", documentPrefix, project.ID, filter.DateTo.Format("2006-01")),
+		DateOfDocument: time.Now(),
+		DateFrom:       filter.DateFrom,
+		DateTo:         filter.DateTo,
+	}, nil
+}
+
+func validateCompletedWorksFormat(format string) error {
+	if format != "xlsx" && format != "pdf" {
+		return fmt.Errorf("Неизвестный формат документа: %s, допустимы xlsx и pdf", format)
+	}
+
+	return nil
+}
+
+func setCompletedWorksHeader(f *excelize.File, sheetName string, header dto.CompletedWorksHeader) {
+	f.SetCellStr(sheetName, "C4", header.Client)
+	f.SetCellStr(sheetName, "C6", header.ProjectName)
+	if !header.ContractDate.IsZero() {
+		f.SetCellStr(sheetName, "C7", header.ContractDate.Format("02.01.2006"))
+	}
+	f.SetCellStr(sheetName, "E10", header.DocumentNumber)
+	f.SetCellStr(sheetName, "E11", header.DateOfDocument.Format("02.01.2006"))
+	f.SetCellStr(sheetName, "E12", fmt.Sprintf("с %s по %s", header.DateFrom.Format("02.01.2006"), header.DateTo.Format("02.01.2006")))
+}
+
+func setCompletedWorksSignatures(f *excelize.File, sheetName string, rowCount int, style int) {
+	f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Сдал")
+	f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), "____________________")
+	f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), "Принял")
+	f.SetCellStr(sheetName, "E"+fmt.Sprint(rowCount), "____________________")
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "A"+fmt.Sprint(rowCount), style)
+	f.SetCellStyle(sheetName, "D"+fmt.Sprint(rowCount), "D"+fmt.Sprint(rowCount), style)
+}
+
+func setKS3Row(f *excelize.File, sheetName string, rowCount int, row dto.KS3Row) {
+	f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), row.ObjectName)
+	f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), row.ObjectType)
+	for column, sum := range map[string]decimal.Decimal{"D": row.SumSinceStart, "E": row.SumSinceYearStart, "F": row.SumForPeriod} {
+		sumFloat, _ := sum.Float64()
+		f.SetCellFloat(sheetName, column+fmt.Sprint(rowCount), sumFloat, 2, 64)
+	}
+}
+
+func saveCompletedWorksDocument(f *excelize.File, fileName, format string) (string, error) {
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	if format == "xlsx" {
+		return fileName, nil
+	}
+
+	defer os.Remove(filePath)
+	pdfFilePath, err := convertExcelToPDF(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(pdfFilePath), nil
+}
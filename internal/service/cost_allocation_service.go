@@ -0,0 +1,236 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Strategy decides which material costs are consumed when material leaves a location.
+// Stock is never empty and its total amount is not less than the amount to be allocated
+type costAllocationStrategy interface {
+	allocate(stock []dto.MaterialCostAmountInLocation, amount float64) []dto.MaterialCostAllocation
+}
+
+const defaultCostAllocationStrategy = "cost-m19-desc"
+
+var costAllocationStrategies = map[string]costAllocationStrategy{
+	"fifo": orderedCostAllocation{less: func(a, b dto.MaterialCostAmountInLocation) bool {
+		return a.DateOfReceipt.Before(b.DateOfReceipt)
+	}},
+	"cost-m19-asc": orderedCostAllocation{less: func(a, b dto.MaterialCostAmountInLocation) bool {
+		return a.MaterialCostM19.LessThan(b.MaterialCostM19)
+	}},
+	"cost-m19-desc": orderedCostAllocation{less: func(a, b dto.MaterialCostAmountInLocation) bool {
+		return a.MaterialCostM19.GreaterThan(b.MaterialCostM19)
+	}},
+	"weighted-average": weightedAverageCostAllocation{},
+}
+
+var costAllocationStrategyLabels = []dto.DataForSelect[string]{
+	{Label: "FIFO по дате поступления", Value: "fifo"},
+	{Label: "Сначала дешевые (по возрастанию М19)", Value: "cost-m19-asc"},
+	{Label: "Сначала дорогие (по убыванию М19)", Value: "cost-m19-desc"},
+	{Label: "Средневзвешенная стоимость", Value: "weighted-average"},
+}
+
+// Consumes material costs one after another in the order of the strategy
+type orderedCostAllocation struct {
+	less func(a, b dto.MaterialCostAmountInLocation) bool
+}
+
+func (strategy orderedCostAllocation) allocate(stock []dto.MaterialCostAmountInLocation, amount float64) []dto.MaterialCostAllocation {
+	sort.SliceStable(stock, func(i, j int) bool {
+		return strategy.less(stock[i], stock[j])
+	})
+
+	result := []dto.MaterialCostAllocation{}
+	for _, entry := range stock {
+		if amount <= 0 {
+			break
+		}
+
+		allocatedAmount := math.Min(entry.MaterialAmount, amount)
+		amount -= allocatedAmount
+		result = append(result, dto.MaterialCostAllocation{
+			MaterialCostID:  entry.MaterialCostID,
+			MaterialCostM19: entry.MaterialCostM19,
+			Amount:          allocatedAmount,
+		})
+	}
+
+	return result
+}
+
+// Consumes every material cost in proportion to its amount in the location,
+// so the consumed value equals the amount multiplied by the weighted average cost
+type weightedAverageCostAllocation struct{}
+
+func (strategy weightedAverageCostAllocation) allocate(stock []dto.MaterialCostAmountInLocation, amount float64) []dto.MaterialCostAllocation {
+	total := 0.0
+	for _, entry := range stock {
+		total += entry.MaterialAmount
+	}
+
+	allocatedAmounts := make([]float64, len(stock))
+	left := amount
+	for index, entry := range stock {
+		allocatedAmounts[index] = math.Min(entry.MaterialAmount, math.Round(amount*entry.MaterialAmount/total*1000)/1000)
+		left -= allocatedAmounts[index]
+	}
+
+	// Rounding leftover is taken from the material costs that still have amount left
+	for index, entry := range stock {
+		if left <= 0 {
+			break
+		}
+
+		extra := math.Min(entry.MaterialAmount-allocatedAmounts[index], left)
+		allocatedAmounts[index] = math.Round((allocatedAmounts[index]+extra)*1000) / 1000
+		left -= extra
+	}
+
+	// Shares rounded up can exceed the amount, the excess is given back starting
+	// from the last material cost
+	for index := len(stock) - 1; index >= 0 && left < 0; index-- {
+		excess := math.Min(allocatedAmounts[index], math.Round(-left*1000)/1000)
+		allocatedAmounts[index] = math.Round((allocatedAmounts[index]-excess)*1000) / 1000
+		left += excess
+	}
+
+	result := []dto.MaterialCostAllocation{}
+	for index, entry := range stock {
+		if allocatedAmounts[index] <= 0 {
+			continue
+		}
+
+		result = append(result, dto.MaterialCostAllocation{
+			MaterialCostID:  entry.MaterialCostID,
+			MaterialCostM19: entry.MaterialCostM19,
+			Amount:          allocatedAmounts[index],
+		})
+	}
+
+	return result
+}
+
+func costAllocationStrategyOfProject(projectRepo repository.IProjectRepository, projectID uint) (string, costAllocationStrategy, error) {
+	project, err := projectRepo.GetByID(projectID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	strategyName := project.CostAllocationStrategy
+	if strategyName == "" {
+		strategyName = defaultCostAllocationStrategy
+	}
+
+	strategy, exists := costAllocationStrategies[strategyName]
+	if !exists {
+		return "", nil, fmt.Errorf("Неизвестная стратегия распределения стоимости: %v", strategyName)
+	}
+
+	return strategyName, strategy, nil
+}
+
+// Decides which material costs of the material in the location are consumed by the amount
+// using the cost allocation strategy of the project
+func allocateMaterialCosts(
+	materialLocationRepo repository.IMaterialLocationRepository,
+	projectRepo repository.IProjectRepository,
+	projectID, materialID uint,
+	locationType string,
+	locationID uint,
+	amount float64,
+) ([]dto.MaterialCostAllocation, error) {
+	_, strategy, err := costAllocationStrategyOfProject(projectRepo, projectID)
+	if err != nil {
+		return []dto.MaterialCostAllocation{}, err
+	}
+
+	stock, err := materialLocationRepo.GetMaterialAmountsWithDateOfReceiptInLocation(projectID, materialID, locationType, locationID)
+	if err != nil {
+		return []dto.MaterialCostAllocation{}, err
+	}
+
+	total := 0.0
+	for _, entry := range stock {
+		total += entry.MaterialAmount
+	}
+
+	if amount-total > 1e-9 {
+		return []dto.MaterialCostAllocation{}, fmt.Errorf("Количество материала превышает остаток: указано %v, имеется %v", amount, total)
+	}
+
+	if amount <= 0 {
+		return []dto.MaterialCostAllocation{}, nil
+	}
+
+	return strategy.allocate(stock, amount), nil
+}
+
+type costAllocationService struct {
+	materialLocationRepo repository.IMaterialLocationRepository
+	projectRepo          repository.IProjectRepository
+}
+
+func NewCostAllocationService(
+	materialLocationRepo repository.IMaterialLocationRepository,
+	projectRepo repository.IProjectRepository,
+) ICostAllocationService {
+	return &costAllocationService{
+		materialLocationRepo: materialLocationRepo,
+		projectRepo:          projectRepo,
+	}
+}
+
+type ICostAllocationService interface {
+	GetStrategies() []dto.DataForSelect[string]
+	GetStrategy(projectID uint) (string, error)
+	UpdateStrategy(projectID uint, strategy string) error
+	Preview(projectID, materialID uint, locationType string, locationID uint, amount float64) (dto.CostAllocationPreview, error)
+}
+
+func (service *costAllocationService) GetStrategies() []dto.DataForSelect[string] {
+	return costAllocationStrategyLabels
+}
+
+func (service *costAllocationService) GetStrategy(projectID uint) (string, error) {
+	strategyName, _, err := costAllocationStrategyOfProject(service.projectRepo, projectID)
+	return strategyName, err
+}
+
+func (service *costAllocationService) UpdateStrategy(projectID uint, strategy string) error {
+	if _, exists := costAllocationStrategies[strategy]; !exists {
+		return fmt.Errorf("Неизвестная стратегия распределения стоимости: %v", strategy)
+	}
+
+	return service.projectRepo.UpdateCostAllocationStrategy(projectID, strategy)
+}
+
+func (service *costAllocationService) Preview(projectID, materialID uint, locationType string, locationID uint, amount float64) (dto.CostAllocationPreview, error) {
+	strategyName, err := service.GetStrategy(projectID)
+	if err != nil {
+		return dto.CostAllocationPreview{}, err
+	}
+
+	allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, projectID, materialID, locationType, locationID, amount)
+	if err != nil {
+		return dto.CostAllocationPreview{}, err
+	}
+
+	totalCost := decimal.Zero
+	for _, allocation := range allocations {
+		totalCost = totalCost.Add(allocation.MaterialCostM19.Mul(decimal.NewFromFloat(allocation.Amount)))
+	}
+
+	return dto.CostAllocationPreview{
+		Strategy:    strategyName,
+		Allocations: allocations,
+		TotalCost:   totalCost,
+	}, nil
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"archive/zip"
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/xuri/excelize/v2"
+)
+
+const handoverActTemplateDirectory = "./pkg/excels/templates/handover-acts/"
+
+// Act is generated for the objects whose work is finished, that is installed or further in the lifecycle
+const handoverActObjectStatus = "installed"
+
+type handoverActService struct {
+	handoverActRepo repository.IHandoverActRepository
+	objectRepo      repository.IObjectRepository
+	projectRepo     repository.IProjectRepository
+	objectTypeRepo  repository.IObjectTypeRepository
+}
+
+func NewHandoverActService(
+	handoverActRepo repository.IHandoverActRepository,
+	objectRepo repository.IObjectRepository,
+	projectRepo repository.IProjectRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) IHandoverActService {
+	return &handoverActService{
+		handoverActRepo: handoverActRepo,
+		objectRepo:      objectRepo,
+		projectRepo:     projectRepo,
+		objectTypeRepo:  objectTypeRepo,
+	}
+}
+
+type IHandoverActService interface {
+	GetAll(projectID uint) ([]dto.HandoverActView, error)
+	Generate(projectID, userID uint, data dto.HandoverActGenerate) (string, error)
+	GetTemplateFile(projectID uint) string
+	UploadTemplate(projectID uint, filePath string) error
+	ResetTemplate(projectID uint) error
+}
+
+func (service *handoverActService) GetAll(projectID uint) ([]dto.HandoverActView, error) {
+	acts, err := service.handoverActRepo.GetAll(projectID)
+	if err != nil {
+		return []dto.HandoverActView{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return []dto.HandoverActView{}, err
+	}
+
+	for index := range acts {
+		acts[index].ObjectType = typeNames[acts[index].ObjectType]
+	}
+
+	return acts, nil
+}
+
+// Generates the acts of the given objects, one object gives a single document
+// and several objects give a zip archive with the document of every object
+func (service *handoverActService) Generate(projectID, userID uint, data dto.HandoverActGenerate) (string, error) {
+	if data.Format != "xlsx" && data.Format != "pdf" {
+		return "", fmt.Errorf("Неизвестный формат документа: %s, допустимы xlsx и pdf", data.Format)
+	}
+
+	if len(data.ObjectIDs) == 0 {
+		return "", fmt.Errorf("Не выбрано ни одного объекта")
+	}
+
+	project, err := service.projectRepo.GetByID(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return "", err
+	}
+
+	documents := []string{}
+	removeDocuments := func() {
+		for _, document := range documents {
+			os.Remove(document)
+		}
+	}
+
+	for _, objectID := range data.ObjectIDs {
+		object, err := service.objectRepo.GetByID(objectID)
+		if err != nil {
+			removeDocuments()
+			return "", err
+		}
+
+		if object.ID == 0 || object.ProjectID != projectID {
+			removeDocuments()
+			return "", fmt.Errorf("Объект с ID %d не найден", objectID)
+		}
+
+		if !objectStatusReached(object.Status, handoverActObjectStatus) {
+			removeDocuments()
+			return "", fmt.Errorf("Объект '%s' не завершен, его статус '%s'. Акт формируется начиная со статуса '%s'", object.Name, objectStatusName(object.Status), objectStatusName(handoverActObjectStatus))
+		}
+
+		act, err := service.getOrCreateAct(projectID, userID, objectID)
+		if err != nil {
+			removeDocuments()
+			return "", err
+		}
+
+		document, err := service.generateDocument(project, object, typeNames[object.Type], act, data.Format)
+		if err != nil {
+			removeDocuments()
+			return "", err
+		}
+
+		documents = append(documents, document)
+	}
+
+	if len(documents) == 1 {
+		return filepath.Base(documents[0]), nil
+	}
+
+	defer removeDocuments()
+	fileName := fmt.Sprintf("Акты приемки-передачи - %s.zip", time.Now().Format("02-01-2006 15-04-05"))
+	if err := zipFiles(filepath.Join("./pkg/excels/temp/", fileName), documents); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// Template of the project if it was uploaded, otherwise the default one
+func (service *handoverActService) GetTemplateFile(projectID uint) string {
+	projectTemplate := filepath.Join(handoverActTemplateDirectory, fmt.Sprintf("%d.xlsx", projectID))
+	if _, err := os.Stat(projectTemplate); err == nil {
+		return projectTemplate
+	}
+
+	return filepath.Join("./pkg/excels/templates/", "Handover Act.xlsx")
+}
+
+func (service *handoverActService) UploadTemplate(projectID uint, filePath string) error {
+	defer os.Remove(filePath)
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	f.Close()
+
+	if err := os.MkdirAll(handoverActTemplateDirectory, 0755); err != nil {
+		return fmt.Errorf("Не удалось создать папку для шаблонов: %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(handoverActTemplateDirectory, fmt.Sprintf("%d.xlsx", projectID)), content, 0644)
+}
+
+func (service *handoverActService) ResetTemplate(projectID uint) error {
+	err := os.Remove(filepath.Join(handoverActTemplateDirectory, fmt.Sprintf("%d.xlsx", projectID)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (service *handoverActService) getOrCreateAct(projectID, userID, objectID uint) (model.HandoverAct, error) {
+	act, err := service.handoverActRepo.GetByObjectID(objectID)
+	if err != nil {
+		return model.HandoverAct{}, err
+	}
+
+	if act.ID != 0 {
+		return act, nil
+	}
+
+	return service.handoverActRepo.Create(model.HandoverAct{
+		ProjectID:       projectID,
+		ObjectID:        objectID,
+		Date:            time.Now(),
+		CreatedByUserID: userID,
+	})
+}
+
+// Fills the template: text placeholders are replaced in every cell of the first sheet,
+// rows with {{materials}} and {{operations}} markers are expanded into the tables
+func (service *handoverActService) generateDocument(project model.Project, object model.Object, objectTypeName string, act model.HandoverAct, format string) (string, error) {
+	materials, err := service.handoverActRepo.GetMaterials(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	serialNumbers, err := service.handoverActRepo.GetSerialNumbers(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	operations, err := service.handoverActRepo.GetOperations(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	workPeriod, err := service.handoverActRepo.GetWorkPeriod(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	teams, err := service.handoverActRepo.GetTeamNumbers(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	supervisors, err := service.handoverActRepo.GetSupervisorNames(object.ID)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := excelize.OpenFile(service.GetTemplateFile(project.ID))
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	formatDate := func(date time.Time) string {
+		if date.IsZero() {
+			return ""
+		}
+
+		return date.Format("02.01.2006")
+	}
+
+	placeholders := strings.NewReplacer(
+		"{{act_number}}", act.Number,
+		"{{act_date}}", formatDate(act.Date),
+		"{{project_name}}", project.Name,
+		"{{object_name}}", object.Name,
+		"{{object_type}}", objectTypeName,
+		"{{work_start}}", formatDate(workPeriod.Start),
+		"{{work_end}}", formatDate(workPeriod.End),
+		"{{supervisors}}", strings.Join(supervisors, ", "),
+		"{{teams}}", strings.Join(teams, ", "),
+	)
+
+	sheetName := f.GetSheetName(0)
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return "", err
+	}
+
+	type tableMarker struct {
+		row    int
+		column int
+	}
+
+	tableMarkers := map[string]tableMarker{}
+	for rowIndex, row := range rows {
+		for columnIndex, value := range row {
+			if !strings.Contains(value, "{{") {
+				continue
+			}
+
+			cellName, _ := excelize.CoordinatesToCellName(columnIndex+1, rowIndex+1)
+			trimmed := strings.TrimSpace(value)
+			if trimmed == "{{materials}}" || trimmed == "{{operations}}" {
+				tableMarkers[trimmed] = tableMarker{row: rowIndex + 1, column: columnIndex + 1}
+				continue
+			}
+
+			f.SetCellStr(sheetName, cellName, placeholders.Replace(value))
+		}
+	}
+
+	materialSerialNumbers := map[uint][]string{}
+	for _, serialNumber := range serialNumbers {
+		materialSerialNumbers[serialNumber.MaterialID] = append(materialSerialNumbers[serialNumber.MaterialID], serialNumber.Code)
+	}
+
+	tables := []struct {
+		marker string
+		values [][]interface{}
+	}{{marker: "{{materials}}"}, {marker: "{{operations}}"}}
+	for index, material := range materials {
+		tables[0].values = append(tables[0].values, []interface{}{
+			index + 1, material.Code, material.Name, material.Unit, material.Amount, strings.Join(materialSerialNumbers[material.MaterialID], ", "),
+		})
+	}
+	for index, operation := range operations {
+		tables[1].values = append(tables[1].values, []interface{}{
+			index + 1, operation.Code, operation.Name, operation.Amount,
+		})
+	}
+
+	// Lower table is filled first so that the rows inserted for it do not move the upper marker
+	sort.Slice(tables, func(i, j int) bool {
+		return tableMarkers[tables[i].marker].row > tableMarkers[tables[j].marker].row
+	})
+
+	// Table starts in the cell of its marker
+	for _, table := range tables {
+		marker, exists := tableMarkers[table.marker]
+		if !exists {
+			continue
+		}
+
+		markerCell, _ := excelize.CoordinatesToCellName(marker.column, marker.row)
+		f.SetCellStr(sheetName, markerCell, "")
+		if len(table.values) > 1 {
+			if err := f.InsertRows(sheetName, marker.row+1, len(table.values)-1); err != nil {
+				return "", err
+			}
+		}
+
+		for index, values := range table.values {
+			cellName, _ := excelize.CoordinatesToCellName(marker.column, marker.row+index)
+			if err := f.SetSheetRow(sheetName, cellName, &values); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	fileName := strings.NewReplacer("/", "-", "\\", "-").Replace(fmt.Sprintf("Акт приемки-передачи %s - %s.xlsx", act.Number, object.Name))
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	if format == "xlsx" {
+		return filePath, nil
+	}
+
+	defer os.Remove(filePath)
+	return convertExcelToPDF(filePath)
+}
+
+// Converts the workbook with the office suite given in the configuration,
+// the PDF is placed next to the workbook
+func convertExcelToPDF(excelFilePath string) (string, error) {
+	converter := viper.GetString("Documents.PdfConverter")
+	if converter == "" {
+		converter = "soffice"
+	}
+
+	outputDirectory := filepath.Dir(excelFilePath)
+	output, err := exec.Command(converter, "--headless", "--convert-to", "pdf", "--outdir", outputDirectory, excelFilePath).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("Не удалось преобразовать документ в PDF: %v %s", err, string(output))
+	}
+
+	pdfFilePath := strings.TrimSuffix(excelFilePath, filepath.Ext(excelFilePath)) + ".pdf"
+	if _, err := os.Stat(pdfFilePath); err != nil {
+		return "", fmt.Errorf("Не удалось преобразовать документ в PDF: %v", err)
+	}
+
+	return pdfFilePath, nil
+}
+
+func zipFiles(archivePath string, filePaths []string) error {
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	archive := zip.NewWriter(archiveFile)
+	for _, filePath := range filePaths {
+		source, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+
+		destination, err := archive.Create(filepath.Base(filePath))
+		if err != nil {
+			source.Close()
+			return err
+		}
+
+		_, err = io.Copy(destination, source)
+		source.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
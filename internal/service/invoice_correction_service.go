@@ -4,7 +4,6 @@ import (
 	"backend-v2/internal/dto"
 	"backend-v2/internal/repository"
 	"backend-v2/model"
-	"backend-v2/pkg/utils"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -17,6 +16,9 @@ type invoiceCorrectionService struct {
 	invoiceObjectRepo     repository.IInvoiceObjectRepository
 	invoiceMaterialsRepo  repository.IInvoiceMaterialsRepository
 	materialLocationRepo  repository.IMaterialLocationRepository
+	materialRepo          repository.IMaterialRepository
+	materialLotRepo       repository.IMaterialLotRepository
+	projectRepo           repository.IProjectRepository
 }
 
 func InitInvoiceCorrectionService(
@@ -24,12 +26,18 @@ func InitInvoiceCorrectionService(
 	invoiceObjectRepo repository.IInvoiceObjectRepository,
 	invoiceMaterialsRepo repository.IInvoiceMaterialsRepository,
 	materialLocationRepo repository.IMaterialLocationRepository,
+	materialRepo repository.IMaterialRepository,
+	materialLotRepo repository.IMaterialLotRepository,
+	projectRepo repository.IProjectRepository,
 ) IInvoiceCorrectionService {
 	return &invoiceCorrectionService{
 		invoiceCorrectionRepo: invoiceCorrection,
 		invoiceObjectRepo:     invoiceObjectRepo,
 		invoiceMaterialsRepo:  invoiceMaterialsRepo,
 		materialLocationRepo:  materialLocationRepo,
+		materialRepo:          materialRepo,
+		materialLotRepo:       materialLotRepo,
+		projectRepo:           projectRepo,
 	}
 }
 
@@ -104,41 +112,52 @@ func (service *invoiceCorrectionService) Create(data dto.InvoiceCorrectionCreate
 	invoiceObject.DateOfCorrection = data.Details.DateOfCorrection
 
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
-		materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(invoiceObject.ProjectID, invoiceMaterial.MaterialID, "team", invoiceObject.TeamID)
+		lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+			service.materialRepo,
+			service.materialLotRepo,
+			invoiceMaterial.MaterialID,
+			"team",
+			invoiceObject.TeamID,
+			model.InvoiceMaterials{
+				ProjectID:   invoiceObject.ProjectID,
+				InvoiceID:   invoiceObject.ID,
+				InvoiceType: "object-correction",
+				IsDefected:  false,
+				Amount:      invoiceMaterial.MaterialAmount,
+				Notes:       invoiceMaterial.Notes,
+			},
+			1,
+		)
 		if err != nil {
-			return model.InvoiceObject{}, err
+			return model.InvoiceObject{}, fmt.Errorf("Ошибка корректировки: %v", err)
+		}
+
+		if hasLot {
+			invoiceMaterialForCreate = append(invoiceMaterialForCreate, lotInvoiceMaterials...)
+			materialLotMovements = append(materialLotMovements, lotMovements...)
+			continue
 		}
 
-		fmt.Println(materialInfoSorted)
+		allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, invoiceObject.ProjectID, invoiceMaterial.MaterialID, "team", invoiceObject.TeamID, invoiceMaterial.MaterialAmount)
+		if err != nil {
+			return model.InvoiceObject{}, fmt.Errorf("Ошибка корректировки: %v", err)
+		}
 
-		index := 0
-		for invoiceMaterial.MaterialAmount > 0 {
-			if len(materialInfoSorted) == 0 {
-				fmt.Println(len(materialInfoSorted))
-				return model.InvoiceObject{}, fmt.Errorf("Ошибка корректировки: количество материала внутри корректировки превышает количество материала у бригады")
-			}
+		for _, allocation := range allocations {
 			invoiceMaterialCreate := model.InvoiceMaterials{
 				ProjectID:      invoiceObject.ProjectID,
 				ID:             0,
-				MaterialCostID: materialInfoSorted[index].MaterialCostID,
+				MaterialCostID: allocation.MaterialCostID,
 				InvoiceID:      invoiceObject.ID,
 				InvoiceType:    "object-correction",
 				IsDefected:     false,
-				Amount:         0,
+				Amount:         allocation.Amount,
 				Notes:          invoiceMaterial.Notes,
 			}
 
-			if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.MaterialAmount {
-				invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-				invoiceMaterial.MaterialAmount -= materialInfoSorted[index].MaterialAmount
-			} else {
-				invoiceMaterialCreate.Amount = invoiceMaterial.MaterialAmount
-				invoiceMaterial.MaterialAmount = 0
-			}
-
 			invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-			index++
 		}
 	}
 
@@ -185,6 +204,11 @@ func (service *invoiceCorrectionService) Create(data dto.InvoiceCorrectionCreate
 		toBeUpdatedObjectLocations = append(toBeUpdatedObjectLocations, materialInObjectLocation)
 	}
 
+	operatorErrors, err := service.findOperatorErrors(invoiceObject, data.Items)
+	if err != nil {
+		return model.InvoiceObject{}, err
+	}
+
 	result, err := service.invoiceCorrectionRepo.Create(dto.InvoiceCorrectionCreateQuery{
 		Details:           invoiceObject,
 		InvoiceMaterials:  invoiceMaterialForCreate,
@@ -195,8 +219,77 @@ func (service *invoiceCorrectionService) Create(data dto.InvoiceCorrectionCreate
 			OperatorWorkerID: data.Details.OperatorWorkerID,
 			InvoiceObjectID:  invoiceObject.ID,
 		},
+		OperatorErrors:       operatorErrors,
+		MaterialLotMovements: materialLotMovements,
 	})
 
+	return result, err
+}
+
+// Compares the materials originally entered by the supervisor with the amounts
+// that the operator confirmed and records every material that did not match
+func (service *invoiceCorrectionService) findOperatorErrors(invoiceObject model.InvoiceObject, items []dto.InvoiceCorrectionMaterialsData) ([]model.OperatorErrorFound, error) {
+	invoiceMaterialLines, err := service.invoiceCorrectionRepo.GetInvoiceMaterialLinesByInvoiceObjectID(invoiceObject.ID)
+	if err != nil {
+		return []model.OperatorErrorFound{}, err
+	}
+
+	expectedAmounts := map[uint]float64{}
+	firstLineOfMaterial := map[uint]dto.InvoiceCorrectionMaterialLine{}
+	materialOrder := []uint{}
+	for _, line := range invoiceMaterialLines {
+		if _, exists := firstLineOfMaterial[line.MaterialID]; !exists {
+			firstLineOfMaterial[line.MaterialID] = line
+			materialOrder = append(materialOrder, line.MaterialID)
+		}
+		expectedAmounts[line.MaterialID] += line.Amount
+	}
+
+	correctedAmounts := map[uint]float64{}
+	notes := map[uint]string{}
+	for _, item := range items {
+		if _, exists := correctedAmounts[item.MaterialID]; !exists {
+			if _, exists := firstLineOfMaterial[item.MaterialID]; !exists {
+				materialOrder = append(materialOrder, item.MaterialID)
+			}
+		}
+		correctedAmounts[item.MaterialID] += item.MaterialAmount
+		notes[item.MaterialID] = item.Notes
+	}
+
+	result := []model.OperatorErrorFound{}
+	for _, materialID := range materialOrder {
+		expected := expectedAmounts[materialID]
+		corrected := correctedAmounts[materialID]
+		if expected == corrected {
+			continue
+		}
+
+		line, exists := firstLineOfMaterial[materialID]
+		if !exists {
+			materialCosts, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(invoiceObject.ProjectID, materialID, "team", invoiceObject.TeamID)
+			if err != nil {
+				return []model.OperatorErrorFound{}, err
+			}
+
+			if len(materialCosts) != 0 {
+				line.MaterialCostID = materialCosts[0].MaterialCostID
+			}
+		}
+
+		result = append(result, model.OperatorErrorFound{
+			ProjectID:          invoiceObject.ProjectID,
+			InvoiceObjectID:    invoiceObject.ID,
+			InvoiceMaterialsID: line.InvoiceMaterialID,
+			MaterialCostID:     line.MaterialCostID,
+			ExpectedAmount:     expected,
+			CorrectedAmount:    corrected,
+			Amount:             corrected - expected,
+			Notes:              notes[materialID],
+			DateOfCorrection:   invoiceObject.DateOfCorrection,
+		})
+	}
+
 	return result, nil
 }
 
@@ -233,7 +326,7 @@ func (service *invoiceCorrectionService) Report(filter dto.InvoiceCorrectionRepo
 		for index, invoiceMaterial := range invoiceMaterials {
 			f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount+index), invoice.DeliveryCode)
 			f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount+index), invoice.ObjectName)
-			f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount+index), utils.ObjectTypeConverter(invoice.ObjectType))
+			f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount+index), objectTypeName(invoice.ObjectType))
 			f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount+index), invoice.TeamNumber)
 			f.SetCellStr(sheetName, "E"+fmt.Sprint(rowCount+index), invoice.TeamLeaderName)
 			dateOfInvoice := invoice.DateOfInvoice.String()
@@ -24,6 +24,7 @@ type invoiceInputService struct {
 	serialNumberRepo         repository.ISerialNumberRepository
 	serialNumberMovementRepo repository.ISerialNumberMovementRepository
 	invoiceCountRepo         repository.IInvoiceCountRepository
+	materialUnitRepo         repository.IMaterialUnitRepository
 }
 
 func InitInvoiceInputService(
@@ -36,6 +37,7 @@ func InitInvoiceInputService(
 	serialNumberRepo repository.ISerialNumberRepository,
 	serialNumberMovementRepo repository.ISerialNumberMovementRepository,
 	invoiceCountRepo repository.IInvoiceCountRepository,
+	materialUnitRepo repository.IMaterialUnitRepository,
 ) IInvoiceInputService {
 	return &invoiceInputService{
 		invoiceInputRepo:         invoiceInputRepo,
@@ -47,6 +49,7 @@ func InitInvoiceInputService(
 		serialNumberRepo:         serialNumberRepo,
 		serialNumberMovementRepo: serialNumberMovementRepo,
 		invoiceCountRepo:         invoiceCountRepo,
+		materialUnitRepo:         materialUnitRepo,
 	}
 }
 
@@ -153,7 +156,13 @@ func (service *invoiceInputService) Create(data dto.InvoiceInput) (model.Invoice
 	var invoiceMaterials []model.InvoiceMaterials
 	var serialNumbers []model.SerialNumber
 	var serialNumberMovements []model.SerialNumberMovement
+	var materialLots []model.MaterialLot
+	var materialLotMovements []model.MaterialLotMovement
 	for _, item := range data.Items {
+		if err := service.normalizeUnit(&item.MaterialData); err != nil {
+			return model.InvoiceInput{}, err
+		}
+
 		invoiceMaterials = append(invoiceMaterials, model.InvoiceMaterials{
 			ProjectID:      data.Details.ProjectID,
 			MaterialCostID: item.MaterialData.MaterialCostID,
@@ -161,8 +170,26 @@ func (service *invoiceInputService) Create(data dto.InvoiceInput) (model.Invoice
 			InvoiceType:    "input",
 			Amount:         item.MaterialData.Amount,
 			Notes:          item.MaterialData.Notes,
+			MaterialUnitID: item.MaterialData.MaterialUnitID,
+			UnitAmount:     item.MaterialData.UnitAmount,
 		})
 
+		materialLot, hasLot, err := service.materialLotOfItem(data.Details.ProjectID, item)
+		if err != nil {
+			return model.InvoiceInput{}, err
+		}
+
+		if hasLot {
+			materialLots = append(materialLots, materialLot)
+			materialLotMovements = append(materialLotMovements, model.MaterialLotMovement{
+				ProjectID:    data.Details.ProjectID,
+				InvoiceType:  "input",
+				Amount:       item.MaterialData.Amount,
+				IsDefected:   item.MaterialData.IsDefected,
+				Confirmation: false,
+			})
+		}
+
 		if len(item.SerialNumbers) == 0 {
 			continue
 		}
@@ -189,6 +216,8 @@ func (service *invoiceInputService) Create(data dto.InvoiceInput) (model.Invoice
 		InvoiceMaterials:     invoiceMaterials,
 		SerialNumbers:        serialNumbers,
 		SerialNumberMovement: serialNumberMovements,
+		MaterialLots:         materialLots,
+		MaterialLotMovements: materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceInput{}, err
@@ -197,11 +226,68 @@ func (service *invoiceInputService) Create(data dto.InvoiceInput) (model.Invoice
 	return invoiceInput, nil
 }
 
+// Amount of the input material can be entered in any of the configured units of the material,
+// it is stored in the base unit while the entered amount is kept for documents
+func (service *invoiceInputService) normalizeUnit(invoiceMaterial *model.InvoiceMaterials) error {
+	if invoiceMaterial.MaterialUnitID == 0 {
+		invoiceMaterial.UnitAmount = invoiceMaterial.Amount
+		return nil
+	}
+
+	materialCost, err := service.materialCostRepo.GetByID(invoiceMaterial.MaterialCostID)
+	if err != nil {
+		return err
+	}
+
+	amount, _, err := convertToBaseUnit(service.materialUnitRepo, materialCost.MaterialID, invoiceMaterial.MaterialUnitID, invoiceMaterial.Amount)
+	if err != nil {
+		return err
+	}
+
+	invoiceMaterial.UnitAmount = invoiceMaterial.Amount
+	invoiceMaterial.Amount = amount
+	return nil
+}
+
+// Lot is recorded only for materials with lot tracking, for them the lot number is required
+func (service *invoiceInputService) materialLotOfItem(projectID uint, item dto.InvoiceInputMaterial) (model.MaterialLot, bool, error) {
+	material, err := service.materialRepo.GetByMaterialCostID(item.MaterialData.MaterialCostID)
+	if err != nil {
+		return model.MaterialLot{}, false, err
+	}
+
+	if !material.HasLot {
+		return model.MaterialLot{}, false, nil
+	}
+
+	if item.LotNumber == "" {
+		return model.MaterialLot{}, false, fmt.Errorf("Для материала <<%v>> необходимо указать номер партии", material.Name)
+	}
+
+	if !item.ManufactureDate.IsZero() && !item.ExpiryDate.IsZero() && item.ExpiryDate.Before(item.ManufactureDate) {
+		return model.MaterialLot{}, false, fmt.Errorf("Срок годности партии %v материала <<%v>> раньше даты производства", item.LotNumber, material.Name)
+	}
+
+	return model.MaterialLot{
+		ProjectID:       projectID,
+		MaterialCostID:  item.MaterialData.MaterialCostID,
+		Code:            item.LotNumber,
+		ManufactureDate: item.ManufactureDate,
+		ExpiryDate:      item.ExpiryDate,
+	}, true, nil
+}
+
 func (service *invoiceInputService) Update(data dto.InvoiceInput) (model.InvoiceInput, error) {
 	var invoiceMaterials []model.InvoiceMaterials
 	var serialNumbers []model.SerialNumber
 	var serialNumberMovements []model.SerialNumberMovement
+	var materialLots []model.MaterialLot
+	var materialLotMovements []model.MaterialLotMovement
 	for _, item := range data.Items {
+		if err := service.normalizeUnit(&item.MaterialData); err != nil {
+			return model.InvoiceInput{}, err
+		}
+
 		invoiceMaterials = append(invoiceMaterials, model.InvoiceMaterials{
 			ProjectID:      data.Details.ProjectID,
 			MaterialCostID: item.MaterialData.MaterialCostID,
@@ -209,8 +295,26 @@ func (service *invoiceInputService) Update(data dto.InvoiceInput) (model.Invoice
 			InvoiceType:    "input",
 			Amount:         item.MaterialData.Amount,
 			Notes:          item.MaterialData.Notes,
+			MaterialUnitID: item.MaterialData.MaterialUnitID,
+			UnitAmount:     item.MaterialData.UnitAmount,
 		})
 
+		materialLot, hasLot, err := service.materialLotOfItem(data.Details.ProjectID, item)
+		if err != nil {
+			return model.InvoiceInput{}, err
+		}
+
+		if hasLot {
+			materialLots = append(materialLots, materialLot)
+			materialLotMovements = append(materialLotMovements, model.MaterialLotMovement{
+				ProjectID:    data.Details.ProjectID,
+				InvoiceType:  "input",
+				Amount:       item.MaterialData.Amount,
+				IsDefected:   item.MaterialData.IsDefected,
+				Confirmation: false,
+			})
+		}
+
 		if len(item.SerialNumbers) == 0 {
 			continue
 		}
@@ -237,6 +341,8 @@ func (service *invoiceInputService) Update(data dto.InvoiceInput) (model.Invoice
 		InvoiceMaterials:     invoiceMaterials,
 		SerialNumbers:        serialNumbers,
 		SerialNumberMovement: serialNumberMovements,
+		MaterialLots:         materialLots,
+		MaterialLotMovements: materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceInput{}, err
@@ -369,6 +475,10 @@ func (service *invoiceInputService) Report(filter dto.InvoiceInputReportFilterRe
 			costM19, _ := invoiceMaterial.MaterialCostM19.Float64()
 			f.SetCellFloat(sheetName, "H"+fmt.Sprint(rowCount), costM19, 2, 64)
 			f.SetCellValue(sheetName, "I"+fmt.Sprint(rowCount), invoiceMaterial.InvoiceMaterialNotes)
+
+			enteredUnit, enteredAmount := enteredUnitForReport(invoiceMaterial.MaterialUnit, invoiceMaterial.InvoiceMaterialAmount, invoiceMaterial.EnteredUnitName, invoiceMaterial.UnitAmount)
+			f.SetCellStr(sheetName, "J"+fmt.Sprint(rowCount), enteredUnit)
+			f.SetCellFloat(sheetName, "K"+fmt.Sprint(rowCount), enteredAmount, 3, 64)
 			rowCount++
 		}
 	}
@@ -405,6 +515,7 @@ func (service *invoiceInputService) NewMaterialAndItsCost(data dto.NewMaterialDa
 		ProjectID:       data.ProjectID,
 		Notes:           data.Notes,
 		HasSerialNumber: data.HasSerialNumber,
+		HasLot:          data.HasLot,
 	})
 	if err != nil {
 		return err
@@ -21,6 +21,10 @@ type invoiceObjectService struct {
 	operationMaterialRepo repository.IOperationMaterialRepository
   operationRepo repository.IOperationRepository
   materialRepo repository.IMaterialRepository
+  materialLotRepo repository.IMaterialLotRepository
+  projectRepo repository.IProjectRepository
+  operationBOMRepo repository.IOperationBOMRepository
+  teamMemberRepo repository.ITeamMemberRepository
 }
 
 func InitInvoiceObjectService(
@@ -36,6 +40,10 @@ func InitInvoiceObjectService(
 	operationMaterialRepo repository.IOperationMaterialRepository,
   operationRepo repository.IOperationRepository,
   materialRepo repository.IMaterialRepository,
+  materialLotRepo repository.IMaterialLotRepository,
+  projectRepo repository.IProjectRepository,
+  operationBOMRepo repository.IOperationBOMRepository,
+  teamMemberRepo repository.ITeamMemberRepository,
 ) IInvoiceObjectService {
 	return &invoiceObjectService{
 		invoiceObjectRepo:     invoiceObjectRepo,
@@ -50,6 +58,10 @@ func InitInvoiceObjectService(
 		operationMaterialRepo: operationMaterialRepo,
     operationRepo: operationRepo,
     materialRepo: materialRepo,
+    materialLotRepo: materialLotRepo,
+    projectRepo: projectRepo,
+    operationBOMRepo: operationBOMRepo,
+    teamMemberRepo: teamMemberRepo,
 	}
 }
 
@@ -126,10 +138,27 @@ func (service *invoiceObjectService) GetInvoiceObjectDescriptiveDataByID(id uint
 		invoiceMaterialsWithSerialNumber = append(invoiceMaterialsWithSerialNumber, current)
 	}
 
+	bomDeviations, err := service.operationBOMRepo.GetDeviationsByInvoiceObjectID(id)
+	if err != nil {
+		return dto.InvoiceObjectWithMaterialsDescriptive{}, err
+	}
+
+	invoiceObject, err := service.invoiceObjectRepo.GetByID(id)
+	if err != nil {
+		return dto.InvoiceObjectWithMaterialsDescriptive{}, err
+	}
+
+	teamMembers, err := service.teamMemberRepo.GetOnDate(invoiceObject.ProjectID, invoiceObject.TeamID, invoiceObject.DateOfInvoice)
+	if err != nil {
+		return dto.InvoiceObjectWithMaterialsDescriptive{}, err
+	}
+
 	return dto.InvoiceObjectWithMaterialsDescriptive{
 		InvoiceData:                  invoiceData,
 		MaterialsWithSerialNumber:    invoiceMaterialsWithSerialNumber,
 		MaterialsWithoutSerialNumber: invoiceMaterialsWithoutSerailNumber,
+		BOMDeviations:                bomDeviations,
+		TeamMembers:                  teamMembers,
 	}, nil
 }
 
@@ -149,36 +178,52 @@ func (service *invoiceObjectService) Create(data dto.InvoiceObjectCreate) (model
 
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	serialNumberMovements := []model.SerialNumberMovement{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, "team", data.Details.TeamID)
+			lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+				service.materialRepo,
+				service.materialLotRepo,
+				invoiceMaterial.MaterialID,
+				"team",
+				data.Details.TeamID,
+				model.InvoiceMaterials{
+					ProjectID:   data.Details.ProjectID,
+					InvoiceType: "object",
+					IsDefected:  false,
+					Amount:      invoiceMaterial.Amount,
+					Notes:       invoiceMaterial.Notes,
+				},
+				1,
+			)
+			if err != nil {
+				return model.InvoiceObject{}, err
+			}
+
+			if hasLot {
+				invoiceMaterialForCreate = append(invoiceMaterialForCreate, lotInvoiceMaterials...)
+				materialLotMovements = append(materialLotMovements, lotMovements...)
+				continue
+			}
+
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, "team", data.Details.TeamID, invoiceMaterial.Amount)
 			if err != nil {
 				return model.InvoiceObject{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "object",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 		}
 
@@ -247,11 +292,18 @@ func (service *invoiceObjectService) Create(data dto.InvoiceObjectCreate) (model
     })
   }
 
+	bomDeviations, err := objectInvoiceBOMDeviations(service.operationBOMRepo, data.Details.ProjectID, data.Operations, data.Items)
+	if err != nil {
+		return model.InvoiceObject{}, err
+	}
+
 	invoiceObject, err := service.invoiceObjectRepo.Create(dto.InvoiceObjectCreateQueryData{
 		Invoice:               data.Details,
 		InvoiceMaterials:      invoiceMaterialForCreate,
 		InvoiceOperations:      invoiceOperationsForCreate,
 		SerialNumberMovements: serialNumberMovements,
+		MaterialLotMovements:  materialLotMovements,
+		BOMDeviations:         bomDeviations,
 	})
 
 	return invoiceObject, err
@@ -22,6 +22,7 @@ type invoiceOutputOutOfProjectService struct {
 	materialsRepo                 repository.IMaterialRepository
 	workerRepo                    repository.IWorkerRepository
 	materialCostRepo              repository.IMaterialCostRepository
+	projectRepo                   repository.IProjectRepository
 }
 
 func InitInvoiceOutputOutOfProjectService(
@@ -33,6 +34,7 @@ func InitInvoiceOutputOutOfProjectService(
 	materialsRepo repository.IMaterialRepository,
 	workerRepo repository.IWorkerRepository,
 	materialCostRepo repository.IMaterialCostRepository,
+	projectRepo repository.IProjectRepository,
 ) IInvoiceOutputOutOfProjectService {
 	return &invoiceOutputOutOfProjectService{
 		invoiceOutputOutOfProjectRepo: invoiceOutputOutOfProjectRepo,
@@ -43,6 +45,7 @@ func InitInvoiceOutputOutOfProjectService(
 		materialsRepo:                 materialsRepo,
 		workerRepo:                    workerRepo,
 		materialCostRepo:              materialCostRepo,
+		projectRepo:                   projectRepo,
 	}
 }
 
@@ -82,34 +85,24 @@ func (service *invoiceOutputOutOfProjectService) Create(data dto.InvoiceOutputOu
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0)
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0, invoiceMaterial.Amount)
 			if err != nil {
 				return model.InvoiceOutputOutOfProject{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "output-out-of-project",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 		}
 
@@ -196,34 +189,24 @@ func (service *invoiceOutputOutOfProjectService) Update(data dto.InvoiceOutputOu
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0)
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0, invoiceMaterial.Amount)
 			if err != nil {
 				return model.InvoiceOutputOutOfProject{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "output-out-of-project",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 		}
 
@@ -31,6 +31,8 @@ type invoiceOutputService struct {
 	serialNumberRepo     repository.ISerialNumberRepository
 	invoiceCountRepo     repository.IInvoiceCountRepository
 	projectRepo          repository.IProjectRepository
+	materialUnitRepo     repository.IMaterialUnitRepository
+	materialLotRepo      repository.IMaterialLotRepository
 }
 
 func InitInvoiceOutputService(
@@ -46,6 +48,8 @@ func InitInvoiceOutputService(
 	serialNumberRepo repository.ISerialNumberRepository,
 	invoiceCountRepo repository.IInvoiceCountRepository,
 	projectRepo repository.IProjectRepository,
+	materialUnitRepo repository.IMaterialUnitRepository,
+	materialLotRepo repository.IMaterialLotRepository,
 ) IInvoiceOutputService {
 	return &invoiceOutputService{
 		invoiceOutputRepo:    invoiceOutputRepo,
@@ -60,6 +64,8 @@ func InitInvoiceOutputService(
 		serialNumberRepo:     serialNumberRepo,
 		invoiceCountRepo:     invoiceCountRepo,
 		projectRepo:          projectRepo,
+		materialUnitRepo:     materialUnitRepo,
+		materialLotRepo:      materialLotRepo,
 	}
 }
 
@@ -164,38 +170,62 @@ func (service *invoiceOutputService) Create(data dto.InvoiceOutput) (model.Invoi
 
 	data.Details.DeliveryCode = utils.UniqueCodeGeneration("О", int64(count+1), data.Details.ProjectID)
 
+	if err := normalizeUnits(service.materialUnitRepo, data.Items, invoiceOutputItemUnit); err != nil {
+		return model.InvoiceOutput{}, err
+	}
+
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	serialNumberMovements := []model.SerialNumberMovement{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0)
+			unitFactor := enteredUnitFactor(invoiceMaterial.MaterialUnitID, invoiceMaterial.Amount, invoiceMaterial.UnitAmount)
+			lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+				service.materialRepo,
+				service.materialLotRepo,
+				invoiceMaterial.MaterialID,
+				"warehouse",
+				0,
+				model.InvoiceMaterials{
+					ProjectID:      data.Details.ProjectID,
+					InvoiceType:    "output",
+					IsDefected:     false,
+					Amount:         invoiceMaterial.Amount,
+					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
+				},
+				unitFactor,
+			)
 			if err != nil {
 				return model.InvoiceOutput{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			if hasLot {
+				invoiceMaterialForCreate = append(invoiceMaterialForCreate, lotInvoiceMaterials...)
+				materialLotMovements = append(materialLotMovements, lotMovements...)
+				continue
+			}
+
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0, invoiceMaterial.Amount)
+			if err != nil {
+				return model.InvoiceOutput{}, err
+			}
+
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "output",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
+				invoiceMaterialCreate.UnitAmount = invoiceMaterialCreate.Amount / unitFactor
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 
 		}
@@ -283,6 +313,7 @@ func (service *invoiceOutputService) Create(data dto.InvoiceOutput) (model.Invoi
 		Invoice:               data.Details,
 		InvoiceMaterials:      correctInvoiceMaterials,
 		SerialNumberMovements: serialNumberMovements,
+		MaterialLotMovements:  materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceOutput{}, err
@@ -292,38 +323,62 @@ func (service *invoiceOutputService) Create(data dto.InvoiceOutput) (model.Invoi
 }
 
 func (service *invoiceOutputService) Update(data dto.InvoiceOutput) (model.InvoiceOutput, error) {
+	if err := normalizeUnits(service.materialUnitRepo, data.Items, invoiceOutputItemUnit); err != nil {
+		return model.InvoiceOutput{}, err
+	}
+
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	serialNumberMovements := []model.SerialNumberMovement{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0)
+			unitFactor := enteredUnitFactor(invoiceMaterial.MaterialUnitID, invoiceMaterial.Amount, invoiceMaterial.UnitAmount)
+			lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+				service.materialRepo,
+				service.materialLotRepo,
+				invoiceMaterial.MaterialID,
+				"warehouse",
+				0,
+				model.InvoiceMaterials{
+					ProjectID:      data.Details.ProjectID,
+					InvoiceType:    "output",
+					IsDefected:     false,
+					Amount:         invoiceMaterial.Amount,
+					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
+				},
+				unitFactor,
+			)
 			if err != nil {
 				return model.InvoiceOutput{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			if hasLot {
+				invoiceMaterialForCreate = append(invoiceMaterialForCreate, lotInvoiceMaterials...)
+				materialLotMovements = append(materialLotMovements, lotMovements...)
+				continue
+			}
+
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, "warehouse", 0, invoiceMaterial.Amount)
+			if err != nil {
+				return model.InvoiceOutput{}, err
+			}
+
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "output",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
+				invoiceMaterialCreate.UnitAmount = invoiceMaterialCreate.Amount / unitFactor
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 
 		}
@@ -397,6 +452,7 @@ func (service *invoiceOutputService) Update(data dto.InvoiceOutput) (model.Invoi
 		Invoice:               data.Details,
 		InvoiceMaterials:      invoiceMaterialForCreate,
 		SerialNumberMovements: serialNumberMovements,
+		MaterialLotMovements:  materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceOutput{}, err
@@ -405,6 +461,16 @@ func (service *invoiceOutputService) Update(data dto.InvoiceOutput) (model.Invoi
 	return invoiceOutput, nil
 }
 
+func invoiceOutputItemUnit(item *dto.InvoiceOutputItem) enteredUnitLine {
+	return enteredUnitLine{
+		materialID:     item.MaterialID,
+		materialUnitID: &item.MaterialUnitID,
+		amount:         &item.Amount,
+		unitAmount:     &item.UnitAmount,
+		serialNumbers:  item.SerialNumbers,
+	}
+}
+
 func (service *invoiceOutputService) Delete(id uint) error {
 
 	invoiceOutput, err := service.invoiceOutputRepo.GetByID(id)
@@ -558,6 +624,10 @@ func (service *invoiceOutputService) Report(filter dto.InvoiceOutputReportFilter
 			materialCostFloat, _ := invoiceMaterial.MaterialCostM19.Float64()
 			f.SetCellFloat(sheetName, "J"+fmt.Sprint(rowCount), materialCostFloat, 2, 64)
 			f.SetCellValue(sheetName, "K"+fmt.Sprint(rowCount), invoiceMaterial.Notes)
+
+			enteredUnit, enteredAmount := enteredUnitForReport(invoiceMaterial.MaterialUnit, invoiceMaterial.Amount, invoiceMaterial.EnteredUnitName, invoiceMaterial.UnitAmount)
+			f.SetCellStr(sheetName, "L"+fmt.Sprint(rowCount), enteredUnit)
+			f.SetCellFloat(sheetName, "M"+fmt.Sprint(rowCount), enteredAmount, 3, 64)
 			rowCount++
 		}
 	}
@@ -929,7 +999,10 @@ func (service *invoiceOutputService) GenerateExcelFile(data dto.InvoiceOutput) e
 		f.SetCellStr(sheetName, "C"+fmt.Sprint(startingRow+index), material.Name)
 		f.SetCellStr(sheetName, "D"+fmt.Sprint(startingRow+index), material.Unit)
 		f.SetCellFloat(sheetName, "E"+fmt.Sprint(startingRow+index), oneEntry.Amount, 3, 64)
-		f.SetCellStr(sheetName, "F"+fmt.Sprint(startingRow+index), oneEntry.Notes)
+		f.SetCellStr(sheetName, "F"+fmt.Sprint(startingRow+index), notesWithEnteredUnit(
+			oneEntry.Notes,
+			enteredUnitNote(service.materialUnitRepo, oneEntry.MaterialUnitID, oneEntry.UnitAmount),
+		))
 	}
 
 	warehouseManager, err := service.workerRepo.GetByID(data.Details.WarehouseManagerWorkerID)
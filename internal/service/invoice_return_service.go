@@ -28,6 +28,8 @@ type invoiceReturnService struct {
 	districtRepo          repository.IDistrictRepository
 	objectSupervisorsRepo repository.IObjectSupervisorsRepository
 	invoiceCountRepo      repository.IInvoiceCountRepository
+	materialUnitRepo      repository.IMaterialUnitRepository
+	materialLotRepo       repository.IMaterialLotRepository
 }
 
 func InitInvoiceReturnService(
@@ -45,6 +47,8 @@ func InitInvoiceReturnService(
 	districtRepo repository.IDistrictRepository,
 	objectSupervisorsRepo repository.IObjectSupervisorsRepository,
 	invoiceCountRepo repository.IInvoiceCountRepository,
+	materialUnitRepo repository.IMaterialUnitRepository,
+	materialLotRepo repository.IMaterialLotRepository,
 ) IInvoiceReturnService {
 	return &invoiceReturnService{
 		invoiceReturnRepo:     invoiceReturnRepo,
@@ -61,6 +65,8 @@ func InitInvoiceReturnService(
 		districtRepo:          districtRepo,
 		objectSupervisorsRepo: objectSupervisorsRepo,
 		invoiceCountRepo:      invoiceCountRepo,
+		materialUnitRepo:      materialUnitRepo,
+		materialLotRepo:       materialLotRepo,
 	}
 }
 
@@ -162,39 +168,61 @@ func (service *invoiceReturnService) Create(data dto.InvoiceReturn) (model.Invoi
 
 	data.Details.DeliveryCode = utils.UniqueCodeGeneration("В", int64(count+1), data.Details.ProjectID)
 
+	if err := normalizeUnits(service.materialUnitRepo, data.Items, invoiceReturnItemUnit); err != nil {
+		return model.InvoiceReturn{}, err
+	}
+
 	invoiceMaterialsForCreate := []model.InvoiceMaterials{}
 	serialNumberMovements := []model.SerialNumberMovement{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialCostsReverseSorted, err := service.materialLocationRepo.GetMaterialAmountReverseSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, data.Details.ReturnerType, data.Details.ReturnerID)
+			unitFactor := enteredUnitFactor(invoiceMaterial.MaterialUnitID, invoiceMaterial.Amount, invoiceMaterial.UnitAmount)
+			lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+				service.materialRepo,
+				service.materialLotRepo,
+				invoiceMaterial.MaterialID,
+				data.Details.ReturnerType,
+				data.Details.ReturnerID,
+				model.InvoiceMaterials{
+					ProjectID:      data.Details.ProjectID,
+					InvoiceType:    "return",
+					IsDefected:     invoiceMaterial.IsDefected,
+					Amount:         invoiceMaterial.Amount,
+					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
+				},
+				unitFactor,
+			)
 			if err != nil {
 				return model.InvoiceReturn{}, err
 			}
 
-			fmt.Println(materialCostsReverseSorted)
+			if hasLot {
+				invoiceMaterialsForCreate = append(invoiceMaterialsForCreate, lotInvoiceMaterials...)
+				materialLotMovements = append(materialLotMovements, lotMovements...)
+				continue
+			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, data.Details.ReturnerType, data.Details.ReturnerID, invoiceMaterial.Amount)
+			if err != nil {
+				return model.InvoiceReturn{}, err
+			}
+
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
-					MaterialCostID: materialCostsReverseSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					ProjectID:      data.Details.ProjectID,
 					InvoiceID:      0,
 					InvoiceType:    "return",
 					IsDefected:     invoiceMaterial.IsDefected,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
 				}
 
-				if materialCostsReverseSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialCostsReverseSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialCostsReverseSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
+				invoiceMaterialCreate.UnitAmount = invoiceMaterialCreate.Amount / unitFactor
 				invoiceMaterialsForCreate = append(invoiceMaterialsForCreate, invoiceMaterialCreate)
-				index++
 			}
 		}
 
@@ -211,6 +239,7 @@ func (service *invoiceReturnService) Create(data dto.InvoiceReturn) (model.Invoi
 		Invoice:               data.Details,
 		InvoiceMaterials:      invoiceMaterialsForCreate,
 		SerialNumberMovements: serialNumberMovements,
+		MaterialLotMovements:  materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceReturn{}, err
@@ -221,37 +250,61 @@ func (service *invoiceReturnService) Create(data dto.InvoiceReturn) (model.Invoi
 
 func (service *invoiceReturnService) Update(data dto.InvoiceReturn) (model.InvoiceReturn, error) {
 
+	if err := normalizeUnits(service.materialUnitRepo, data.Items, invoiceReturnItemUnit); err != nil {
+		return model.InvoiceReturn{}, err
+	}
+
 	invoiceMaterialsForCreate := []model.InvoiceMaterials{}
 	serialNumberMovements := []model.SerialNumberMovement{}
+	materialLotMovements := []model.MaterialLotMovement{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialCostsReverseSorted, err := service.materialLocationRepo.GetMaterialAmountReverseSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, data.Details.ReturnerType, data.Details.ReturnerID)
+			unitFactor := enteredUnitFactor(invoiceMaterial.MaterialUnitID, invoiceMaterial.Amount, invoiceMaterial.UnitAmount)
+			lotInvoiceMaterials, lotMovements, hasLot, err := pickMaterialLots(
+				service.materialRepo,
+				service.materialLotRepo,
+				invoiceMaterial.MaterialID,
+				data.Details.ReturnerType,
+				data.Details.ReturnerID,
+				model.InvoiceMaterials{
+					ProjectID:      data.Details.ProjectID,
+					InvoiceType:    "return",
+					IsDefected:     invoiceMaterial.IsDefected,
+					Amount:         invoiceMaterial.Amount,
+					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
+				},
+				unitFactor,
+			)
 			if err != nil {
 				return model.InvoiceReturn{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			if hasLot {
+				invoiceMaterialsForCreate = append(invoiceMaterialsForCreate, lotInvoiceMaterials...)
+				materialLotMovements = append(materialLotMovements, lotMovements...)
+				continue
+			}
+
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, data.Details.ReturnerType, data.Details.ReturnerID, invoiceMaterial.Amount)
+			if err != nil {
+				return model.InvoiceReturn{}, err
+			}
+
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
-					MaterialCostID: materialCostsReverseSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					ProjectID:      data.Details.ProjectID,
 					InvoiceID:      0,
 					InvoiceType:    "return",
 					IsDefected:     invoiceMaterial.IsDefected,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
+					MaterialUnitID: invoiceMaterial.MaterialUnitID,
 				}
 
-				if materialCostsReverseSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialCostsReverseSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialCostsReverseSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
+				invoiceMaterialCreate.UnitAmount = invoiceMaterialCreate.Amount / unitFactor
 				invoiceMaterialsForCreate = append(invoiceMaterialsForCreate, invoiceMaterialCreate)
-				index++
 			}
 		}
 
@@ -274,6 +327,7 @@ func (service *invoiceReturnService) Update(data dto.InvoiceReturn) (model.Invoi
 		Invoice:               data.Details,
 		InvoiceMaterials:      invoiceMaterialsForCreate,
 		SerialNumberMovements: serialNumberMovements,
+		MaterialLotMovements:  materialLotMovements,
 	})
 	if err != nil {
 		return model.InvoiceReturn{}, err
@@ -503,6 +557,8 @@ func (service *invoiceReturnService) Report(filter dto.InvoiceReturnReportFilter
 		Price             float64
 		IsDefected        string
 		Notes             string
+		EnteredUnit       string
+		EnteredAmount     float64
 	}
 
 	reportData := []InvoiceReturnReportData{}
@@ -559,6 +615,17 @@ func (service *invoiceReturnService) Report(filter dto.InvoiceReturnReportFilter
 			}
 			oneEntry.Notes = invoiceMaterial.Notes
 
+			enteredUnitName := ""
+			if invoiceMaterial.MaterialUnitID != 0 {
+				materialUnit, err := service.materialUnitRepo.GetViewByID(invoiceMaterial.MaterialUnitID)
+				if err != nil {
+					return "", err
+				}
+
+				enteredUnitName = materialUnit.UnitName
+			}
+			oneEntry.EnteredUnit, oneEntry.EnteredAmount = enteredUnitForReport(material.Unit, invoiceMaterial.Amount, enteredUnitName, invoiceMaterial.UnitAmount)
+
 			reportData = append(reportData, oneEntry)
 		}
 	}
@@ -578,6 +645,8 @@ func (service *invoiceReturnService) Report(filter dto.InvoiceReturnReportFilter
 		f.SetCellValue(sheetName, "H"+fmt.Sprint(rowCount), oneEntry.Price)
 		f.SetCellValue(sheetName, "I"+fmt.Sprint(rowCount), oneEntry.IsDefected)
 		f.SetCellValue(sheetName, "J"+fmt.Sprint(rowCount), oneEntry.Notes)
+		f.SetCellValue(sheetName, "K"+fmt.Sprint(rowCount), oneEntry.EnteredUnit)
+		f.SetCellValue(sheetName, "L"+fmt.Sprint(rowCount), oneEntry.EnteredAmount)
 		rowCount++
 	}
 
@@ -722,6 +791,16 @@ func (service *invoiceReturnService) GetMaterialAmountByMaterialID(projectID, ma
 	return service.materialLocationRepo.GetTotalAmountInLocation(projectID, materialID, locationID, locationType)
 }
 
+func invoiceReturnItemUnit(item *dto.InvoiceReturnItem) enteredUnitLine {
+	return enteredUnitLine{
+		materialID:     item.MaterialID,
+		materialUnitID: &item.MaterialUnitID,
+		amount:         &item.Amount,
+		unitAmount:     &item.UnitAmount,
+		serialNumbers:  item.SerialNumbers,
+	}
+}
+
 func (service *invoiceReturnService) GenerateExcel(data dto.InvoiceReturn) error {
 
 	templateFilePath := filepath.Join("./pkg/excels/templates/return.xlsx")
@@ -833,7 +912,7 @@ func (service *invoiceReturnService) GenerateExcel(data dto.InvoiceReturn) error
 			return err
 		}
 
-		f.SetCellStr(sheetName, "D2", utils.ObjectTypeConverter(object.Type))
+		f.SetCellStr(sheetName, "D2", objectTypeName(object.Type))
 		f.SetCellStr(sheetName, "C3", object.Name)
 
 		teamData, err := service.teamRepo.GetTeamNumberAndTeamLeadersByID(data.Details.ProjectID, data.Details.AcceptorID)
@@ -872,7 +951,10 @@ func (service *invoiceReturnService) GenerateExcel(data dto.InvoiceReturn) error
 			materialDefect = "Да"
 		}
 		f.SetCellValue(sheetName, "F"+fmt.Sprint(startingRow+index), materialDefect)
-		f.SetCellValue(sheetName, "G"+fmt.Sprint(startingRow+index), oneEntry.Notes)
+		f.SetCellValue(sheetName, "G"+fmt.Sprint(startingRow+index), notesWithEnteredUnit(
+			oneEntry.Notes,
+			enteredUnitNote(service.materialUnitRepo, oneEntry.MaterialUnitID, oneEntry.UnitAmount),
+		))
 	}
 
 	savePath := filepath.Join("./pkg/excels/return/", data.Details.DeliveryCode+".xlsx")
@@ -23,6 +23,7 @@ type invoiceWriteOffService struct {
 	materialRepo         repository.IMaterialRepository
 	materialCostRepo     repository.IMaterialCostRepository
 	invoiceCountRepo     repository.IInvoiceCountRepository
+	projectRepo          repository.IProjectRepository
 }
 
 func InitInvoiceWriteOffService(
@@ -35,6 +36,7 @@ func InitInvoiceWriteOffService(
 	materialRepo repository.IMaterialRepository,
 	materialCostRepo repository.IMaterialCostRepository,
 	invoiceCountRepo repository.IInvoiceCountRepository,
+	projectRepo repository.IProjectRepository,
 ) IInvoiceWriteOffService {
 	return &invoiceWriteOffService{
 		invoiceWriteOffRepo:  invoiceWriteOffRepo,
@@ -46,6 +48,7 @@ func InitInvoiceWriteOffService(
 		materialRepo:         materialRepo,
 		materialCostRepo:     materialCostRepo,
 		invoiceCountRepo:     invoiceCountRepo,
+		projectRepo:          projectRepo,
 	}
 }
 
@@ -152,34 +155,24 @@ func (service *invoiceWriteOffService) Create(data dto.InvoiceWriteOff) (model.I
 	}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, writeOffLocation, data.Details.WriteOffLocationID)
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, writeOffLocation, data.Details.WriteOffLocationID, invoiceMaterial.Amount)
 			if err != nil {
 				return model.InvoiceWriteOff{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "writeoff",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 
 		}
@@ -220,34 +213,24 @@ func (service *invoiceWriteOffService) Update(data dto.InvoiceWriteOff) (model.I
 	invoiceMaterialForCreate := []model.InvoiceMaterials{}
 	for _, invoiceMaterial := range data.Items {
 		if len(invoiceMaterial.SerialNumbers) == 0 {
-			materialInfoSorted, err := service.materialLocationRepo.GetMaterialAmountSortedByCostM19InLocation(data.Details.ProjectID, invoiceMaterial.MaterialID, writeOffLocation, data.Details.WriteOffLocationID)
+			allocations, err := allocateMaterialCosts(service.materialLocationRepo, service.projectRepo, data.Details.ProjectID, invoiceMaterial.MaterialID, writeOffLocation, data.Details.WriteOffLocationID, invoiceMaterial.Amount)
 			if err != nil {
 				return model.InvoiceWriteOff{}, err
 			}
 
-			index := 0
-			for invoiceMaterial.Amount > 0 {
+			for _, allocation := range allocations {
 				invoiceMaterialCreate := model.InvoiceMaterials{
 					ProjectID:      data.Details.ProjectID,
 					ID:             0,
-					MaterialCostID: materialInfoSorted[index].MaterialCostID,
+					MaterialCostID: allocation.MaterialCostID,
 					InvoiceID:      0,
 					InvoiceType:    "writeoff",
 					IsDefected:     false,
-					Amount:         0,
+					Amount:         allocation.Amount,
 					Notes:          invoiceMaterial.Notes,
 				}
 
-				if materialInfoSorted[index].MaterialAmount <= invoiceMaterial.Amount {
-					invoiceMaterialCreate.Amount = materialInfoSorted[index].MaterialAmount
-					invoiceMaterial.Amount -= materialInfoSorted[index].MaterialAmount
-				} else {
-					invoiceMaterialCreate.Amount = invoiceMaterial.Amount
-					invoiceMaterial.Amount = 0
-				}
-
 				invoiceMaterialForCreate = append(invoiceMaterialForCreate, invoiceMaterialCreate)
-				index++
 			}
 
 		}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var kpiIntervals = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+}
+
+type kpiService struct {
+	kpiRepo    repository.IKPIRepository
+	teamRepo   repository.ITeamRepository
+	workerRepo repository.IWorkerRepository
+}
+
+func NewKPIService(
+	kpiRepo repository.IKPIRepository,
+	teamRepo repository.ITeamRepository,
+	workerRepo repository.IWorkerRepository,
+) IKPIService {
+	return &kpiService{
+		kpiRepo:    kpiRepo,
+		teamRepo:   teamRepo,
+		workerRepo: workerRepo,
+	}
+}
+
+type IKPIService interface {
+	GetReport(filter dto.KPIFilter) (dto.KPIReport, error)
+}
+
+func (service *kpiService) GetReport(filter dto.KPIFilter) (dto.KPIReport, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return dto.KPIReport{}, fmt.Errorf("Период не указан")
+	}
+
+	if filter.DateFrom.After(filter.DateTo) {
+		return dto.KPIReport{}, fmt.Errorf("Начало периода позже его окончания")
+	}
+
+	if filter.Interval == "" {
+		filter.Interval = "month"
+	}
+
+	if !kpiIntervals[filter.Interval] {
+		return dto.KPIReport{}, fmt.Errorf("Неизвестный интервал: %s, допустимы day, week и month", filter.Interval)
+	}
+
+	entityNames, err := service.entityNames(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	periods := kpiPeriods(filter.DateFrom, filter.DateTo, filter.Interval)
+	periodIndexes := map[string]int{}
+	for index, period := range periods {
+		periodIndexes[period.Format("2006-01-02")] = index
+	}
+
+	seriesNames := []struct {
+		code string
+		name string
+	}{
+		{"operationsAmount", "Выполнено работ"},
+		{"operationsValue", "Стоимость выполненных работ"},
+		{"objectsFinished", "Смонтировано объектов"},
+		{"materialsToNorm", "Расход материалов к норме, %"},
+		{"correctionRate", "Накладные с корректировкой, %"},
+		{"lossValue", "Списано потерь"},
+		{"daysToInstallation", "Дней от выдачи до монтажа"},
+	}
+
+	entities := map[uint]*dto.KPIEntity{}
+	series := func(entityID uint, code string) []dto.KPIPoint {
+		entity, exists := entities[entityID]
+		if !exists {
+			entity = &dto.KPIEntity{
+				ID:     entityID,
+				Name:   entityNames[entityID],
+				Series: []dto.KPISeries{},
+			}
+			for _, seriesName := range seriesNames {
+				points := make([]dto.KPIPoint, len(periods))
+				for index, period := range periods {
+					points[index] = dto.KPIPoint{Period: period}
+				}
+
+				entity.Series = append(entity.Series, dto.KPISeries{
+					Code:   seriesName.code,
+					Name:   seriesName.name,
+					Points: points,
+				})
+			}
+			entities[entityID] = entity
+		}
+
+		for _, entitySeries := range entity.Series {
+			if entitySeries.Code == code {
+				return entitySeries.Points
+			}
+		}
+
+		return nil
+	}
+
+	setValue := func(entityID uint, code string, period time.Time, value float64) {
+		index, exists := periodIndexes[kpiPeriodStart(period, filter.Interval).Format("2006-01-02")]
+		if !exists || entityID == 0 {
+			return
+		}
+
+		series(entityID, code)[index].Value = math.Round(value*100) / 100
+	}
+
+	operations, err := service.kpiRepo.GetOperations(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range operations {
+		setValue(entry.EntityID, "operationsAmount", entry.Period, entry.Amount)
+		setValue(entry.EntityID, "operationsValue", entry.Period, entry.Value)
+	}
+
+	objectsFinished, err := service.kpiRepo.GetObjectsFinished(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range objectsFinished {
+		setValue(entry.EntityID, "objectsFinished", entry.Period, entry.Value)
+	}
+
+	materialsToNorm, err := service.materialsToNorm(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range materialsToNorm {
+		setValue(entry.EntityID, "materialsToNorm", entry.Period, entry.Value)
+	}
+
+	corrections, err := service.kpiRepo.GetCorrections(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range corrections {
+		if entry.Total != 0 {
+			setValue(entry.EntityID, "correctionRate", entry.Period, entry.Corrected/entry.Total*100)
+		}
+	}
+
+	losses, err := service.kpiRepo.GetLosses(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range losses {
+		setValue(entry.EntityID, "lossValue", entry.Period, entry.Value)
+	}
+
+	daysToInstallation, err := service.kpiRepo.GetDaysToInstallation(filter)
+	if err != nil {
+		return dto.KPIReport{}, err
+	}
+
+	for _, entry := range daysToInstallation {
+		setValue(entry.EntityID, "daysToInstallation", entry.Period, entry.Value)
+	}
+
+	if filter.EntityID != 0 {
+		series(filter.EntityID, seriesNames[0].code)
+	}
+
+	result := dto.KPIReport{
+		GroupBy:  filter.GroupBy,
+		Interval: filter.Interval,
+		DateFrom: filter.DateFrom,
+		DateTo:   filter.DateTo,
+		Periods:  periods,
+		Entities: []dto.KPIEntity{},
+	}
+
+	for _, entity := range entities {
+		result.Entities = append(result.Entities, *entity)
+	}
+
+	sort.Slice(result.Entities, func(i, j int) bool {
+		if result.Entities[i].Name != result.Entities[j].Name {
+			return result.Entities[i].Name < result.Entities[j].Name
+		}
+
+		return result.Entities[i].ID < result.Entities[j].ID
+	})
+
+	return result, nil
+}
+
+// Actual consumption of every material to its norm averaged over the materials
+// that have a norm, materials are measured in different units so they are not summed
+func (service *kpiService) materialsToNorm(filter dto.KPIFilter) ([]dto.KPIQueryResult, error) {
+	normative, err := service.kpiRepo.GetNormativeMaterials(filter)
+	if err != nil {
+		return []dto.KPIQueryResult{}, err
+	}
+
+	actual, err := service.kpiRepo.GetActualMaterials(filter)
+	if err != nil {
+		return []dto.KPIQueryResult{}, err
+	}
+
+	type materialKey struct {
+		entityID   uint
+		period     string
+		materialID uint
+	}
+
+	actualAmounts := map[materialKey]float64{}
+	for _, entry := range actual {
+		actualAmounts[materialKey{entry.EntityID, entry.Period.Format("2006-01-02"), entry.MaterialID}] += entry.Amount
+	}
+
+	type periodKey struct {
+		entityID uint
+		period   string
+	}
+
+	ratios := map[periodKey][]float64{}
+	periods := map[periodKey]time.Time{}
+	order := []periodKey{}
+	for _, entry := range normative {
+		if entry.Amount <= 0 {
+			continue
+		}
+
+		key := periodKey{entry.EntityID, entry.Period.Format("2006-01-02")}
+		if _, exists := ratios[key]; !exists {
+			order = append(order, key)
+			periods[key] = entry.Period
+		}
+
+		actualAmount := actualAmounts[materialKey{entry.EntityID, key.period, entry.MaterialID}]
+		ratios[key] = append(ratios[key], actualAmount/entry.Amount*100)
+	}
+
+	result := []dto.KPIQueryResult{}
+	for _, key := range order {
+		sum := 0.0
+		for _, ratio := range ratios[key] {
+			sum += ratio
+		}
+
+		result = append(result, dto.KPIQueryResult{
+			EntityID: key.entityID,
+			Period:   periods[key],
+			Value:    sum / float64(len(ratios[key])),
+		})
+	}
+
+	return result, nil
+}
+
+// Team numbers or supervisor names by their IDs
+func (service *kpiService) entityNames(filter dto.KPIFilter) (map[uint]string, error) {
+	result := map[uint]string{}
+	switch filter.GroupBy {
+	case "team":
+		teams, err := service.teamRepo.GetAll(filter.ProjectID)
+		if err != nil {
+			return result, err
+		}
+
+		for _, team := range teams {
+			result[team.ID] = team.Number
+		}
+	case "supervisor":
+		workers, err := service.workerRepo.GetAll(filter.ProjectID)
+		if err != nil {
+			return result, err
+		}
+
+		for _, worker := range workers {
+			result[worker.ID] = worker.Name
+		}
+	default:
+		return result, fmt.Errorf("Неизвестная группировка: %s, допустимы team и supervisor", filter.GroupBy)
+	}
+
+	if filter.EntityID != 0 && result[filter.EntityID] == "" {
+		return result, fmt.Errorf("Запись с ID %d не найдена", filter.EntityID)
+	}
+
+	return result, nil
+}
+
+// Starts of the chart periods covering the date range
+func kpiPeriods(dateFrom, dateTo time.Time, interval string) []time.Time {
+	result := []time.Time{}
+	for period := kpiPeriodStart(dateFrom, interval); !period.After(dateTo); {
+		result = append(result, period)
+		switch interval {
+		case "month":
+			period = period.AddDate(0, 1, 0)
+		case "week":
+			period = period.AddDate(0, 0, 7)
+		default:
+			period = period.AddDate(0, 0, 1)
+		}
+	}
+
+	return result
+}
+
+// Same truncation as date_trunc of Postgres, weeks start on Monday
+func kpiPeriodStart(date time.Time, interval string) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+
+	return day
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type materialConsumptionService struct {
+	materialConsumptionRepo repository.IMaterialConsumptionRepository
+	projectRepo             repository.IProjectRepository
+	objectRepo              repository.IObjectRepository
+	districtRepo            repository.IDistrictRepository
+	objectTypeRepo          repository.IObjectTypeRepository
+}
+
+func NewMaterialConsumptionService(
+	materialConsumptionRepo repository.IMaterialConsumptionRepository,
+	projectRepo repository.IProjectRepository,
+	objectRepo repository.IObjectRepository,
+	districtRepo repository.IDistrictRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) IMaterialConsumptionService {
+	return &materialConsumptionService{
+		materialConsumptionRepo: materialConsumptionRepo,
+		projectRepo:             projectRepo,
+		objectRepo:              objectRepo,
+		districtRepo:            districtRepo,
+		objectTypeRepo:          objectTypeRepo,
+	}
+}
+
+type IMaterialConsumptionService interface {
+	GetReport(filter dto.MaterialConsumptionFilter) (dto.MaterialConsumptionReport, error)
+	Export(filter dto.MaterialConsumptionFilter) (string, error)
+}
+
+func (service *materialConsumptionService) GetReport(filter dto.MaterialConsumptionFilter) (dto.MaterialConsumptionReport, error) {
+	scope, err := service.scope(filter)
+	if err != nil {
+		return dto.MaterialConsumptionReport{}, err
+	}
+
+	project, err := service.projectRepo.GetByID(filter.ProjectID)
+	if err != nil {
+		return dto.MaterialConsumptionReport{}, err
+	}
+
+	normative, err := service.materialConsumptionRepo.GetNormative(filter)
+	if err != nil {
+		return dto.MaterialConsumptionReport{}, err
+	}
+
+	actual, err := service.materialConsumptionRepo.GetActual(filter)
+	if err != nil {
+		return dto.MaterialConsumptionReport{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return dto.MaterialConsumptionReport{}, err
+	}
+
+	objects := map[uint]*dto.MaterialConsumptionObject{}
+	rows := map[[2]uint]*dto.MaterialConsumptionRow{}
+	totals := map[uint]*dto.MaterialConsumptionRow{}
+	rowOf := func(amount dto.MaterialConsumptionQueryResult) (*dto.MaterialConsumptionRow, *dto.MaterialConsumptionRow) {
+		if _, exists := objects[amount.ObjectID]; !exists {
+			objects[amount.ObjectID] = &dto.MaterialConsumptionObject{
+				ObjectID:   amount.ObjectID,
+				ObjectName: amount.ObjectName,
+				ObjectType: typeNames[amount.ObjectType],
+			}
+		}
+
+		key := [2]uint{amount.ObjectID, amount.MaterialID}
+		if _, exists := rows[key]; !exists {
+			rows[key] = &dto.MaterialConsumptionRow{
+				MaterialID: amount.MaterialID,
+				Code:       amount.Code,
+				Name:       amount.Name,
+				Unit:       amount.Unit,
+			}
+		}
+
+		if _, exists := totals[amount.MaterialID]; !exists {
+			totals[amount.MaterialID] = &dto.MaterialConsumptionRow{
+				MaterialID: amount.MaterialID,
+				Code:       amount.Code,
+				Name:       amount.Name,
+				Unit:       amount.Unit,
+			}
+		}
+
+		return rows[key], totals[amount.MaterialID]
+	}
+
+	for _, amount := range normative {
+		row, total := rowOf(amount)
+		row.NormativeAmount += amount.Amount
+		total.NormativeAmount += amount.Amount
+	}
+
+	for _, amount := range actual {
+		row, total := rowOf(amount)
+		row.ActualAmount += amount.Amount
+		total.ActualAmount += amount.Amount
+	}
+
+	for key, row := range rows {
+		object := objects[key[0]]
+		object.Rows = append(object.Rows, materialConsumptionRowDifference(*row))
+	}
+
+	result := dto.MaterialConsumptionReport{
+		ProjectName: project.Name,
+		Scope:       scope,
+		DateFrom:    filter.DateFrom,
+		DateTo:      filter.DateTo,
+		Objects:     []dto.MaterialConsumptionObject{},
+		Totals:      []dto.MaterialConsumptionRow{},
+	}
+
+	for _, object := range objects {
+		sortMaterialConsumptionRows(object.Rows)
+		result.Objects = append(result.Objects, *object)
+	}
+
+	sort.Slice(result.Objects, func(i, j int) bool {
+		if result.Objects[i].ObjectName == result.Objects[j].ObjectName {
+			return result.Objects[i].ObjectID < result.Objects[j].ObjectID
+		}
+
+		return result.Objects[i].ObjectName < result.Objects[j].ObjectName
+	})
+
+	for _, total := range totals {
+		result.Totals = append(result.Totals, materialConsumptionRowDifference(*total))
+	}
+	sortMaterialConsumptionRows(result.Totals)
+
+	return result, nil
+}
+
+func (service *materialConsumptionService) Export(filter dto.MaterialConsumptionFilter) (string, error) {
+	report, err := service.GetReport(filter)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "M-29.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	totalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	overconsumptionStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "М-29"
+	f.SetCellStr(sheetName, "C5", report.ProjectName)
+	f.SetCellStr(sheetName, "C6", report.Scope)
+	f.SetCellStr(sheetName, "C7", fmt.Sprintf("с %s по %s", report.DateFrom.Format("02.01.2006"), report.DateTo.Format("02.01.2006")))
+
+	rowCount := 10
+	writeRows := func(rows []dto.MaterialConsumptionRow) {
+		for index, row := range rows {
+			f.SetCellInt(sheetName, "A"+fmt.Sprint(rowCount), index+1)
+			f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), row.Code)
+			f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), row.Name)
+			f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), row.Unit)
+			f.SetCellFloat(sheetName, "E"+fmt.Sprint(rowCount), row.NormativeAmount, 3, 64)
+			f.SetCellFloat(sheetName, "F"+fmt.Sprint(rowCount), row.ActualAmount, 3, 64)
+			f.SetCellFloat(sheetName, "G"+fmt.Sprint(rowCount), row.Overconsumption, 3, 64)
+			f.SetCellFloat(sheetName, "H"+fmt.Sprint(rowCount), row.Underconsumption, 3, 64)
+			if row.Overconsumption > 0 {
+				f.SetCellStyle(sheetName, "G"+fmt.Sprint(rowCount), "G"+fmt.Sprint(rowCount), overconsumptionStyle)
+			}
+			rowCount++
+		}
+	}
+
+	for _, object := range report.Objects {
+		f.MergeCell(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount))
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), fmt.Sprintf("Объект: %s (%s)", object.ObjectName, object.ObjectType))
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount), totalStyle)
+		rowCount++
+		writeRows(object.Rows)
+	}
+
+	if len(report.Objects) > 1 {
+		f.MergeCell(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount))
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Итого по всем объектам")
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount), totalStyle)
+		rowCount++
+		writeRows(report.Totals)
+	}
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"М-29 %s - %s.xlsx",
+		report.DateFrom.Format("01-2006"),
+		currentTime.Format("02-01-2006"),
+	)
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// Text describing the object or the district of the report, one of them must be given
+func (service *materialConsumptionService) scope(filter dto.MaterialConsumptionFilter) (string, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return "", fmt.Errorf("Отчетный месяц не указан")
+	}
+
+	if filter.ObjectID != 0 {
+		object, err := service.objectRepo.GetByID(filter.ObjectID)
+		if err != nil {
+			return "", err
+		}
+
+		if object.ID == 0 || object.ProjectID != filter.ProjectID {
+			return "", fmt.Errorf("Объект с ID %d не найден", filter.ObjectID)
+		}
+
+		return "Объект " + object.Name, nil
+	}
+
+	if filter.DistrictID != 0 {
+		district, err := service.districtRepo.GetByID(filter.DistrictID)
+		if err != nil {
+			return "", err
+		}
+
+		if district.ID == 0 || district.ProjectID != filter.ProjectID {
+			return "", fmt.Errorf("Район с ID %d не найден", filter.DistrictID)
+		}
+
+		return "Район " + district.Name, nil
+	}
+
+	return "", fmt.Errorf("Укажите объект или район")
+}
+
+func materialConsumptionRowDifference(row dto.MaterialConsumptionRow) dto.MaterialConsumptionRow {
+	difference := row.ActualAmount - row.NormativeAmount
+	if difference > 0 {
+		row.Overconsumption = difference
+	} else {
+		row.Underconsumption = -difference
+	}
+
+	return row
+}
+
+func sortMaterialConsumptionRows(rows []dto.MaterialConsumptionRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Code < rows[j].Code
+	})
+}
@@ -208,7 +208,7 @@ func (service *materialLocationService) BalanceReport(projectID uint, data dto.R
 				}
 
 				locationInformation.LocationName = objectData[0].ObjectName
-				locationInformation.LocationType = utils.ObjectTypeConverter(objectData[0].ObjectType)
+				locationInformation.LocationType = objectTypeName(objectData[0].ObjectType)
 
 				for index, entry := range objectData {
 					if index == len(objectData)-1 {
@@ -406,7 +406,7 @@ func (service *materialLocationService) BalanceReportWriteOff(projectID uint, da
 					return "", fmt.Errorf("Ошибка базы: %v", err)
 				}
 				locationInformation.LocationName = objectData[0].ObjectName
-				locationInformation.LocationType = utils.ObjectTypeConverter(objectData[0].ObjectType)
+				locationInformation.LocationType = objectTypeName(objectData[0].ObjectType)
 
 				for index, entry := range objectData {
 					if index == len(objectData)-1 {
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type materialLotService struct {
+	materialLotRepo repository.IMaterialLotRepository
+}
+
+func NewMaterialLotService(materialLotRepo repository.IMaterialLotRepository) IMaterialLotService {
+	return &materialLotService{
+		materialLotRepo: materialLotRepo,
+	}
+}
+
+type IMaterialLotService interface {
+	GetExpiring(projectID uint, days int, locationType string, locationID uint) ([]dto.MaterialLotExpiringView, error)
+	ExpiringReport(projectID uint, days int, locationType string, locationID uint) (string, error)
+	GetByInvoice(invoiceID uint, invoiceType string) ([]dto.MaterialLotInInvoiceView, error)
+}
+
+func (service *materialLotService) GetExpiring(projectID uint, days int, locationType string, locationID uint) ([]dto.MaterialLotExpiringView, error) {
+	return service.materialLotRepo.GetExpiring(dto.MaterialLotExpiringFilter{
+		ProjectID:     projectID,
+		ExpiresBefore: time.Now().AddDate(0, 0, days),
+		LocationType:  locationType,
+		LocationID:    locationID,
+	})
+}
+
+func (service *materialLotService) ExpiringReport(projectID uint, days int, locationType string, locationID uint) (string, error) {
+	lots, err := service.GetExpiring(projectID, days, locationType, locationID)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "Expiring Lots Report.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", err
+	}
+	sheetName := "Sheet1"
+
+	currentTime := time.Now()
+	rowCount := 2
+	for _, lot := range lots {
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), locationTypeName(lot.LocationType))
+		f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), lot.LocationName)
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), lot.MaterialName)
+		f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), lot.MaterialUnit)
+		f.SetCellStr(sheetName, "E"+fmt.Sprint(rowCount), lot.LotCode)
+		if !lot.ManufactureDate.IsZero() {
+			f.SetCellStr(sheetName, "F"+fmt.Sprint(rowCount), lot.ManufactureDate.Format("02.01.2006"))
+		}
+		f.SetCellStr(sheetName, "G"+fmt.Sprint(rowCount), lot.ExpiryDate.Format("02.01.2006"))
+		f.SetCellInt(sheetName, "H"+fmt.Sprint(rowCount), int(math.Ceil(lot.ExpiryDate.Sub(currentTime).Hours()/24)))
+		f.SetCellFloat(sheetName, "I"+fmt.Sprint(rowCount), lot.Amount, 2, 64)
+		rowCount++
+	}
+
+	fileName := fmt.Sprintf(
+		"Отчет Партий с Истекающим Сроком - %s.xlsx",
+		currentTime.Format("02-01-2006"),
+	)
+
+	tempFilePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(tempFilePath); err != nil {
+		return "", err
+	}
+
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+	}
+
+	return fileName, nil
+}
+
+func (service *materialLotService) GetByInvoice(invoiceID uint, invoiceType string) ([]dto.MaterialLotInInvoiceView, error) {
+	return service.materialLotRepo.GetByInvoice(invoiceID, invoiceType)
+}
+
+func locationTypeName(locationType string) string {
+	switch locationType {
+	case "warehouse":
+		return "Склад"
+	case "team":
+		return "Бригада"
+	case "object":
+		return "Объект"
+	default:
+		return locationType
+	}
+}
+
+// Picks the amount of material from the lots in the location, lots that expire first are picked first
+func allocateMaterialLots(
+	materialLotRepo repository.IMaterialLotRepository,
+	projectID, materialID uint,
+	locationType string,
+	locationID uint,
+	amount float64,
+) ([]dto.MaterialLotAllocation, error) {
+	lots, err := materialLotRepo.GetAmountSortedByExpiryInLocation(projectID, materialID, locationType, locationID)
+	if err != nil {
+		return []dto.MaterialLotAllocation{}, err
+	}
+
+	result := []dto.MaterialLotAllocation{}
+	for _, lot := range lots {
+		if amount <= 0 {
+			break
+		}
+
+		allocation := dto.MaterialLotAllocation{
+			MaterialCostID: lot.MaterialCostID,
+			MaterialLotID:  lot.MaterialLotID,
+			Amount:         math.Min(lot.Amount, amount),
+		}
+
+		amount -= allocation.Amount
+		result = append(result, allocation)
+	}
+
+	if amount > 0 {
+		return []dto.MaterialLotAllocation{}, fmt.Errorf("Количество материала превышает остаток материала в партиях")
+	}
+
+	return result, nil
+}
+
+// Turns picked lots into invoice materials, one per material cost, and lot movements, one per lot.
+// Fields of the invoice materials other than cost and amount are taken from the line
+func materialLotAllocationsToInvoice(
+	allocations []dto.MaterialLotAllocation,
+	line model.InvoiceMaterials,
+	unitFactor float64,
+) ([]model.InvoiceMaterials, []model.MaterialLotMovement) {
+	invoiceMaterials := []model.InvoiceMaterials{}
+	materialLotMovements := []model.MaterialLotMovement{}
+	for _, allocation := range allocations {
+		materialLotMovements = append(materialLotMovements, model.MaterialLotMovement{
+			MaterialLotID: allocation.MaterialLotID,
+			ProjectID:     line.ProjectID,
+			InvoiceType:   line.InvoiceType,
+			Amount:        allocation.Amount,
+			IsDefected:    line.IsDefected,
+			Confirmation:  false,
+		})
+
+		index := -1
+		for invoiceMaterialIndex, invoiceMaterial := range invoiceMaterials {
+			if invoiceMaterial.MaterialCostID == allocation.MaterialCostID {
+				index = invoiceMaterialIndex
+				break
+			}
+		}
+
+		if index == -1 {
+			invoiceMaterial := line
+			invoiceMaterial.MaterialCostID = allocation.MaterialCostID
+			invoiceMaterial.Amount = 0
+			invoiceMaterials = append(invoiceMaterials, invoiceMaterial)
+			index = len(invoiceMaterials) - 1
+		}
+
+		invoiceMaterials[index].Amount += allocation.Amount
+		invoiceMaterials[index].UnitAmount = invoiceMaterials[index].Amount / unitFactor
+	}
+
+	return invoiceMaterials, materialLotMovements
+}
+
+// Materials with lot tracking are picked from the lots of the location instead of the material costs.
+// Amount of the line is the amount to be picked, false is returned for materials without lot tracking
+func pickMaterialLots(
+	materialRepo repository.IMaterialRepository,
+	materialLotRepo repository.IMaterialLotRepository,
+	materialID uint,
+	locationType string,
+	locationID uint,
+	line model.InvoiceMaterials,
+	unitFactor float64,
+) ([]model.InvoiceMaterials, []model.MaterialLotMovement, bool, error) {
+	material, err := materialRepo.GetByID(materialID)
+	if err != nil {
+		return []model.InvoiceMaterials{}, []model.MaterialLotMovement{}, false, err
+	}
+
+	if !material.HasLot {
+		return []model.InvoiceMaterials{}, []model.MaterialLotMovement{}, false, nil
+	}
+
+	allocations, err := allocateMaterialLots(materialLotRepo, line.ProjectID, materialID, locationType, locationID, line.Amount)
+	if err != nil {
+		return []model.InvoiceMaterials{}, []model.MaterialLotMovement{}, true, fmt.Errorf("Материал <<%v>>: %v", material.Name, err)
+	}
+
+	invoiceMaterials, materialLotMovements := materialLotAllocationsToInvoice(allocations, line, unitFactor)
+	return invoiceMaterials, materialLotMovements, true, nil
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type networkTopologyService struct {
+	networkTopologyRepo repository.INetworkTopologyRepository
+	objectRepo          repository.IObjectRepository
+	objectTypeRepo      repository.IObjectTypeRepository
+}
+
+func NewNetworkTopologyService(
+	networkTopologyRepo repository.INetworkTopologyRepository,
+	objectRepo repository.IObjectRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) INetworkTopologyService {
+	return &networkTopologyService{
+		networkTopologyRepo: networkTopologyRepo,
+		objectRepo:          objectRepo,
+		objectTypeRepo:      objectTypeRepo,
+	}
+}
+
+type INetworkTopologyService interface {
+	GetGraph(projectID uint) (dto.NetworkTopology, error)
+	GetUpstream(projectID, objectID uint) (dto.NetworkTreeNode, error)
+	GetDownstream(projectID, objectID uint) (dto.NetworkTreeNode, error)
+	CreateConnection(projectID uint, data dto.NetworkConnectionCreate) (model.NetworkConnection, error)
+	DeleteConnection(projectID, id uint) error
+	ResolveNourashes(projectID uint) ([]dto.NourashesResolveResult, error)
+	ExportGraphML(projectID uint) (string, error)
+}
+
+type networkGraph struct {
+	nodes      map[uint]dto.NetworkNode
+	nodeOrder  []uint
+	edges      []dto.NetworkEdge
+	downstream map[uint][]uint
+	upstream   map[uint][]uint
+
+	// Network levels of the object types, see objectTypeDefinition
+	levels map[string]uint
+}
+
+func (service *networkTopologyService) loadGraph(projectID uint) (networkGraph, error) {
+	nodes, err := service.networkTopologyRepo.GetNodes(projectID)
+	if err != nil {
+		return networkGraph{}, err
+	}
+
+	edges, err := service.networkTopologyRepo.GetEdges(projectID)
+	if err != nil {
+		return networkGraph{}, err
+	}
+
+	levels, err := objectNetworkLevels(service.objectTypeRepo)
+	if err != nil {
+		return networkGraph{}, err
+	}
+
+	graph := networkGraph{
+		nodes:      map[uint]dto.NetworkNode{},
+		nodeOrder:  []uint{},
+		edges:      []dto.NetworkEdge{},
+		downstream: map[uint][]uint{},
+		upstream:   map[uint][]uint{},
+		levels:     levels,
+	}
+	for _, node := range nodes {
+		graph.nodes[node.ObjectID] = node
+		graph.nodeOrder = append(graph.nodeOrder, node.ObjectID)
+	}
+
+	for _, edge := range edges {
+		_, sourceExists := graph.nodes[edge.SourceObjectID]
+		_, targetExists := graph.nodes[edge.TargetObjectID]
+		if !sourceExists || !targetExists {
+			continue
+		}
+
+		graph.edges = append(graph.edges, edge)
+		graph.downstream[edge.SourceObjectID] = append(graph.downstream[edge.SourceObjectID], edge.TargetObjectID)
+		graph.upstream[edge.TargetObjectID] = append(graph.upstream[edge.TargetObjectID], edge.SourceObjectID)
+	}
+
+	return graph, nil
+}
+
+func (service *networkTopologyService) GetGraph(projectID uint) (dto.NetworkTopology, error) {
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return dto.NetworkTopology{}, err
+	}
+
+	result := dto.NetworkTopology{
+		Nodes:   []dto.NetworkNode{},
+		Edges:   graph.edges,
+		Cycles:  networkCycles(graph),
+		Orphans: []dto.NetworkNode{},
+	}
+
+	for _, objectID := range graph.nodeOrder {
+		node := graph.nodes[objectID]
+		result.Nodes = append(result.Nodes, node)
+
+		// Objects of the first level (substations) are the sources of the network and are not fed by anything
+		level, knownType := graph.levels[node.Type]
+		if knownType && level > 1 && len(graph.upstream[objectID]) == 0 {
+			result.Orphans = append(result.Orphans, node)
+		}
+	}
+
+	return result, nil
+}
+
+func (service *networkTopologyService) GetUpstream(projectID, objectID uint) (dto.NetworkTreeNode, error) {
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return dto.NetworkTreeNode{}, err
+	}
+
+	if _, exists := graph.nodes[objectID]; !exists {
+		return dto.NetworkTreeNode{}, fmt.Errorf("Объект с ID %d не найден", objectID)
+	}
+
+	return networkTree(graph, graph.upstream, objectID, map[uint]bool{}), nil
+}
+
+func (service *networkTopologyService) GetDownstream(projectID, objectID uint) (dto.NetworkTreeNode, error) {
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return dto.NetworkTreeNode{}, err
+	}
+
+	if _, exists := graph.nodes[objectID]; !exists {
+		return dto.NetworkTreeNode{}, fmt.Errorf("Объект с ID %d не найден", objectID)
+	}
+
+	return networkTree(graph, graph.downstream, objectID, map[uint]bool{}), nil
+}
+
+func (service *networkTopologyService) CreateConnection(projectID uint, data dto.NetworkConnectionCreate) (model.NetworkConnection, error) {
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return model.NetworkConnection{}, err
+	}
+
+	if err := validateNetworkConnection(graph, data.SourceObjectID, data.TargetObjectID); err != nil {
+		return model.NetworkConnection{}, err
+	}
+
+	return service.networkTopologyRepo.CreateConnection(model.NetworkConnection{
+		ProjectID:      projectID,
+		SourceObjectID: data.SourceObjectID,
+		TargetObjectID: data.TargetObjectID,
+	})
+}
+
+func (service *networkTopologyService) DeleteConnection(projectID, id uint) error {
+	connection, err := service.networkTopologyRepo.GetConnectionByID(id)
+	if err != nil {
+		return err
+	}
+
+	if connection.ID == 0 || connection.ProjectID != projectID {
+		return fmt.Errorf("Связь с ID %d не найдена", id)
+	}
+
+	return service.networkTopologyRepo.DeleteConnection(id)
+}
+
+// Converts the free-text Nourashes of KL04KV lines into connections with the objects
+// of the project that have the same names. Lines that already have connections are skipped
+func (service *networkTopologyService) ResolveNourashes(projectID uint) ([]dto.NourashesResolveResult, error) {
+	lines, err := service.networkTopologyRepo.GetKL04KVWithoutConnections(projectID)
+	if err != nil {
+		return []dto.NourashesResolveResult{}, err
+	}
+
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return []dto.NourashesResolveResult{}, err
+	}
+
+	objectIDsByName := map[string][]uint{}
+	for _, objectID := range graph.nodeOrder {
+		name := strings.ToLower(strings.TrimSpace(graph.nodes[objectID].Name))
+		objectIDsByName[name] = append(objectIDsByName[name], objectID)
+	}
+
+	result := []dto.NourashesResolveResult{}
+	for _, line := range lines {
+		lineResult := dto.NourashesResolveResult{
+			ObjectID:          line.ObjectID,
+			Name:              line.Name,
+			Nourashes:         line.Nourashes,
+			ResolvedObjectIDs: []uint{},
+			Unresolved:        []string{},
+		}
+
+		for _, name := range splitNourashes(line.Nourashes) {
+			objectIDs := objectIDsByName[strings.ToLower(name)]
+			if len(objectIDs) != 1 || validateNetworkConnection(graph, line.ObjectID, objectIDs[0]) != nil {
+				lineResult.Unresolved = append(lineResult.Unresolved, name)
+				continue
+			}
+
+			lineResult.ResolvedObjectIDs = append(lineResult.ResolvedObjectIDs, objectIDs[0])
+			graph.downstream[line.ObjectID] = append(graph.downstream[line.ObjectID], objectIDs[0])
+			graph.upstream[objectIDs[0]] = append(graph.upstream[objectIDs[0]], line.ObjectID)
+		}
+
+		err := service.networkTopologyRepo.CreateConnectionsFromSource(projectID, line.ObjectID, lineResult.ResolvedObjectIDs)
+		if err != nil {
+			return []dto.NourashesResolveResult{}, err
+		}
+
+		result = append(result, lineResult)
+	}
+
+	return result, nil
+}
+
+func (service *networkTopologyService) ExportGraphML(projectID uint) (string, error) {
+	graph, err := service.loadGraph(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	buffer.WriteString(`  <key id="name" for="node" attr.name="name" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="type" for="node" attr.name="type" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="status" for="node" attr.name="status" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="origin" for="edge" attr.name="origin" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <graph id="network" edgedefault="directed">` + "\n")
+
+	for _, objectID := range graph.nodeOrder {
+		node := graph.nodes[objectID]
+		fmt.Fprintf(&buffer, "    <node id=\"n%d\">\n", node.ObjectID)
+		fmt.Fprintf(&buffer, "      <data key=\"name\">%s</data>\n", escapeXML(node.Name))
+		fmt.Fprintf(&buffer, "      <data key=\"type\">%s</data>\n", escapeXML(node.Type))
+		fmt.Fprintf(&buffer, "      <data key=\"status\">%s</data>\n", escapeXML(objectStatusName(node.Status)))
+		buffer.WriteString("    </node>\n")
+	}
+
+	for index, edge := range graph.edges {
+		fmt.Fprintf(&buffer, "    <edge id=\"e%d\" source=\"n%d\" target=\"n%d\">\n", index, edge.SourceObjectID, edge.TargetObjectID)
+		fmt.Fprintf(&buffer, "      <data key=\"origin\">%s</data>\n", escapeXML(edge.Origin))
+		buffer.WriteString("    </edge>\n")
+	}
+
+	buffer.WriteString("  </graph>\n")
+	buffer.WriteString("</graphml>\n")
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf("Топология сети - %s.graphml", currentTime.Format("02-01-2006"))
+	fileDestination := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := os.WriteFile(fileDestination, buffer.Bytes(), 0644); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func escapeXML(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
+
+func splitNourashes(nourashes string) []string {
+	result := []string{}
+	for _, name := range strings.FieldsFunc(nourashes, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	}) {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func validateNetworkConnection(graph networkGraph, sourceObjectID, targetObjectID uint) error {
+	source, sourceExists := graph.nodes[sourceObjectID]
+	if !sourceExists {
+		return fmt.Errorf("Объект с ID %d не найден", sourceObjectID)
+	}
+
+	target, targetExists := graph.nodes[targetObjectID]
+	if !targetExists {
+		return fmt.Errorf("Объект с ID %d не найден", targetObjectID)
+	}
+
+	sourceLevel, sourceKnown := graph.levels[source.Type]
+	targetLevel, targetKnown := graph.levels[target.Type]
+	if !sourceKnown || !targetKnown || sourceLevel >= targetLevel {
+		return fmt.Errorf("Объект '%s' не может питать объект '%s'", source.Name, target.Name)
+	}
+
+	for _, objectID := range graph.downstream[sourceObjectID] {
+		if objectID == targetObjectID {
+			return fmt.Errorf("Объект '%s' уже питает объект '%s'", source.Name, target.Name)
+		}
+	}
+
+	return nil
+}
+
+func networkTree(graph networkGraph, adjacency map[uint][]uint, objectID uint, path map[uint]bool) dto.NetworkTreeNode {
+	node := graph.nodes[objectID]
+	result := dto.NetworkTreeNode{
+		ObjectID: node.ObjectID,
+		Name:     node.Name,
+		Type:     node.Type,
+		Status:   node.Status,
+		Children: []dto.NetworkTreeNode{},
+	}
+
+	if path[objectID] {
+		result.Cycle = true
+		return result
+	}
+
+	path[objectID] = true
+	for _, nextObjectID := range adjacency[objectID] {
+		result.Children = append(result.Children, networkTree(graph, adjacency, nextObjectID, path))
+	}
+	delete(path, objectID)
+
+	return result
+}
+
+// Returns every cycle found by the depth first search as the list of objects in the cycle
+func networkCycles(graph networkGraph) [][]uint {
+	const (
+		notVisited = iota
+		inProgress
+		visited
+	)
+
+	cycles := [][]uint{}
+	state := map[uint]int{}
+	stack := []uint{}
+
+	var visit func(objectID uint)
+	visit = func(objectID uint) {
+		state[objectID] = inProgress
+		stack = append(stack, objectID)
+
+		for _, nextObjectID := range graph.downstream[objectID] {
+			switch state[nextObjectID] {
+			case notVisited:
+				visit(nextObjectID)
+			case inProgress:
+				for index := len(stack) - 1; index >= 0; index-- {
+					if stack[index] == nextObjectID {
+						cycles = append(cycles, append([]uint{}, stack[index:]...))
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[objectID] = visited
+	}
+
+	for _, objectID := range graph.nodeOrder {
+		if state[objectID] == notVisited {
+			visit(objectID)
+		}
+	}
+
+	return cycles
+}
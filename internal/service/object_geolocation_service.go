@@ -0,0 +1,258 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const earthRadiusInMeters = 6371000.0
+
+type objectGeolocationService struct {
+	objectGeolocationRepo repository.IObjectGeolocationRepository
+}
+
+func NewObjectGeolocationService(objectGeolocationRepo repository.IObjectGeolocationRepository) IObjectGeolocationService {
+	return &objectGeolocationService{
+		objectGeolocationRepo: objectGeolocationRepo,
+	}
+}
+
+type IObjectGeolocationService interface {
+	GetGeoJSON(projectID uint) (dto.GeoJSONFeatureCollection, error)
+	ExportGeoJSON(projectID uint) (string, error)
+	Search(projectID uint, filter dto.ObjectGeolocationSearch) (dto.GeoJSONFeatureCollection, error)
+}
+
+func (service *objectGeolocationService) GetGeoJSON(projectID uint) (dto.GeoJSONFeatureCollection, error) {
+	return service.featureCollection(projectID, func(points [][2]float64) bool { return true })
+}
+
+func (service *objectGeolocationService) ExportGeoJSON(projectID uint) (string, error) {
+	featureCollection, err := service.GetGeoJSON(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(featureCollection)
+	if err != nil {
+		return "", err
+	}
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf("Объекты на карте - %s.geojson", currentTime.Format("02-01-2006"))
+	fileDestination := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := os.WriteFile(fileDestination, content, 0644); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// Objects with at least one point inside the circle or the bounding box are returned
+func (service *objectGeolocationService) Search(projectID uint, filter dto.ObjectGeolocationSearch) (dto.GeoJSONFeatureCollection, error) {
+	if filter.Radius > 0 {
+		if err := validateCoordinates(filter.Latitude, filter.Longitude); err != nil {
+			return dto.GeoJSONFeatureCollection{}, err
+		}
+
+		return service.featureCollection(projectID, func(points [][2]float64) bool {
+			for _, point := range points {
+				if distanceInMeters(filter.Latitude, filter.Longitude, point[0], point[1]) <= filter.Radius {
+					return true
+				}
+			}
+
+			return false
+		})
+	}
+
+	if err := validateCoordinates(filter.MinLatitude, filter.MinLongitude); err != nil {
+		return dto.GeoJSONFeatureCollection{}, err
+	}
+
+	if err := validateCoordinates(filter.MaxLatitude, filter.MaxLongitude); err != nil {
+		return dto.GeoJSONFeatureCollection{}, err
+	}
+
+	if filter.MinLatitude >= filter.MaxLatitude || filter.MinLongitude >= filter.MaxLongitude {
+		return dto.GeoJSONFeatureCollection{}, fmt.Errorf("Укажите радиус поиска или правильную прямоугольную область")
+	}
+
+	return service.featureCollection(projectID, func(points [][2]float64) bool {
+		for _, point := range points {
+			if point[0] >= filter.MinLatitude && point[0] <= filter.MaxLatitude &&
+				point[1] >= filter.MinLongitude && point[1] <= filter.MaxLongitude {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (service *objectGeolocationService) featureCollection(projectID uint, include func(points [][2]float64) bool) (dto.GeoJSONFeatureCollection, error) {
+	objects, err := service.objectGeolocationRepo.GetLocatedObjects(projectID)
+	if err != nil {
+		return dto.GeoJSONFeatureCollection{}, err
+	}
+
+	teamNumbers, err := service.objectGeolocationRepo.GetTeamNumbers(projectID)
+	if err != nil {
+		return dto.GeoJSONFeatureCollection{}, err
+	}
+
+	teamsOfObject := map[uint][]string{}
+	for _, teamNumber := range teamNumbers {
+		teamsOfObject[teamNumber.ObjectID] = append(teamsOfObject[teamNumber.ObjectID], teamNumber.TeamNumber)
+	}
+
+	result := dto.GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []dto.GeoJSONFeature{},
+	}
+	for _, object := range objects {
+		points := [][2]float64{{object.Latitude, object.Longitude}}
+		if object.Route != "" {
+			points, err = routePoints(object.Route)
+			if err != nil {
+				return dto.GeoJSONFeatureCollection{}, fmt.Errorf("Неправильный маршрут у объекта '%s': %v", object.Name, err)
+			}
+		}
+
+		if !include(points) {
+			continue
+		}
+
+		geometry := dto.GeoJSONGeometry{
+			Type:        "Point",
+			Coordinates: []float64{object.Longitude, object.Latitude},
+		}
+		if object.Route != "" {
+			coordinates := [][]float64{}
+			for _, point := range points {
+				coordinates = append(coordinates, []float64{point[1], point[0]})
+			}
+
+			geometry = dto.GeoJSONGeometry{
+				Type:        "LineString",
+				Coordinates: coordinates,
+			}
+		}
+
+		teams := teamsOfObject[object.ObjectID]
+		if teams == nil {
+			teams = []string{}
+		}
+
+		result.Features = append(result.Features, dto.GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: geometry,
+			Properties: dto.ObjectGeolocationProperties{
+				ObjectID:   object.ObjectID,
+				Name:       object.Name,
+				Type:       object.Type,
+				Status:     object.Status,
+				StatusName: objectStatusName(object.Status),
+				Teams:      teams,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// Great-circle distance between two points by the haversine formula
+func distanceInMeters(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+
+	return 2 * earthRadiusInMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func validateCoordinates(latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 {
+		return fmt.Errorf("широта должна быть от -90 до 90, получено %v", latitude)
+	}
+
+	if longitude < -180 || longitude > 180 {
+		return fmt.Errorf("долгота должна быть от -180 до 180, получено %v", longitude)
+	}
+
+	return nil
+}
+
+// Reads coordinate written by user, both comma and dot are accepted as decimal separator.
+// Empty value means the coordinate is not set
+func coordinateFromInput(value string) (float64, error) {
+	value = strings.TrimSpace(strings.ReplaceAll(value, ",", "."))
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// Route of the line is stored as points "latitude,longitude" separated by ";"
+func routePoints(route string) ([][2]float64, error) {
+	points := [][2]float64{}
+	for _, pointRaw := range strings.Split(route, ";") {
+		pointRaw = strings.TrimSpace(pointRaw)
+		if pointRaw == "" {
+			continue
+		}
+
+		coordinates := strings.Split(pointRaw, ",")
+		if len(coordinates) != 2 {
+			return nil, fmt.Errorf("точка '%s' должна быть в формате 'широта,долгота'", pointRaw)
+		}
+
+		latitude, err := strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("неправильная широта в точке '%s'", pointRaw)
+		}
+
+		longitude, err := strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("неправильная долгота в точке '%s'", pointRaw)
+		}
+
+		if err := validateCoordinates(latitude, longitude); err != nil {
+			return nil, err
+		}
+
+		points = append(points, [2]float64{latitude, longitude})
+	}
+
+	if len(points) == 1 {
+		return nil, fmt.Errorf("маршрут линии должен содержать минимум две точки")
+	}
+
+	return points, nil
+}
+
+// Validates the route and writes it in the form it is stored in
+func normalizeRoute(route string) (string, error) {
+	points, err := routePoints(route)
+	if err != nil {
+		return "", err
+	}
+
+	pointsFormatted := []string{}
+	for _, point := range points {
+		pointsFormatted = append(pointsFormatted, strconv.FormatFloat(point[0], 'f', -1, 64)+","+strconv.FormatFloat(point[1], 'f', -1, 64))
+	}
+
+	return strings.Join(pointsFormatted, ";"), nil
+}
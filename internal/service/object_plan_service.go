@@ -0,0 +1,572 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+type objectPlanService struct {
+	objectPlanRepo repository.IObjectPlanRepository
+	objectRepo     repository.IObjectRepository
+	materialRepo   repository.IMaterialRepository
+	operationRepo  repository.IOperationRepository
+	objectTypeRepo repository.IObjectTypeRepository
+}
+
+func NewObjectPlanService(
+	objectPlanRepo repository.IObjectPlanRepository,
+	objectRepo repository.IObjectRepository,
+	materialRepo repository.IMaterialRepository,
+	operationRepo repository.IOperationRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) IObjectPlanService {
+	return &objectPlanService{
+		objectPlanRepo: objectPlanRepo,
+		objectRepo:     objectRepo,
+		materialRepo:   materialRepo,
+		operationRepo:  operationRepo,
+		objectTypeRepo: objectTypeRepo,
+	}
+}
+
+type IObjectPlanService interface {
+	GetByObjectID(projectID, objectID uint) (dto.ObjectPlanView, error)
+	Replace(projectID uint, data dto.ObjectPlanReplace) error
+	Import(projectID uint, filePath string) error
+	GetReport(projectID, objectID uint) (dto.ObjectPlanReport, error)
+	ExportReport(projectID, objectID uint) (string, error)
+}
+
+func (service *objectPlanService) GetByObjectID(projectID, objectID uint) (dto.ObjectPlanView, error) {
+	object, err := service.objectRepo.GetByID(objectID)
+	if err != nil {
+		return dto.ObjectPlanView{}, err
+	}
+
+	if object.ID == 0 || object.ProjectID != projectID {
+		return dto.ObjectPlanView{}, fmt.Errorf("Объект с ID %d не найден", objectID)
+	}
+
+	materials, err := service.objectPlanRepo.GetPlannedMaterialsByObjectID(objectID)
+	if err != nil {
+		return dto.ObjectPlanView{}, err
+	}
+
+	operations, err := service.objectPlanRepo.GetPlannedOperationsByObjectID(objectID)
+	if err != nil {
+		return dto.ObjectPlanView{}, err
+	}
+
+	return dto.ObjectPlanView{
+		ObjectID:   objectID,
+		Materials:  materials,
+		Operations: operations,
+	}, nil
+}
+
+func (service *objectPlanService) Replace(projectID uint, data dto.ObjectPlanReplace) error {
+	object, err := service.objectRepo.GetByID(data.ObjectID)
+	if err != nil {
+		return err
+	}
+
+	if object.ID == 0 || object.ProjectID != projectID {
+		return fmt.Errorf("Объект с ID %d не найден", data.ObjectID)
+	}
+
+	materials := []model.ObjectPlannedMaterial{}
+	plannedMaterials := map[uint]bool{}
+	for _, item := range data.Materials {
+		if item.Amount <= 0 {
+			return fmt.Errorf("Плановое количество материала должно быть больше 0")
+		}
+
+		if plannedMaterials[item.MaterialID] {
+			return fmt.Errorf("Материал с ID %d указан в плане несколько раз", item.MaterialID)
+		}
+		plannedMaterials[item.MaterialID] = true
+
+		material, err := service.materialRepo.GetByID(item.MaterialID)
+		if err != nil {
+			return err
+		}
+
+		if material.ID == 0 || material.ProjectID != projectID {
+			return fmt.Errorf("Материал с ID %d не найден", item.MaterialID)
+		}
+
+		materials = append(materials, model.ObjectPlannedMaterial{
+			ProjectID:  projectID,
+			ObjectID:   object.ID,
+			MaterialID: material.ID,
+			Amount:     item.Amount,
+		})
+	}
+
+	operations := []model.ObjectPlannedOperation{}
+	plannedOperations := map[uint]bool{}
+	for _, item := range data.Operations {
+		if item.Amount <= 0 {
+			return fmt.Errorf("Плановое количество услуги должно быть больше 0")
+		}
+
+		if plannedOperations[item.OperationID] {
+			return fmt.Errorf("Услуга с ID %d указана в плане несколько раз", item.OperationID)
+		}
+		plannedOperations[item.OperationID] = true
+
+		operation, err := service.operationRepo.GetByID(item.OperationID)
+		if err != nil {
+			return err
+		}
+
+		if operation.ID == 0 || operation.ProjectID != projectID {
+			return fmt.Errorf("Услуга с ID %d не найдена", item.OperationID)
+		}
+
+		operations = append(operations, model.ObjectPlannedOperation{
+			ProjectID:   projectID,
+			ObjectID:    object.ID,
+			OperationID: operation.ID,
+			Amount:      item.Amount,
+		})
+	}
+
+	return service.objectPlanRepo.Replace([]uint{object.ID}, materials, operations)
+}
+
+// Plan of every object in the file is replaced by the plan from the file,
+// several rows of the same material or operation on the object are summed up
+func (service *objectPlanService) Import(projectID uint, filePath string) error {
+	defer os.Remove(filePath)
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	sheetName := "План"
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return fmt.Errorf("Не смог найти таблицу '%s': %v", sheetName, err)
+	}
+
+	if len(rows) <= 1 {
+		return fmt.Errorf("Файл не имеет данных")
+	}
+
+	objects, err := service.objectRepo.GetAll(projectID)
+	if err != nil {
+		return err
+	}
+
+	objectIDByName := map[string]uint{}
+	for _, object := range objects {
+		objectIDByName[object.Name] = object.ID
+	}
+
+	materials, err := service.materialRepo.GetAll(projectID)
+	if err != nil {
+		return err
+	}
+
+	materialIDByCode := map[string]uint{}
+	for _, material := range materials {
+		materialIDByCode[material.Code] = material.ID
+	}
+
+	operations, err := service.operationRepo.GetAll(projectID)
+	if err != nil {
+		return err
+	}
+
+	operationIDByCode := map[string]uint{}
+	for _, operation := range operations {
+		operationIDByCode[operation.Code] = operation.ID
+	}
+
+	objectIDs := []uint{}
+	plannedMaterials := map[[2]uint]float64{}
+	plannedOperations := map[[2]uint]float64{}
+	for index, row := range rows[1:] {
+		rowNumber := index + 2
+		cell := func(column int) string {
+			if column > len(row) {
+				return ""
+			}
+
+			return strings.TrimSpace(row[column-1])
+		}
+
+		if cell(1) == "" && cell(3) == "" {
+			continue
+		}
+
+		objectID, exists := objectIDByName[cell(1)]
+		if !exists {
+			return fmt.Errorf("Ошибка в файле, объект '%s' в ячейке A%d не найден", cell(1), rowNumber)
+		}
+
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(cell(5), ",", "."), 64)
+		if err != nil {
+			return fmt.Errorf("Ошибка в файле, неправильный формат данных в ячейке E%d: %v", rowNumber, err)
+		}
+
+		if amount <= 0 {
+			return fmt.Errorf("Ошибка в файле, количество в ячейке E%d должно быть больше 0", rowNumber)
+		}
+
+		switch strings.ToLower(cell(2)) {
+		case "материал":
+			materialID, exists := materialIDByCode[cell(3)]
+			if !exists {
+				return fmt.Errorf("Ошибка в файле, материал с кодом '%s' в ячейке C%d не найден", cell(3), rowNumber)
+			}
+
+			plannedMaterials[[2]uint{objectID, materialID}] += amount
+
+		case "услуга":
+			operationID, exists := operationIDByCode[cell(3)]
+			if !exists {
+				return fmt.Errorf("Ошибка в файле, услуга с кодом '%s' в ячейке C%d не найдена", cell(3), rowNumber)
+			}
+
+			plannedOperations[[2]uint{objectID, operationID}] += amount
+
+		default:
+			return fmt.Errorf("Ошибка в файле, в ячейке B%d должно быть 'Материал' или 'Услуга'", rowNumber)
+		}
+
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	if len(objectIDs) == 0 {
+		return fmt.Errorf("Файл не имеет данных")
+	}
+
+	materialsToCreate := []model.ObjectPlannedMaterial{}
+	for key, amount := range plannedMaterials {
+		materialsToCreate = append(materialsToCreate, model.ObjectPlannedMaterial{
+			ProjectID:  projectID,
+			ObjectID:   key[0],
+			MaterialID: key[1],
+			Amount:     amount,
+		})
+	}
+
+	operationsToCreate := []model.ObjectPlannedOperation{}
+	for key, amount := range plannedOperations {
+		operationsToCreate = append(operationsToCreate, model.ObjectPlannedOperation{
+			ProjectID:   projectID,
+			ObjectID:    key[0],
+			OperationID: key[1],
+			Amount:      amount,
+		})
+	}
+
+	return service.objectPlanRepo.Replace(objectIDs, materialsToCreate, operationsToCreate)
+}
+
+func (service *objectPlanService) GetReport(projectID, objectID uint) (dto.ObjectPlanReport, error) {
+	plannedMaterials, err := service.objectPlanRepo.GetPlannedMaterials(projectID, objectID)
+	if err != nil {
+		return dto.ObjectPlanReport{}, err
+	}
+
+	actualMaterials, err := service.objectPlanRepo.GetActualMaterials(projectID, objectID)
+	if err != nil {
+		return dto.ObjectPlanReport{}, err
+	}
+
+	plannedOperations, err := service.objectPlanRepo.GetPlannedOperations(projectID, objectID)
+	if err != nil {
+		return dto.ObjectPlanReport{}, err
+	}
+
+	actualOperations, err := service.objectPlanRepo.GetActualOperations(projectID, objectID)
+	if err != nil {
+		return dto.ObjectPlanReport{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return dto.ObjectPlanReport{}, err
+	}
+
+	objects := map[uint]*dto.ObjectPlanReportObject{}
+	for _, amounts := range [][]dto.ObjectPlanAmountQueryResult{plannedMaterials, actualMaterials, plannedOperations, actualOperations} {
+		for _, amount := range amounts {
+			if _, exists := objects[amount.ObjectID]; !exists {
+				objects[amount.ObjectID] = &dto.ObjectPlanReportObject{
+					ObjectID:   amount.ObjectID,
+					ObjectName: amount.ObjectName,
+					ObjectType: typeNames[amount.ObjectType],
+				}
+			}
+		}
+	}
+
+	materialRows := objectPlanReportRows(plannedMaterials, actualMaterials)
+	operationRows := objectPlanReportRows(plannedOperations, actualOperations)
+
+	result := dto.ObjectPlanReport{
+		Objects:    []dto.ObjectPlanReportObject{},
+		Materials:  []dto.ObjectPlanReportRow{},
+		Operations: []dto.ObjectPlanReportRow{},
+		PlannedSum: decimal.Zero,
+		ActualSum:  decimal.Zero,
+	}
+	for _, object := range objects {
+		object.Materials = materialRows[object.ObjectID]
+		if object.Materials == nil {
+			object.Materials = []dto.ObjectPlanReportRow{}
+		}
+
+		object.Operations = operationRows[object.ObjectID]
+		if object.Operations == nil {
+			object.Operations = []dto.ObjectPlanReportRow{}
+		}
+
+		object.PlannedSum = decimal.Zero
+		object.ActualSum = decimal.Zero
+		for _, row := range append(append([]dto.ObjectPlanReportRow{}, object.Materials...), object.Operations...) {
+			object.PlannedSum = object.PlannedSum.Add(row.PlannedSum)
+			object.ActualSum = object.ActualSum.Add(row.ActualSum)
+			object.Overrun = object.Overrun || row.Overrun
+		}
+		object.Overrun = object.Overrun || object.ActualSum.GreaterThan(object.PlannedSum)
+
+		result.Objects = append(result.Objects, *object)
+		result.Materials = append(result.Materials, object.Materials...)
+		result.Operations = append(result.Operations, object.Operations...)
+		result.PlannedSum = result.PlannedSum.Add(object.PlannedSum)
+		result.ActualSum = result.ActualSum.Add(object.ActualSum)
+	}
+
+	sort.Slice(result.Objects, func(i, j int) bool {
+		return result.Objects[i].ObjectID < result.Objects[j].ObjectID
+	})
+
+	result.Materials = projectPlanReportRows(result.Materials)
+	result.Operations = projectPlanReportRows(result.Operations)
+	result.Overrun = result.ActualSum.GreaterThan(result.PlannedSum)
+	for _, row := range append(append([]dto.ObjectPlanReportRow{}, result.Materials...), result.Operations...) {
+		result.Overrun = result.Overrun || row.Overrun
+	}
+
+	return result, nil
+}
+
+func (service *objectPlanService) ExportReport(projectID, objectID uint) (string, error) {
+	report, err := service.GetReport(projectID, objectID)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "Object Plan Report.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	overrunStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	totalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Объекты"
+	rowCount := 2
+	for _, object := range report.Objects {
+		for _, kind := range []struct {
+			name string
+			rows []dto.ObjectPlanReportRow
+		}{{"Материал", object.Materials}, {"Услуга", object.Operations}} {
+			for _, row := range kind.rows {
+				f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), object.ObjectName)
+				f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), object.ObjectType)
+				f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), kind.name)
+				setObjectPlanReportRow(f, sheetName, 4, rowCount, row)
+				if row.Overrun {
+					f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "L"+fmt.Sprint(rowCount), overrunStyle)
+				}
+				rowCount++
+			}
+		}
+
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Итого по объекту "+object.ObjectName)
+		setObjectPlanReportSums(f, sheetName, 10, rowCount, object.PlannedSum, object.ActualSum)
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "L"+fmt.Sprint(rowCount), totalStyle)
+		rowCount++
+	}
+
+	sheetName = "Проект"
+	rowCount = 2
+	for _, kind := range []struct {
+		name string
+		rows []dto.ObjectPlanReportRow
+	}{{"Материал", report.Materials}, {"Услуга", report.Operations}} {
+		for _, row := range kind.rows {
+			f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), kind.name)
+			setObjectPlanReportRow(f, sheetName, 2, rowCount, row)
+			if row.Overrun {
+				f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "J"+fmt.Sprint(rowCount), overrunStyle)
+			}
+			rowCount++
+		}
+	}
+
+	f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Итого по проекту")
+	setObjectPlanReportSums(f, sheetName, 8, rowCount, report.PlannedSum, report.ActualSum)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "J"+fmt.Sprint(rowCount), totalStyle)
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"План и факт по объектам - %s.xlsx",
+		currentTime.Format("02-01-2006"),
+	)
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// Writes code, name, unit, amounts and sums of the row starting from the given column
+func setObjectPlanReportRow(f *excelize.File, sheetName string, startColumn, rowCount int, row dto.ObjectPlanReportRow) {
+	cell := func(offset int) string {
+		cellName, _ := excelize.CoordinatesToCellName(startColumn+offset, rowCount)
+		return cellName
+	}
+
+	f.SetCellStr(sheetName, cell(0), row.Code)
+	f.SetCellStr(sheetName, cell(1), row.Name)
+	f.SetCellStr(sheetName, cell(2), row.Unit)
+	f.SetCellFloat(sheetName, cell(3), row.PlannedAmount, 2, 64)
+	f.SetCellFloat(sheetName, cell(4), row.ActualAmount, 2, 64)
+	f.SetCellFloat(sheetName, cell(5), row.AmountDifference, 2, 64)
+	setObjectPlanReportSums(f, sheetName, startColumn+6, rowCount, row.PlannedSum, row.ActualSum)
+}
+
+func setObjectPlanReportSums(f *excelize.File, sheetName string, startColumn, rowCount int, plannedSum, actualSum decimal.Decimal) {
+	for offset, sum := range []decimal.Decimal{plannedSum, actualSum, actualSum.Sub(plannedSum)} {
+		cellName, _ := excelize.CoordinatesToCellName(startColumn+offset, rowCount)
+		sumFloat, _ := sum.Float64()
+		f.SetCellFloat(sheetName, cellName, sumFloat, 2, 64)
+	}
+}
+
+// Planned and actual amounts of the same item on the same object are merged into one row, rows grouped by object
+func objectPlanReportRows(planned, actual []dto.ObjectPlanAmountQueryResult) map[uint][]dto.ObjectPlanReportRow {
+	rows := map[[2]uint]*dto.ObjectPlanReportRow{}
+	rowOf := func(amount dto.ObjectPlanAmountQueryResult) *dto.ObjectPlanReportRow {
+		key := [2]uint{amount.ObjectID, amount.ItemID}
+		if _, exists := rows[key]; !exists {
+			rows[key] = &dto.ObjectPlanReportRow{
+				ID:         amount.ItemID,
+				Code:       amount.Code,
+				Name:       amount.Name,
+				Unit:       amount.Unit,
+				PlannedSum: decimal.Zero,
+				ActualSum:  decimal.Zero,
+			}
+		}
+
+		return rows[key]
+	}
+
+	for _, amount := range planned {
+		row := rowOf(amount)
+		row.PlannedAmount += amount.Amount
+		row.PlannedSum = row.PlannedSum.Add(amount.Sum)
+	}
+
+	for _, amount := range actual {
+		row := rowOf(amount)
+		row.ActualAmount += amount.Amount
+		row.ActualSum = row.ActualSum.Add(amount.Sum)
+	}
+
+	result := map[uint][]dto.ObjectPlanReportRow{}
+	for key, row := range rows {
+		result[key[0]] = append(result[key[0]], objectPlanReportRowTotals(*row))
+	}
+
+	for objectID := range result {
+		sortObjectPlanReportRows(result[objectID])
+	}
+
+	return result
+}
+
+// Rows of all objects merged by item for the project level roll-up
+func projectPlanReportRows(objectRows []dto.ObjectPlanReportRow) []dto.ObjectPlanReportRow {
+	rows := map[uint]*dto.ObjectPlanReportRow{}
+	for _, objectRow := range objectRows {
+		row, exists := rows[objectRow.ID]
+		if !exists {
+			row = &dto.ObjectPlanReportRow{
+				ID:         objectRow.ID,
+				Code:       objectRow.Code,
+				Name:       objectRow.Name,
+				Unit:       objectRow.Unit,
+				PlannedSum: decimal.Zero,
+				ActualSum:  decimal.Zero,
+			}
+			rows[objectRow.ID] = row
+		}
+
+		row.PlannedAmount += objectRow.PlannedAmount
+		row.ActualAmount += objectRow.ActualAmount
+		row.PlannedSum = row.PlannedSum.Add(objectRow.PlannedSum)
+		row.ActualSum = row.ActualSum.Add(objectRow.ActualSum)
+	}
+
+	result := []dto.ObjectPlanReportRow{}
+	for _, row := range rows {
+		result = append(result, objectPlanReportRowTotals(*row))
+	}
+	sortObjectPlanReportRows(result)
+
+	return result
+}
+
+// Anything used above the plan, including items that were not planned at all, is an overrun
+func objectPlanReportRowTotals(row dto.ObjectPlanReportRow) dto.ObjectPlanReportRow {
+	row.AmountDifference = row.ActualAmount - row.PlannedAmount
+	row.SumDifference = row.ActualSum.Sub(row.PlannedSum)
+	row.Overrun = row.AmountDifference > 1e-9 || row.SumDifference.IsPositive()
+	return row
+}
+
+func sortObjectPlanReportRows(rows []dto.ObjectPlanReportRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Code != rows[j].Code {
+			return rows[i].Code < rows[j].Code
+		}
+
+		return rows[i].ID < rows[j].ID
+	})
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+type objectProfitabilityService struct {
+	objectProfitabilityRepo repository.IObjectProfitabilityRepository
+	objectRepo              repository.IObjectRepository
+	objectTypeRepo          repository.IObjectTypeRepository
+}
+
+func NewObjectProfitabilityService(
+	objectProfitabilityRepo repository.IObjectProfitabilityRepository,
+	objectRepo repository.IObjectRepository,
+	objectTypeRepo repository.IObjectTypeRepository,
+) IObjectProfitabilityService {
+	return &objectProfitabilityService{
+		objectProfitabilityRepo: objectProfitabilityRepo,
+		objectRepo:              objectRepo,
+		objectTypeRepo:          objectTypeRepo,
+	}
+}
+
+type IObjectProfitabilityService interface {
+	GetSummary(filter dto.ObjectProfitabilityFilter) (dto.ObjectProfitabilitySummary, error)
+	GetInvoices(filter dto.ObjectProfitabilityFilter) ([]dto.ObjectProfitabilityInvoice, error)
+	Export(filter dto.ObjectProfitabilityFilter) (string, error)
+}
+
+func (service *objectProfitabilityService) GetSummary(filter dto.ObjectProfitabilityFilter) (dto.ObjectProfitabilitySummary, error) {
+	invoices, err := service.objectProfitabilityRepo.GetInvoices(filter)
+	if err != nil {
+		return dto.ObjectProfitabilitySummary{}, err
+	}
+
+	typeNames, err := objectTypeNames(service.objectTypeRepo)
+	if err != nil {
+		return dto.ObjectProfitabilitySummary{}, err
+	}
+
+	objects := map[uint]*dto.ObjectProfitabilityRow{}
+	objectTypes := map[string]*dto.ObjectProfitabilityRow{}
+	districts := map[uint]*dto.ObjectProfitabilityRow{}
+	total := newObjectProfitabilityRow()
+	for _, invoice := range invoices {
+		if _, exists := objects[invoice.ObjectID]; !exists {
+			row := newObjectProfitabilityRow()
+			row.ID = invoice.ObjectID
+			row.Name = invoice.ObjectName
+			row.ObjectType = typeNames[invoice.ObjectType]
+			objects[invoice.ObjectID] = &row
+		}
+
+		if _, exists := objectTypes[invoice.ObjectType]; !exists {
+			row := newObjectProfitabilityRow()
+			row.Code = invoice.ObjectType
+			row.Name = typeNames[invoice.ObjectType]
+			objectTypes[invoice.ObjectType] = &row
+		}
+
+		if _, exists := districts[invoice.DistrictID]; !exists {
+			row := newObjectProfitabilityRow()
+			row.ID = invoice.DistrictID
+			row.Name = invoice.DistrictName
+			if invoice.DistrictID == 0 {
+				row.Name = "Без района"
+			}
+			districts[invoice.DistrictID] = &row
+		}
+
+		for _, row := range []*dto.ObjectProfitabilityRow{objects[invoice.ObjectID], objectTypes[invoice.ObjectType], districts[invoice.DistrictID], &total} {
+			addObjectProfitabilityInvoice(row, invoice)
+		}
+	}
+
+	result := dto.ObjectProfitabilitySummary{
+		Objects:     []dto.ObjectProfitabilityRow{},
+		ObjectTypes: []dto.ObjectProfitabilityRow{},
+		Districts:   []dto.ObjectProfitabilityRow{},
+		Total:       objectProfitabilityRowTotals(total),
+	}
+
+	for _, row := range objects {
+		result.Objects = append(result.Objects, objectProfitabilityRowTotals(*row))
+	}
+
+	for _, row := range objectTypes {
+		result.ObjectTypes = append(result.ObjectTypes, objectProfitabilityRowTotals(*row))
+	}
+
+	for _, row := range districts {
+		result.Districts = append(result.Districts, objectProfitabilityRowTotals(*row))
+	}
+
+	for _, rows := range [][]dto.ObjectProfitabilityRow{result.Objects, result.ObjectTypes, result.Districts} {
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i].Name < rows[j].Name
+		})
+	}
+
+	return result, nil
+}
+
+// Invoices of the object that contributed to its customer value and prime cost
+func (service *objectProfitabilityService) GetInvoices(filter dto.ObjectProfitabilityFilter) ([]dto.ObjectProfitabilityInvoice, error) {
+	object, err := service.objectRepo.GetByID(filter.ObjectID)
+	if err != nil {
+		return []dto.ObjectProfitabilityInvoice{}, err
+	}
+
+	if object.ID == 0 || object.ProjectID != filter.ProjectID {
+		return []dto.ObjectProfitabilityInvoice{}, fmt.Errorf("Объект с ID %d не найден", filter.ObjectID)
+	}
+
+	invoices, err := service.objectProfitabilityRepo.GetInvoices(filter)
+	if err != nil {
+		return []dto.ObjectProfitabilityInvoice{}, err
+	}
+
+	result := []dto.ObjectProfitabilityInvoice{}
+	for _, invoice := range invoices {
+		result = append(result, objectProfitabilityInvoice(invoice))
+	}
+
+	return result, nil
+}
+
+func (service *objectProfitabilityService) Export(filter dto.ObjectProfitabilityFilter) (string, error) {
+	summary, err := service.GetSummary(filter)
+	if err != nil {
+		return "", err
+	}
+
+	invoices, err := service.objectProfitabilityRepo.GetInvoices(filter)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "Object Profitability Report.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	lossStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	totalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Объекты"
+	rowCount := 2
+	for _, row := range summary.Objects {
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), row.Name)
+		f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), row.ObjectType)
+		setObjectProfitabilityValues(f, sheetName, 3, rowCount, row)
+		if row.Margin.IsNegative() {
+			f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount), lossStyle)
+		}
+		rowCount++
+	}
+
+	f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Итого")
+	setObjectProfitabilityValues(f, sheetName, 3, rowCount, summary.Total)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "H"+fmt.Sprint(rowCount), totalStyle)
+
+	for _, group := range []struct {
+		sheetName string
+		rows      []dto.ObjectProfitabilityRow
+	}{{"Типы объектов", summary.ObjectTypes}, {"Районы", summary.Districts}} {
+		rowCount = 2
+		for _, row := range group.rows {
+			f.SetCellStr(group.sheetName, "A"+fmt.Sprint(rowCount), row.Name)
+			setObjectProfitabilityValues(f, group.sheetName, 2, rowCount, row)
+			if row.Margin.IsNegative() {
+				f.SetCellStyle(group.sheetName, "A"+fmt.Sprint(rowCount), "G"+fmt.Sprint(rowCount), lossStyle)
+			}
+			rowCount++
+		}
+
+		f.SetCellStr(group.sheetName, "A"+fmt.Sprint(rowCount), "Итого")
+		setObjectProfitabilityValues(f, group.sheetName, 2, rowCount, summary.Total)
+		f.SetCellStyle(group.sheetName, "A"+fmt.Sprint(rowCount), "G"+fmt.Sprint(rowCount), totalStyle)
+	}
+
+	sheetName = "Накладные"
+	rowCount = 2
+	for _, invoiceData := range invoices {
+		invoice := objectProfitabilityInvoice(invoiceData)
+		confirmed := "Нет"
+		if invoice.ConfirmedByOperator {
+			confirmed = "Да"
+		}
+
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), invoiceData.ObjectName)
+		f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), invoice.DeliveryCode)
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), invoice.DateOfInvoice.Format("02.01.2006"))
+		f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), confirmed)
+		f.SetCellStr(sheetName, "E"+fmt.Sprint(rowCount), invoice.DistrictName)
+		setObjectProfitabilityValues(f, sheetName, 6, rowCount, dto.ObjectProfitabilityRow{
+			CustomerValue: invoice.CustomerValue,
+			MaterialCost:  invoice.MaterialCost,
+			LabourCost:    invoice.LabourCost,
+			PrimeCost:     invoice.PrimeCost,
+			Margin:        invoice.Margin,
+			MarginPercent: invoice.MarginPercent,
+		})
+		rowCount++
+	}
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"Рентабельность объектов - %s.xlsx",
+		currentTime.Format("02-01-2006"),
+	)
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func newObjectProfitabilityRow() dto.ObjectProfitabilityRow {
+	return dto.ObjectProfitabilityRow{
+		CustomerValue: decimal.Zero,
+		MaterialCost:  decimal.Zero,
+		LabourCost:    decimal.Zero,
+	}
+}
+
+func addObjectProfitabilityInvoice(row *dto.ObjectProfitabilityRow, invoice dto.ObjectProfitabilityInvoiceQueryResult) {
+	row.CustomerValue = row.CustomerValue.Add(invoice.MaterialValue).Add(invoice.OperationValue)
+	row.MaterialCost = row.MaterialCost.Add(invoice.MaterialCost)
+	row.LabourCost = row.LabourCost.Add(invoice.LabourCost)
+}
+
+// Fills prime cost, margin and margin percent from customer value, material and labour costs
+func objectProfitabilityRowTotals(row dto.ObjectProfitabilityRow) dto.ObjectProfitabilityRow {
+	row.PrimeCost = row.MaterialCost.Add(row.LabourCost)
+	row.Margin = row.CustomerValue.Sub(row.PrimeCost)
+	row.MarginPercent = marginPercent(row.Margin, row.CustomerValue)
+	return row
+}
+
+func objectProfitabilityInvoice(invoice dto.ObjectProfitabilityInvoiceQueryResult) dto.ObjectProfitabilityInvoice {
+	row := newObjectProfitabilityRow()
+	addObjectProfitabilityInvoice(&row, invoice)
+	row = objectProfitabilityRowTotals(row)
+
+	return dto.ObjectProfitabilityInvoice{
+		InvoiceID:           invoice.InvoiceID,
+		DeliveryCode:        invoice.DeliveryCode,
+		DateOfInvoice:       invoice.DateOfInvoice,
+		ConfirmedByOperator: invoice.ConfirmedByOperator,
+		DistrictName:        invoice.DistrictName,
+		CustomerValue:       row.CustomerValue,
+		MaterialCost:        row.MaterialCost,
+		LabourCost:          row.LabourCost,
+		PrimeCost:           row.PrimeCost,
+		Margin:              row.Margin,
+		MarginPercent:       row.MarginPercent,
+	}
+}
+
+func marginPercent(margin, customerValue decimal.Decimal) float64 {
+	if customerValue.IsZero() {
+		return 0
+	}
+
+	percent, _ := margin.Div(customerValue).Mul(decimal.NewFromInt(100)).Round(2).Float64()
+	return percent
+}
+
+// Writes customer value, costs, margin and margin percent starting from the given column
+func setObjectProfitabilityValues(f *excelize.File, sheetName string, startColumn, rowCount int, row dto.ObjectProfitabilityRow) {
+	for offset, value := range []decimal.Decimal{row.CustomerValue, row.MaterialCost, row.LabourCost, row.PrimeCost, row.Margin} {
+		cellName, _ := excelize.CoordinatesToCellName(startColumn+offset, rowCount)
+		valueFloat, _ := value.Float64()
+		f.SetCellFloat(sheetName, cellName, valueFloat, 2, 64)
+	}
+
+	cellName, _ := excelize.CoordinatesToCellName(startColumn+5, rowCount)
+	f.SetCellFloat(sheetName, cellName, row.MarginPercent, 2, 64)
+}
@@ -9,32 +9,17 @@ import (
 type objectService struct {
 	objectRepo            repository.IObjectRepository
 	objectSupervisorsRepo repository.IObjectSupervisorsRepository
-	kl04kvObjectRepo      repository.IKL04KVObjectRepository
-	mjdObjectRepo         repository.IMJDObjectRepository
-	sipObjectRepo         repository.ISIPObjectRepository
-	stvtObjectRepo        repository.ISTVTObjectRepository
-	tpObjectRepo          repository.ITPObjectRepository
 	objectTeamsRepo       repository.IObjectTeamsRepository
 }
 
 func InitObjectService(
 	objectRepo repository.IObjectRepository,
 	objectSupervisorsRepo repository.IObjectSupervisorsRepository,
-	kl04kvObjectRepo repository.IKL04KVObjectRepository,
-	mjdObjectRepo repository.IMJDObjectRepository,
-	sipObjectRepo repository.ISIPObjectRepository,
-	stvtObjectRepo repository.ISTVTObjectRepository,
-	tpObjectRepo repository.ITPObjectRepository,
 	objectTeamsRepo repository.IObjectTeamsRepository,
 ) IObjectService {
 	return &objectService{
 		objectRepo:            objectRepo,
 		objectSupervisorsRepo: objectSupervisorsRepo,
-		kl04kvObjectRepo:      kl04kvObjectRepo,
-		mjdObjectRepo:         mjdObjectRepo,
-		sipObjectRepo:         sipObjectRepo,
-		stvtObjectRepo:        stvtObjectRepo,
-		tpObjectRepo:          tpObjectRepo,
 		objectTeamsRepo:       objectTeamsRepo,
 	}
 }
@@ -106,16 +91,21 @@ func (service *objectService) GetByID(id uint) (model.Object, error) {
 
 func (service *objectService) Create(data dto.ObjectCreate) (model.Object, error) {
 
+	status, err := objectStatusFromInput(data.Status)
+	if err != nil {
+		return model.Object{}, err
+	}
+
 	object := model.Object{
 		ID:               0,
 		ObjectDetailedID: 0,
 		Type:             "",
 		Name:             data.Name,
-		Status:           data.Status,
+		Status:           status,
 		ProjectID:        data.ProjectID,
 	}
 
-	object, err := service.objectRepo.Create(object)
+	object, err = service.objectRepo.Create(object)
 	if err != nil {
 		return model.Object{}, err
 	}
@@ -124,7 +114,12 @@ func (service *objectService) Create(data dto.ObjectCreate) (model.Object, error
 }
 
 func (service *objectService) Update(data model.Object) (model.Object, error) {
-	return service.objectRepo.Update(data)
+	object, err := service.objectRepo.GetByID(data.ID)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	return service.objectRepo.Update(withStoredObjectStatus(data, object))
 }
 
 func (service *objectService) Delete(id uint) error {
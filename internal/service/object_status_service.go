@@ -0,0 +1,284 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lifecycle of the object in the order the object goes through it
+var objectStatuses = []dto.ObjectStatusView{
+	{Status: "planned", Name: "Запланирован"},
+	{Status: "design", Name: "Проектирование"},
+	{Status: "materials_issued", Name: "Материалы выданы"},
+	{Status: "in_progress", Name: "В работе"},
+	{Status: "installed", Name: "Смонтирован"},
+	{Status: "commissioned", Name: "Введен в эксплуатацию"},
+	{Status: "handed_over", Name: "Сдан заказчику"},
+}
+
+const defaultObjectStatus = "planned"
+
+type objectStatusTransitionRule struct {
+	from string
+	to   string
+
+	// User role must have update permission on this resource to make the transition
+	resourceURL string
+
+	// Returns the reason why the transition is not possible or empty string
+	precondition func(objectStatusRepo repository.IObjectStatusRepository, objectID uint) (string, error)
+}
+
+var objectStatusTransitionRules = []objectStatusTransitionRule{
+	{from: "planned", to: "design", resourceURL: "/object-status"},
+	{from: "design", to: "materials_issued", resourceURL: "/object-status", precondition: materialsIssuedToObjectTeams},
+	{from: "materials_issued", to: "in_progress", resourceURL: "/object-status"},
+	{from: "in_progress", to: "installed", resourceURL: "/object-status", precondition: objectHasConfirmedInvoice},
+	{from: "installed", to: "commissioned", resourceURL: "/object-status/handover"},
+	{from: "commissioned", to: "handed_over", resourceURL: "/object-status/handover"},
+
+	{from: "design", to: "planned", resourceURL: "/object-status/rollback"},
+	{from: "materials_issued", to: "design", resourceURL: "/object-status/rollback"},
+	{from: "in_progress", to: "materials_issued", resourceURL: "/object-status/rollback"},
+	{from: "installed", to: "in_progress", resourceURL: "/object-status/rollback"},
+	{from: "commissioned", to: "installed", resourceURL: "/object-status/rollback"},
+}
+
+func materialsIssuedToObjectTeams(objectStatusRepo repository.IObjectStatusRepository, objectID uint) (string, error) {
+	issued, err := objectStatusRepo.HasConfirmedOutputToObjectTeams(objectID)
+	if err != nil {
+		return "", err
+	}
+
+	if !issued {
+		return "бригадам объекта не было выдано материалов по подтвержденной накладной отпуск", nil
+	}
+
+	return "", nil
+}
+
+func objectHasConfirmedInvoice(objectStatusRepo repository.IObjectStatusRepository, objectID uint) (string, error) {
+	confirmed, err := objectStatusRepo.HasConfirmedObjectInvoice(objectID)
+	if err != nil {
+		return "", err
+	}
+
+	if !confirmed {
+		return "у объекта нет ни одной подтвержденной накладной объект", nil
+	}
+
+	return "", nil
+}
+
+type objectStatusService struct {
+	objectStatusRepo repository.IObjectStatusRepository
+	objectRepo       repository.IObjectRepository
+	permissionRepo   repository.IPermissionRepository
+}
+
+func NewObjectStatusService(
+	objectStatusRepo repository.IObjectStatusRepository,
+	objectRepo repository.IObjectRepository,
+	permissionRepo repository.IPermissionRepository,
+) IObjectStatusService {
+	return &objectStatusService{
+		objectStatusRepo: objectStatusRepo,
+		objectRepo:       objectRepo,
+		permissionRepo:   permissionRepo,
+	}
+}
+
+type IObjectStatusService interface {
+	GetAll() []dto.ObjectStatusView
+	GetAvailableTransitions(objectID, roleID uint) ([]dto.ObjectStatusView, error)
+	Transition(projectID, userID, roleID uint, data dto.ObjectStatusTransitionCreate) error
+	GetTimeline(objectID uint) ([]dto.ObjectStatusTransitionView, error)
+}
+
+func (service *objectStatusService) GetAll() []dto.ObjectStatusView {
+	return objectStatuses
+}
+
+func (service *objectStatusService) GetAvailableTransitions(objectID, roleID uint) ([]dto.ObjectStatusView, error) {
+	object, err := service.objectRepo.GetByID(objectID)
+	if err != nil {
+		return []dto.ObjectStatusView{}, err
+	}
+
+	result := []dto.ObjectStatusView{}
+	for _, status := range objectStatuses {
+		rule, found := objectStatusTransitionRuleFor(object.Status, status.Status)
+		if !found {
+			continue
+		}
+
+		allowed, err := service.roleCanTransition(roleID, rule)
+		if err != nil {
+			return []dto.ObjectStatusView{}, err
+		}
+
+		if allowed {
+			result = append(result, status)
+		}
+	}
+
+	return result, nil
+}
+
+func (service *objectStatusService) Transition(projectID, userID, roleID uint, data dto.ObjectStatusTransitionCreate) error {
+	object, err := service.objectRepo.GetByID(data.ObjectID)
+	if err != nil {
+		return err
+	}
+
+	if object.ID == 0 || object.ProjectID != projectID {
+		return fmt.Errorf("Объект с ID %d не найден", data.ObjectID)
+	}
+
+	rule, found := objectStatusTransitionRuleFor(object.Status, data.ToStatus)
+	if !found {
+		return fmt.Errorf("Переход из статуса '%s' в статус '%s' не разрешен", objectStatusName(object.Status), objectStatusName(data.ToStatus))
+	}
+
+	allowed, err := service.roleCanTransition(roleID, rule)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("Доступ запрещен: у вашей роли нет прав на перевод объекта в статус '%s'", objectStatusName(data.ToStatus))
+	}
+
+	if rule.precondition != nil {
+		reason, err := rule.precondition(service.objectStatusRepo, object.ID)
+		if err != nil {
+			return err
+		}
+
+		if reason != "" {
+			return fmt.Errorf("Невозможно перевести объект в статус '%s': %s", objectStatusName(data.ToStatus), reason)
+		}
+	}
+
+	changed, err := service.objectStatusRepo.Transition(model.ObjectStatusTransition{
+		ProjectID:  projectID,
+		ObjectID:   object.ID,
+		FromStatus: object.Status,
+		ToStatus:   data.ToStatus,
+		UserID:     userID,
+		Date:       time.Now(),
+		Comment:    data.Comment,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return fmt.Errorf("Статус объекта был изменен другим пользователем, обновите страницу")
+	}
+
+	return nil
+}
+
+func (service *objectStatusService) GetTimeline(objectID uint) ([]dto.ObjectStatusTransitionView, error) {
+	timeline, err := service.objectStatusRepo.GetTimeline(objectID)
+	if err != nil {
+		return []dto.ObjectStatusTransitionView{}, err
+	}
+
+	for index := range timeline {
+		timeline[index].FromStatusName = objectStatusName(timeline[index].FromStatus)
+		timeline[index].ToStatusName = objectStatusName(timeline[index].ToStatus)
+	}
+
+	return timeline, nil
+}
+
+func (service *objectStatusService) roleCanTransition(roleID uint, rule objectStatusTransitionRule) (bool, error) {
+	permission, err := service.permissionRepo.GetByResourceURL(rule.resourceURL, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	return permission.U, nil
+}
+
+// Objects with status that is not part of the lifecycle (e.g. imported before
+// the lifecycle was introduced) can only be moved to the default status
+func objectStatusTransitionRuleFor(from, to string) (objectStatusTransitionRule, bool) {
+	if !isObjectStatus(from) && to == defaultObjectStatus {
+		return objectStatusTransitionRule{from: from, to: to, resourceURL: "/object-status"}, true
+	}
+
+	for _, rule := range objectStatusTransitionRules {
+		if rule.from == from && rule.to == to {
+			return rule, true
+		}
+	}
+
+	return objectStatusTransitionRule{}, false
+}
+
+func isObjectStatus(status string) bool {
+	for _, objectStatus := range objectStatuses {
+		if objectStatus.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Status of the object is changed only through the status transitions, so the
+// status sent with the edited object is replaced by the stored one
+func withStoredObjectStatus(data, stored model.Object) model.Object {
+	data.Status = stored.Status
+	return data
+}
+
+// Whether the object with the status went through the given status of the lifecycle
+func objectStatusReached(status, reached string) bool {
+	statusIndex, reachedIndex := -1, -1
+	for index, objectStatus := range objectStatuses {
+		if objectStatus.Status == status {
+			statusIndex = index
+		}
+
+		if objectStatus.Status == reached {
+			reachedIndex = index
+		}
+	}
+
+	return statusIndex != -1 && reachedIndex != -1 && statusIndex >= reachedIndex
+}
+
+func objectStatusName(status string) string {
+	for _, objectStatus := range objectStatuses {
+		if objectStatus.Status == status {
+			return objectStatus.Name
+		}
+	}
+
+	return status
+}
+
+// Converts the status entered by user or written in the import file into the status of the lifecycle,
+// both the status and its name are accepted. Empty value means the default status
+func objectStatusFromInput(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultObjectStatus, nil
+	}
+
+	for _, objectStatus := range objectStatuses {
+		if objectStatus.Status == value || strings.EqualFold(objectStatus.Name, value) {
+			return objectStatus.Status, nil
+		}
+	}
+
+	return "", fmt.Errorf("неизвестный статус объекта '%s'", value)
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var objectAttributeDataTypes = map[string]string{
+	"string":  "Строка",
+	"number":  "Число",
+	"integer": "Целое число",
+	"boolean": "Да/Нет",
+}
+
+var objectTypeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type objectTypeDefinition struct {
+	code string
+	name string
+
+	// Table with the details of the builtin type, custom types keep their details as attribute values
+	detailTable  string
+	objectTypeID uint
+	attributeIDs map[string]uint
+
+	// Position of the type in the network from the source of the power to the consumer,
+	// an object can only feed objects of the types with greater level. 0 means the type is not part of the network
+	networkLevel uint
+
+	// Table that keeps the objects feeding the objects of the type and the types of these
+	// objects. Types without the table keep their feeders in network_connections and can be
+	// fed by any type of the lower level. Objects fed by the type are always kept in network_connections
+	fedByTable string
+	fedByTypes []string
+
+	// Attribute that is filled with the names of the objects fed by the object
+	feedsNamesAttribute string
+
+	attributes []dto.ObjectTypeAttributeView
+
+	// Additional validation of the parsed values, may normalize them
+	validate func(values map[string]interface{}) error
+}
+
+var builtinObjectTypes = []objectTypeDefinition{
+	{
+		code:         "substation_objects",
+		name:         "Подстанция",
+		detailTable:  "substation_objects",
+		networkLevel: 1,
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "voltage_class", Name: "Класс напряжения", DataType: "string", Required: true},
+			{Code: "number_of_transformers", Name: "Количество трансформаторов", DataType: "integer", Required: true},
+			{Code: "latitude", Name: "Широта", DataType: "number"},
+			{Code: "longitude", Name: "Долгота", DataType: "number"},
+		},
+		validate: validateObjectLocation,
+	},
+	{
+		code:         "substation_cell_objects",
+		name:         "Ячейка подстанции",
+		detailTable:  "substation_cell_objects",
+		networkLevel: 2,
+		fedByTable:   "substation_cell_nourashes_substation_objects",
+		fedByTypes:   []string{"substation_objects"},
+		attributes:   []dto.ObjectTypeAttributeView{},
+	},
+	{
+		code:         "tp_objects",
+		name:         "ТП",
+		detailTable:  "tp_objects",
+		networkLevel: 3,
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "model", Name: "Модель", DataType: "string", Required: true},
+			{Code: "voltage_class", Name: "Класс напряжения", DataType: "string", Required: true},
+			{Code: "latitude", Name: "Широта", DataType: "number"},
+			{Code: "longitude", Name: "Долгота", DataType: "number"},
+		},
+		validate: validateObjectLocation,
+	},
+	{
+		code:                "kl04kv_objects",
+		name:                "КЛ 04 КВ",
+		detailTable:         "kl04_kv_objects",
+		networkLevel:        4,
+		fedByTable:          "tp_nourashes_objects",
+		fedByTypes:          []string{"tp_objects"},
+		feedsNamesAttribute: "nourashes",
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "nourashes", Name: "Питает", DataType: "string"},
+			{Code: "length", Name: "Длина линии", DataType: "number", Required: true},
+			{Code: "route", Name: "Маршрут (широта,долгота; ...)", DataType: "string"},
+		},
+		validate: validateObjectRoute,
+	},
+	{
+		code:         "sip_objects",
+		name:         "СИП",
+		detailTable:  "s_ip_objects",
+		networkLevel: 4,
+		fedByTable:   "tp_nourashes_objects",
+		fedByTypes:   []string{"tp_objects"},
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "amount_feeders", Name: "Кол-во фидеров", DataType: "integer", Required: true},
+			{Code: "route", Name: "Маршрут (широта,долгота; ...)", DataType: "string"},
+		},
+		validate: validateObjectRoute,
+	},
+	{
+		code:         "stvt_objects",
+		name:         "СТВТ",
+		detailTable:  "stvt_objects",
+		networkLevel: 4,
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "voltage_class", Name: "Класс напряжения", DataType: "string", Required: true},
+			{Code: "tt_coefficient", Name: "ТТ Коэффицент", DataType: "string", Required: true},
+		},
+	},
+	{
+		code:         "mjd_objects",
+		name:         "МЖД",
+		detailTable:  "mjd_objects",
+		networkLevel: 5,
+		fedByTable:   "tp_nourashes_objects",
+		fedByTypes:   []string{"tp_objects"},
+		attributes: []dto.ObjectTypeAttributeView{
+			{Code: "model", Name: "Тип", DataType: "string", Required: true},
+			{Code: "amount_entrances", Name: "Кол-во подъездов", DataType: "integer", Required: true},
+			{Code: "amount_stores", Name: "Кол-во этажей", DataType: "integer", Required: true},
+			{Code: "has_basement", Name: "Наличие подвала", DataType: "boolean"},
+			{Code: "latitude", Name: "Широта", DataType: "number"},
+			{Code: "longitude", Name: "Долгота", DataType: "number"},
+		},
+		validate: validateObjectLocation,
+	},
+}
+
+func validateObjectLocation(values map[string]interface{}) error {
+	if err := validateCoordinates(values["latitude"].(float64), values["longitude"].(float64)); err != nil {
+		return fmt.Errorf("Неправильные координаты объекта: %v", err)
+	}
+
+	return nil
+}
+
+func validateObjectRoute(values map[string]interface{}) error {
+	route, err := normalizeRoute(values["route"].(string))
+	if err != nil {
+		return fmt.Errorf("Неправильный маршрут линии: %v", err)
+	}
+
+	values["route"] = route
+	return nil
+}
+
+// Builtin types followed by the types defined by the administrators
+func objectTypeDefinitions(objectTypeRepo repository.IObjectTypeRepository) ([]objectTypeDefinition, error) {
+	objectTypes, err := objectTypeRepo.GetAll()
+	if err != nil {
+		return []objectTypeDefinition{}, err
+	}
+
+	attributes, err := objectTypeRepo.GetAllAttributes()
+	if err != nil {
+		return []objectTypeDefinition{}, err
+	}
+
+	result := append([]objectTypeDefinition{}, builtinObjectTypes...)
+	for _, objectType := range objectTypes {
+		definition := objectTypeDefinition{
+			code:         objectType.Code,
+			name:         objectType.Name,
+			objectTypeID: objectType.ID,
+			attributeIDs: map[string]uint{},
+			networkLevel: objectType.NetworkLevel,
+			attributes:   []dto.ObjectTypeAttributeView{},
+		}
+
+		for _, attribute := range attributes {
+			if attribute.ObjectTypeID != objectType.ID {
+				continue
+			}
+
+			definition.attributeIDs[attribute.Code] = attribute.ID
+			definition.attributes = append(definition.attributes, dto.ObjectTypeAttributeView{
+				Code:     attribute.Code,
+				Name:     attribute.Name,
+				DataType: attribute.DataType,
+				Required: attribute.Required,
+			})
+		}
+
+		result = append(result, definition)
+	}
+
+	return result, nil
+}
+
+// Feeders of the lower network level, limited to fedByTypes when the type declares them
+func (definition objectTypeDefinition) canBeFedBy(feederType string, levels map[string]uint) bool {
+	feederLevel, inNetwork := levels[feederType]
+	if !inNetwork || definition.networkLevel == 0 || feederLevel >= definition.networkLevel {
+		return false
+	}
+
+	if len(definition.fedByTypes) == 0 {
+		return true
+	}
+
+	for _, fedByType := range definition.fedByTypes {
+		if fedByType == feederType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func objectTypeDefinitionByCode(objectTypeRepo repository.IObjectTypeRepository, code string) (objectTypeDefinition, error) {
+	definitions, err := objectTypeDefinitions(objectTypeRepo)
+	if err != nil {
+		return objectTypeDefinition{}, err
+	}
+
+	for _, definition := range definitions {
+		if definition.code == code {
+			return definition, nil
+		}
+	}
+
+	return objectTypeDefinition{}, fmt.Errorf("Тип объекта '%s' не найден", code)
+}
+
+func objectNetworkLevels(objectTypeRepo repository.IObjectTypeRepository) (map[string]uint, error) {
+	definitions, err := objectTypeDefinitions(objectTypeRepo)
+	if err != nil {
+		return map[string]uint{}, err
+	}
+
+	result := map[string]uint{}
+	for _, definition := range definitions {
+		if definition.networkLevel != 0 {
+			result[definition.code] = definition.networkLevel
+		}
+	}
+
+	return result, nil
+}
+
+// Names of builtin and custom types by their codes
+func objectTypeNames(objectTypeRepo repository.IObjectTypeRepository) (map[string]string, error) {
+	definitions, err := objectTypeDefinitions(objectTypeRepo)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	result := map[string]string{}
+	for _, definition := range definitions {
+		result[definition.code] = definition.name
+	}
+
+	return result, nil
+}
+
+func isBuiltinObjectType(code string) bool {
+	for _, definition := range builtinObjectTypes {
+		if definition.code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Name of the builtin type for the reports
+func objectTypeName(code string) string {
+	for _, definition := range builtinObjectTypes {
+		if definition.code == code {
+			return definition.name
+		}
+	}
+
+	return "Другое"
+}
+
+func objectTypeView(definition objectTypeDefinition) dto.ObjectTypeView {
+	return dto.ObjectTypeView{
+		Code:         definition.code,
+		Name:         definition.name,
+		Builtin:      definition.detailTable != "",
+		NetworkLevel: definition.networkLevel,
+		Attributes:   definition.attributes,
+	}
+}
+
+// Parses the values entered by user or written in the import file into the values of the attributes types,
+// missing values of not required attributes become zero values
+func objectValuesFromInput(definition objectTypeDefinition, rawValues map[string]string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, attribute := range definition.attributes {
+		value, err := objectAttributeValueFromInput(attribute, rawValues[attribute.Code])
+		if err != nil {
+			return map[string]interface{}{}, err
+		}
+
+		values[attribute.Code] = value
+	}
+
+	if definition.validate != nil {
+		if err := definition.validate(values); err != nil {
+			return map[string]interface{}{}, err
+		}
+	}
+
+	return values, nil
+}
+
+func objectAttributeValueFromInput(attribute dto.ObjectTypeAttributeView, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if value == "" && attribute.Required {
+		return nil, fmt.Errorf("поле '%s' обязательно для заполнения", attribute.Name)
+	}
+
+	switch attribute.DataType {
+	case "number":
+		if value == "" {
+			return float64(0), nil
+		}
+
+		number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("поле '%s' должно быть числом, получено '%s'", attribute.Name, value)
+		}
+
+		return number, nil
+
+	case "integer":
+		if value == "" {
+			return uint(0), nil
+		}
+
+		number, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("поле '%s' должно быть целым неотрицательным числом, получено '%s'", attribute.Name, value)
+		}
+
+		return uint(number), nil
+
+	case "boolean":
+		switch strings.ToLower(value) {
+		case "", "false", "нет", "0":
+			return false, nil
+		case "true", "да", "1":
+			return true, nil
+		default:
+			return nil, fmt.Errorf("поле '%s' должно быть 'Да' или 'Нет', получено '%s'", attribute.Name, value)
+		}
+
+	default:
+		return value, nil
+	}
+}
+
+// Value as it is stored for the custom types and read back by objectAttributeValueFromInput
+func objectAttributeRawValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func objectAttributeDisplayValue(value interface{}) string {
+	if value, isBool := value.(bool); isBool {
+		if value {
+			return "Да"
+		}
+
+		return "Нет"
+	}
+
+	return objectAttributeRawValue(value)
+}
+
+func objectDetailsStorage(definition objectTypeDefinition, values map[string]interface{}) dto.ObjectDetailsStorage {
+	if definition.detailTable != "" {
+		return dto.ObjectDetailsStorage{
+			DetailTable:  definition.detailTable,
+			ColumnValues: values,
+		}
+	}
+
+	attributeValues := map[uint]string{}
+	for _, attribute := range definition.attributes {
+		attributeValues[definition.attributeIDs[attribute.Code]] = objectAttributeRawValue(values[attribute.Code])
+	}
+
+	return dto.ObjectDetailsStorage{
+		ObjectTypeID:    definition.objectTypeID,
+		AttributeValues: attributeValues,
+	}
+}
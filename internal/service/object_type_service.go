@@ -0,0 +1,855 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const objectTypeImportSheetName = "Импорт"
+
+type objectTypeService struct {
+	objectTypeRepo        repository.IObjectTypeRepository
+	objectRepo            repository.IObjectRepository
+	workerRepo            repository.IWorkerRepository
+	teamRepo              repository.ITeamRepository
+	objectSupervisorsRepo repository.IObjectSupervisorsRepository
+	objectTeamsRepo       repository.IObjectTeamsRepository
+	permissionRepo        repository.IPermissionRepository
+}
+
+func NewObjectTypeService(
+	objectTypeRepo repository.IObjectTypeRepository,
+	objectRepo repository.IObjectRepository,
+	workerRepo repository.IWorkerRepository,
+	teamRepo repository.ITeamRepository,
+	objectSupervisorsRepo repository.IObjectSupervisorsRepository,
+	objectTeamsRepo repository.IObjectTeamsRepository,
+	permissionRepo repository.IPermissionRepository,
+) IObjectTypeService {
+	return &objectTypeService{
+		objectTypeRepo:        objectTypeRepo,
+		objectRepo:            objectRepo,
+		workerRepo:            workerRepo,
+		teamRepo:              teamRepo,
+		objectSupervisorsRepo: objectSupervisorsRepo,
+		objectTeamsRepo:       objectTeamsRepo,
+		permissionRepo:        permissionRepo,
+	}
+}
+
+type IObjectTypeService interface {
+	GetAll() ([]dto.ObjectTypeView, error)
+	Create(roleID uint, data dto.ObjectTypeCreate) (dto.ObjectTypeView, error)
+	Delete(roleID uint, code string) error
+	GetObjectsPaginated(page, limit int, filter dto.TypedObjectSearchParameters) ([]dto.TypedObjectPaginated, error)
+	CountObjects(filter dto.TypedObjectSearchParameters) (int64, error)
+	GetAllObjects(projectID uint, objectType string) ([]dto.TypedObjectQuery, error)
+	GetObjectNamesForSearch(projectID uint, objectType string) ([]dto.DataForSelect[string], error)
+	CreateObject(projectID uint, objectType string, data dto.TypedObjectCreate) (model.Object, error)
+	UpdateObject(projectID uint, objectType string, data dto.TypedObjectCreate) (model.Object, error)
+	DeleteObject(projectID uint, objectType string, objectID uint) error
+	TemplateFile(projectID uint, objectType string) (string, error)
+	Import(projectID uint, objectType string, filePath string) error
+	Export(projectID uint, objectType string) (string, error)
+}
+
+func (service *objectTypeService) GetAll() ([]dto.ObjectTypeView, error) {
+	definitions, err := objectTypeDefinitions(service.objectTypeRepo)
+	if err != nil {
+		return []dto.ObjectTypeView{}, err
+	}
+
+	result := []dto.ObjectTypeView{}
+	for _, definition := range definitions {
+		result = append(result, objectTypeView(definition))
+	}
+
+	return result, nil
+}
+
+func (service *objectTypeService) Create(roleID uint, data dto.ObjectTypeCreate) (dto.ObjectTypeView, error) {
+	permission, err := service.permissionRepo.GetByResourceURL("/object-type", roleID)
+	if err != nil {
+		return dto.ObjectTypeView{}, err
+	}
+
+	if !permission.W {
+		return dto.ObjectTypeView{}, fmt.Errorf("Доступ запрещен: у вашей роли нет прав на создание типов объектов")
+	}
+
+	data.Code = strings.TrimSpace(data.Code)
+	data.Name = strings.TrimSpace(data.Name)
+	if !objectTypeCodePattern.MatchString(data.Code) {
+		return dto.ObjectTypeView{}, fmt.Errorf("Код типа должен состоять из строчных латинских букв, цифр и '_' и начинаться с буквы")
+	}
+
+	if data.Name == "" {
+		return dto.ObjectTypeView{}, fmt.Errorf("Укажите название типа объекта")
+	}
+
+	definitions, err := objectTypeDefinitions(service.objectTypeRepo)
+	if err != nil {
+		return dto.ObjectTypeView{}, err
+	}
+
+	maxNetworkLevel := uint(0)
+	for _, definition := range definitions {
+		if definition.code == data.Code {
+			return dto.ObjectTypeView{}, fmt.Errorf("Тип объекта с кодом '%s' уже существует", data.Code)
+		}
+
+		if definition.networkLevel > maxNetworkLevel {
+			maxNetworkLevel = definition.networkLevel
+		}
+	}
+
+	// New type can become a new consumer level of the network but can not skip levels
+	if data.NetworkLevel > maxNetworkLevel+1 {
+		return dto.ObjectTypeView{}, fmt.Errorf("Уровень в сети должен быть от 0 до %d", maxNetworkLevel+1)
+	}
+
+	attributes := []model.ObjectTypeAttribute{}
+	attributeCodes := map[string]bool{}
+	for index, attribute := range data.Attributes {
+		attribute.Code = strings.TrimSpace(attribute.Code)
+		attribute.Name = strings.TrimSpace(attribute.Name)
+		if !objectTypeCodePattern.MatchString(attribute.Code) {
+			return dto.ObjectTypeView{}, fmt.Errorf("Код атрибута '%s' должен состоять из строчных латинских букв, цифр и '_' и начинаться с буквы", attribute.Code)
+		}
+
+		if attributeCodes[attribute.Code] {
+			return dto.ObjectTypeView{}, fmt.Errorf("Атрибут с кодом '%s' указан несколько раз", attribute.Code)
+		}
+		attributeCodes[attribute.Code] = true
+
+		if attribute.Name == "" {
+			return dto.ObjectTypeView{}, fmt.Errorf("Укажите название атрибута '%s'", attribute.Code)
+		}
+
+		if _, exists := objectAttributeDataTypes[attribute.DataType]; !exists {
+			return dto.ObjectTypeView{}, fmt.Errorf("Неизвестный тип данных '%s' у атрибута '%s'", attribute.DataType, attribute.Name)
+		}
+
+		attributes = append(attributes, model.ObjectTypeAttribute{
+			Code:        attribute.Code,
+			Name:        attribute.Name,
+			DataType:    attribute.DataType,
+			Required:    attribute.Required,
+			OrderNumber: uint(index + 1),
+		})
+	}
+
+	objectType, err := service.objectTypeRepo.Create(model.ObjectType{
+		Code:         data.Code,
+		Name:         data.Name,
+		NetworkLevel: data.NetworkLevel,
+	}, attributes)
+	if err != nil {
+		return dto.ObjectTypeView{}, err
+	}
+
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType.Code)
+	if err != nil {
+		return dto.ObjectTypeView{}, err
+	}
+
+	return objectTypeView(definition), nil
+}
+
+func (service *objectTypeService) Delete(roleID uint, code string) error {
+	permission, err := service.permissionRepo.GetByResourceURL("/object-type", roleID)
+	if err != nil {
+		return err
+	}
+
+	if !permission.D {
+		return fmt.Errorf("Доступ запрещен: у вашей роли нет прав на удаление типов объектов")
+	}
+
+	if isBuiltinObjectType(code) {
+		return fmt.Errorf("Встроенный тип объекта не может быть удален")
+	}
+
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, code)
+	if err != nil {
+		return err
+	}
+
+	count, err := service.objectTypeRepo.CountObjectsOfType(code)
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return fmt.Errorf("Невозможно удалить тип '%s': существует %d объект(ов) этого типа", definition.name, count)
+	}
+
+	return service.objectTypeRepo.Delete(definition.objectTypeID)
+}
+
+func (service *objectTypeService) GetObjectsPaginated(page, limit int, filter dto.TypedObjectSearchParameters) ([]dto.TypedObjectPaginated, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, filter.Type)
+	if err != nil {
+		return []dto.TypedObjectPaginated{}, err
+	}
+
+	objects, err := service.objectTypeRepo.GetObjectsPaginated(page, limit, filter)
+	if err != nil {
+		return []dto.TypedObjectPaginated{}, err
+	}
+
+	values, err := service.objectValues(definition, objects)
+	if err != nil {
+		return []dto.TypedObjectPaginated{}, err
+	}
+
+	result := []dto.TypedObjectPaginated{}
+	for _, object := range objects {
+		supervisorNames, err := service.objectSupervisorsRepo.GetSupervisorsNameByObjectID(object.ObjectID)
+		if err != nil {
+			return []dto.TypedObjectPaginated{}, err
+		}
+
+		teamNumbers, err := service.objectTeamsRepo.GetTeamsNumberByObjectID(object.ObjectID)
+		if err != nil {
+			return []dto.TypedObjectPaginated{}, err
+		}
+
+		fedByNames, err := service.objectTypeRepo.GetFedByNames(object.ObjectID, definition.code, definition.fedByTable)
+		if err != nil {
+			return []dto.TypedObjectPaginated{}, err
+		}
+
+		objectValues, exists := values[object.ObjectDetailedID]
+		if !exists {
+			objectValues = map[string]interface{}{}
+		}
+
+		result = append(result, dto.TypedObjectPaginated{
+			ObjectID:         object.ObjectID,
+			ObjectDetailedID: object.ObjectDetailedID,
+			Type:             definition.code,
+			Name:             object.Name,
+			Status:           object.Status,
+			StatusName:       objectStatusName(object.Status),
+			Values:           objectValues,
+			Supervisors:      supervisorNames,
+			Teams:            teamNumbers,
+			FedBy:            fedByNames,
+		})
+	}
+
+	return result, nil
+}
+
+func (service *objectTypeService) CountObjects(filter dto.TypedObjectSearchParameters) (int64, error) {
+	return service.objectTypeRepo.CountObjects(filter)
+}
+
+func (service *objectTypeService) GetAllObjects(projectID uint, objectType string) ([]dto.TypedObjectQuery, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return []dto.TypedObjectQuery{}, err
+	}
+
+	return service.objectTypeRepo.GetAllObjects(projectID, definition.code)
+}
+
+func (service *objectTypeService) GetObjectNamesForSearch(projectID uint, objectType string) ([]dto.DataForSelect[string], error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return []dto.DataForSelect[string]{}, err
+	}
+
+	return service.objectTypeRepo.GetObjectNamesForSearch(projectID, definition.code)
+}
+
+func (service *objectTypeService) CreateObject(projectID uint, objectType string, data dto.TypedObjectCreate) (model.Object, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return model.Object{}, fmt.Errorf("Укажите наименование объекта")
+	}
+
+	status, err := objectStatusFromInput(data.Status)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	feeding, rawValues, err := service.objectFeeding(projectID, 0, definition, data)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	values, err := objectValuesFromInput(definition, rawValues)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	if err := checkWorkerClearance(service.workerRepo, "supervisor", data.Supervisors); err != nil {
+		return model.Object{}, err
+	}
+
+	return service.objectTypeRepo.CreateObject(model.Object{
+		ProjectID: projectID,
+		Type:      definition.code,
+		Name:      data.Name,
+		Status:    status,
+	}, objectDetailsStorage(definition, values), data.Supervisors, data.Teams, feeding)
+}
+
+func (service *objectTypeService) UpdateObject(projectID uint, objectType string, data dto.TypedObjectCreate) (model.Object, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	object, err := service.typedObject(projectID, definition, data.ObjectID)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	object.Name = strings.TrimSpace(data.Name)
+	if object.Name == "" {
+		return model.Object{}, fmt.Errorf("Укажите наименование объекта")
+	}
+
+	feeding, rawValues, err := service.objectFeeding(projectID, object.ID, definition, data)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	values, err := objectValuesFromInput(definition, rawValues)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	if err := checkWorkerClearance(service.workerRepo, "supervisor", data.Supervisors); err != nil {
+		return model.Object{}, err
+	}
+
+	err = service.objectTypeRepo.UpdateObject(object, objectDetailsStorage(definition, values), data.Supervisors, data.Teams, feeding)
+	return object, err
+}
+
+func (service *objectTypeService) DeleteObject(projectID uint, objectType string, objectID uint) error {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return err
+	}
+
+	object, err := service.typedObject(projectID, definition, objectID)
+	if err != nil {
+		return err
+	}
+
+	return service.objectTypeRepo.DeleteObject(object, definition.detailTable)
+}
+
+func (service *objectTypeService) TemplateFile(projectID uint, objectType string) (string, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := objectTypeWorkbook(definition)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	allSupervisors, err := service.workerRepo.GetByJobTitleInProject("Супервайзер", projectID)
+	if err != nil {
+		return "", fmt.Errorf("Данные супервайзеров недоступны: %v", err)
+	}
+
+	for index, supervisor := range allSupervisors {
+		f.SetCellStr("Супервайзеры", "A"+fmt.Sprint(index+2), supervisor.Name)
+	}
+
+	allTeams, err := service.teamRepo.GetAll(projectID)
+	if err != nil {
+		return "", fmt.Errorf("Данны бригад не доступны: %v", err)
+	}
+
+	for index, team := range allTeams {
+		f.SetCellStr("Бригады", "A"+fmt.Sprint(index+2), team.Number)
+	}
+
+	allObjects, err := service.objectRepo.GetAll(projectID)
+	if err != nil {
+		return "", fmt.Errorf("Данные объектов недоступны: %v", err)
+	}
+
+	levels, err := objectNetworkLevels(service.objectTypeRepo)
+	if err != nil {
+		return "", err
+	}
+
+	feederRow := 2
+	for _, object := range allObjects {
+		if definition.canBeFedBy(object.Type, levels) {
+			f.SetCellStr("Питающие объекты", "A"+fmt.Sprint(feederRow), object.Name)
+			feederRow++
+		}
+	}
+
+	currentTime := time.Now()
+	temporaryFileName := fmt.Sprintf(
+		"Шаблон импорта объектов %s - %s.xlsx",
+		definition.code,
+		currentTime.Format("02-01-2006"),
+	)
+	temporaryFilePath := filepath.Join("./pkg/excels/temp/", temporaryFileName)
+	if err := f.SaveAs(temporaryFilePath); err != nil {
+		return "", fmt.Errorf("Не удалось обновить шаблон с новыми данными: %v", err)
+	}
+
+	return temporaryFilePath, nil
+}
+
+func (service *objectTypeService) Import(projectID uint, objectType string, filePath string) error {
+	defer os.Remove(filePath)
+
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return err
+	}
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return fmt.Errorf("Не смог открыть файл: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(objectTypeImportSheetName)
+	if err != nil {
+		return fmt.Errorf("Не смог найти таблицу '%s': %v", objectTypeImportSheetName, err)
+	}
+
+	if len(rows) <= 1 {
+		return fmt.Errorf("Файл не имеет данных")
+	}
+
+	supervisorColumn := len(definition.attributes) + 3
+	teamColumn := len(definition.attributes) + 4
+	fedByColumn := len(definition.attributes) + 5
+	objects := []dto.TypedObjectImportData{}
+	for index, row := range rows[1:] {
+		rowNumber := index + 2
+		cell := func(column int) string {
+			if column > len(row) {
+				return ""
+			}
+
+			return strings.TrimSpace(row[column-1])
+		}
+
+		name := cell(1)
+		if name == "" {
+			return fmt.Errorf("Ошибка в файле, не указано наименование в ячейке A%d", rowNumber)
+		}
+
+		status, err := objectStatusFromInput(cell(2))
+		if err != nil {
+			return fmt.Errorf("Ошибка в файле, неправильные данные в ячейке B%d: %v", rowNumber, err)
+		}
+
+		cellValues := map[string]interface{}{}
+		for attributeIndex, attribute := range definition.attributes {
+			cellValues[attribute.Code] = cell(attributeIndex + 3)
+		}
+
+		fedBy := []uint{}
+		for _, feederName := range splitImportCell(cell(fedByColumn)) {
+			feeder, err := service.objectTypeRepo.GetObjectByName(projectID, feederName)
+			if err != nil || feeder.ID == 0 {
+				return fmt.Errorf("Ошибка в файле, питающий объект '%s' в строке %d не найден", feederName, rowNumber)
+			}
+
+			fedBy = append(fedBy, feeder.ID)
+		}
+
+		feeding, rawValues, err := service.objectFeeding(projectID, 0, definition, dto.TypedObjectCreate{
+			Values: cellValues,
+			FedBy:  fedBy,
+		})
+		if err != nil {
+			return fmt.Errorf("Ошибка в файле, неправильные данные в строке %d: %v", rowNumber, err)
+		}
+
+		values, err := objectValuesFromInput(definition, rawValues)
+		if err != nil {
+			return fmt.Errorf("Ошибка в файле, неправильные данные в строке %d: %v", rowNumber, err)
+		}
+
+		supervisors := []uint{}
+		for _, supervisorName := range splitImportCell(cell(supervisorColumn)) {
+			supervisorWorker, err := service.workerRepo.GetByName(supervisorName)
+			if err != nil {
+				return fmt.Errorf("Ошибка в файле, супервайзер '%s' в строке %d не найден: %v", supervisorName, rowNumber, err)
+			}
+
+			supervisors = append(supervisors, supervisorWorker.ID)
+		}
+
+		teams := []uint{}
+		for _, teamNumber := range splitImportCell(cell(teamColumn)) {
+			team, err := service.teamRepo.GetByNumber(teamNumber)
+			if err != nil || team.ID == 0 {
+				return fmt.Errorf("Ошибка в файле, бригада '%s' в строке %d не найдена", teamNumber, rowNumber)
+			}
+
+			teams = append(teams, team.ID)
+		}
+
+		objects = append(objects, dto.TypedObjectImportData{
+			Name:        name,
+			Status:      status,
+			Details:     objectDetailsStorage(definition, values),
+			Supervisors: uniqueObjectIDs(supervisors),
+			Teams:       uniqueObjectIDs(teams),
+			Feeding:     feeding,
+		})
+	}
+
+	supervisorWorkerIDs := []uint{}
+	for _, object := range objects {
+		supervisorWorkerIDs = append(supervisorWorkerIDs, object.Supervisors...)
+	}
+	if err := checkWorkerClearance(service.workerRepo, "supervisor", supervisorWorkerIDs); err != nil {
+		return err
+	}
+
+	return service.objectTypeRepo.CreateObjectsInBatches(projectID, definition.code, objects)
+}
+
+func (service *objectTypeService) Export(projectID uint, objectType string) (string, error) {
+	definition, err := objectTypeDefinitionByCode(service.objectTypeRepo, objectType)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := objectTypeWorkbook(definition)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	filter := dto.TypedObjectSearchParameters{
+		ProjectID: projectID,
+		Type:      definition.code,
+	}
+	objectCount, err := service.objectTypeRepo.CountObjects(filter)
+	if err != nil {
+		return "", err
+	}
+
+	startingRow := 2
+	limit := 100
+	page := 1
+	for objectCount > 0 {
+		objects, err := service.GetObjectsPaginated(page, limit, filter)
+		if err != nil {
+			return "", err
+		}
+
+		for index, object := range objects {
+			row := fmt.Sprint(startingRow + index)
+			f.SetCellStr(objectTypeImportSheetName, "A"+row, object.Name)
+			f.SetCellStr(objectTypeImportSheetName, "B"+row, object.StatusName)
+			for attributeIndex, attribute := range definition.attributes {
+				column, _ := excelize.ColumnNumberToName(attributeIndex + 3)
+				f.SetCellStr(objectTypeImportSheetName, column+row, objectAttributeDisplayValue(object.Values[attribute.Code]))
+			}
+
+			supervisorColumn, _ := excelize.ColumnNumberToName(len(definition.attributes) + 3)
+			f.SetCellStr(objectTypeImportSheetName, supervisorColumn+row, strings.Join(object.Supervisors, ", "))
+
+			teamColumn, _ := excelize.ColumnNumberToName(len(definition.attributes) + 4)
+			f.SetCellStr(objectTypeImportSheetName, teamColumn+row, strings.Join(object.Teams, ", "))
+
+			fedByColumn, _ := excelize.ColumnNumberToName(len(definition.attributes) + 5)
+			f.SetCellStr(objectTypeImportSheetName, fedByColumn+row, strings.Join(object.FedBy, ", "))
+		}
+
+		startingRow = page*limit + 2
+		page++
+		objectCount -= int64(limit)
+	}
+
+	currentTime := time.Now()
+	exportFileName := fmt.Sprintf(
+		"Экспорт объектов %s - %s.xlsx",
+		definition.code,
+		currentTime.Format("02-01-2006"),
+	)
+	exportFilePath := filepath.Join("./pkg/excels/temp/", exportFileName)
+	if err := f.SaveAs(exportFilePath); err != nil {
+		return "", err
+	}
+
+	return exportFileName, nil
+}
+
+func (service *objectTypeService) typedObject(projectID uint, definition objectTypeDefinition, objectID uint) (model.Object, error) {
+	object, err := service.objectRepo.GetByID(objectID)
+	if err != nil {
+		return model.Object{}, err
+	}
+
+	if object.ID == 0 || object.ProjectID != projectID || object.Type != definition.code {
+		return model.Object{}, fmt.Errorf("Объект типа '%s' с ID %d не найден", definition.name, objectID)
+	}
+
+	return object, nil
+}
+
+// Values of the attributes of the objects grouped by ObjectDetailedID
+func (service *objectTypeService) objectValues(definition objectTypeDefinition, objects []dto.TypedObjectQuery) (map[uint]map[string]interface{}, error) {
+	result := map[uint]map[string]interface{}{}
+	detailedIDs := []uint{}
+	for _, object := range objects {
+		detailedIDs = append(detailedIDs, object.ObjectDetailedID)
+		result[object.ObjectDetailedID] = map[string]interface{}{}
+	}
+
+	if len(detailedIDs) == 0 {
+		return result, nil
+	}
+
+	if definition.detailTable != "" {
+		columns := []string{}
+		for _, attribute := range definition.attributes {
+			columns = append(columns, attribute.Code)
+		}
+
+		rows, err := service.objectTypeRepo.GetColumnValues(definition.detailTable, columns, detailedIDs)
+		if err != nil {
+			return result, err
+		}
+
+		for _, row := range rows {
+			detailedID, err := strconv.ParseUint(fmt.Sprint(row["id"]), 10, 64)
+			if err != nil {
+				return result, err
+			}
+
+			for _, column := range columns {
+				result[uint(detailedID)][column] = row[column]
+			}
+		}
+
+		return result, nil
+	}
+
+	attributeOfID := map[uint]dto.ObjectTypeAttributeView{}
+	for _, attribute := range definition.attributes {
+		attribute.Required = false
+		attributeOfID[definition.attributeIDs[attribute.Code]] = attribute
+	}
+
+	attributeValues, err := service.objectTypeRepo.GetCustomAttributeValues(detailedIDs)
+	if err != nil {
+		return result, err
+	}
+
+	for _, attributeValue := range attributeValues {
+		attribute, exists := attributeOfID[attributeValue.ObjectTypeAttributeID]
+		if !exists {
+			continue
+		}
+
+		value, err := objectAttributeValueFromInput(attribute, attributeValue.Value)
+		if err != nil {
+			return result, err
+		}
+
+		result[attributeValue.CustomObjectID][attribute.Code] = value
+	}
+
+	return result, nil
+}
+
+// Checks the feeders of the object and the objects fed by it against the network levels
+// of their types. Returns the relations to save and the raw values of the attributes,
+// where the attribute with the names of the fed objects is filled from the fed objects
+func (service *objectTypeService) objectFeeding(projectID, objectID uint, definition objectTypeDefinition, data dto.TypedObjectCreate) (dto.ObjectFeeding, map[string]string, error) {
+	rawValues := objectRawValues(data.Values)
+	feeding := dto.ObjectFeeding{
+		FedByTable: definition.fedByTable,
+		FedBy:      uniqueObjectIDs(data.FedBy),
+		Feeds:      uniqueObjectIDs(data.Feeds),
+	}
+
+	if len(feeding.FedBy) == 0 && len(feeding.Feeds) == 0 {
+		return feeding, rawValues, nil
+	}
+
+	if definition.networkLevel == 0 {
+		return dto.ObjectFeeding{}, rawValues, fmt.Errorf("Объекты типа '%s' не входят в электрическую сеть", definition.name)
+	}
+
+	levels, err := objectNetworkLevels(service.objectTypeRepo)
+	if err != nil {
+		return dto.ObjectFeeding{}, rawValues, err
+	}
+
+	feeders, err := service.projectObjects(projectID, objectID, feeding.FedBy)
+	if err != nil {
+		return dto.ObjectFeeding{}, rawValues, err
+	}
+
+	for _, feeder := range feeders {
+		if !definition.canBeFedBy(feeder.Type, levels) {
+			return dto.ObjectFeeding{}, rawValues, fmt.Errorf("Объект '%s' не может питать объект типа '%s'", feeder.Name, definition.name)
+		}
+	}
+
+	fedObjects, err := service.projectObjects(projectID, objectID, feeding.Feeds)
+	if err != nil {
+		return dto.ObjectFeeding{}, rawValues, err
+	}
+
+	fedNames := []string{}
+	for _, fedObject := range fedObjects {
+		if levels[fedObject.Type] <= definition.networkLevel {
+			return dto.ObjectFeeding{}, rawValues, fmt.Errorf("Объект типа '%s' не может питать объект '%s'", definition.name, fedObject.Name)
+		}
+
+		fedNames = append(fedNames, fedObject.Name)
+	}
+
+	if definition.feedsNamesAttribute != "" && feeding.Feeds != nil {
+		rawValues[definition.feedsNamesAttribute] = strings.Join(fedNames, ", ")
+	}
+
+	return feeding, rawValues, nil
+}
+
+// Objects of the project with the given IDs, the object itself can not be among them
+func (service *objectTypeService) projectObjects(projectID, objectID uint, ids []uint) ([]model.Object, error) {
+	if len(ids) == 0 {
+		return []model.Object{}, nil
+	}
+
+	objects, err := service.objectRepo.GetByRangeOfIDs(ids)
+	if err != nil {
+		return []model.Object{}, err
+	}
+
+	if len(objects) != len(ids) {
+		return []model.Object{}, fmt.Errorf("Не все указанные объекты найдены")
+	}
+
+	for _, object := range objects {
+		if object.ProjectID != projectID {
+			return []model.Object{}, fmt.Errorf("Объект '%s' относится к другому проекту", object.Name)
+		}
+
+		if object.ID == objectID {
+			return []model.Object{}, fmt.Errorf("Объект '%s' не может питать сам себя", object.Name)
+		}
+	}
+
+	return objects, nil
+}
+
+// Export joins the supervisors, teams and feeders of the object with ", ",
+// so the cells of the import file can list several of them
+func splitImportCell(value string) []string {
+	result := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
+
+// Keeps nil as nil so that omitted lists stay omitted
+func uniqueObjectIDs(ids []uint) []uint {
+	if ids == nil {
+		return nil
+	}
+
+	result := []uint{}
+	added := map[uint]bool{}
+	for _, id := range ids {
+		if !added[id] {
+			added[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// Values sent in the request body are converted to the same form as the values of the import file
+func objectRawValues(values map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for code, value := range values {
+		result[code] = objectAttributeRawValue(value)
+	}
+
+	return result
+}
+
+// Workbook with the import sheet of the object type and the sheets for supervisor and team lists
+func objectTypeWorkbook(definition objectTypeDefinition) (*excelize.File, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", objectTypeImportSheetName); err != nil {
+		return nil, err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Family: "Calibri", Size: 12},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []string{"Наимнование*", "Статус*"}
+	for _, attribute := range definition.attributes {
+		header := attribute.Name
+		if attribute.Required {
+			header += "*"
+		}
+
+		headers = append(headers, header)
+	}
+	headers = append(headers, "Супервайзер", "Бригада", "Питается от")
+
+	for index, header := range headers {
+		column, _ := excelize.ColumnNumberToName(index + 1)
+		f.SetCellStr(objectTypeImportSheetName, column+"1", header)
+		f.SetCellStyle(objectTypeImportSheetName, column+"1", column+"1", headerStyle)
+		f.SetColWidth(objectTypeImportSheetName, column, column, 20)
+	}
+
+	for _, sheetName := range []string{"Супервайзеры", "Бригады", "Питающие объекты"} {
+		if _, err := f.NewSheet(sheetName); err != nil {
+			return nil, err
+		}
+
+		f.SetCellStr(sheetName, "A1", sheetName)
+		f.SetCellStyle(sheetName, "A1", "A1", headerStyle)
+		f.SetColWidth(sheetName, "A", "A", 30)
+	}
+
+	return f, nil
+}
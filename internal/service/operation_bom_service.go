@@ -0,0 +1,236 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"math"
+)
+
+// Allowed difference in percent between the materials entered in the object invoice
+// and the bill of materials of its operations, bigger differences are recorded
+const bomDeviationTolerancePercent = 20.0
+
+type operationBOMService struct {
+	operationBOMRepo     repository.IOperationBOMRepository
+	operationRepo        repository.IOperationRepository
+	materialRepo         repository.IMaterialRepository
+	materialLocationRepo repository.IMaterialLocationRepository
+}
+
+func NewOperationBOMService(
+	operationBOMRepo repository.IOperationBOMRepository,
+	operationRepo repository.IOperationRepository,
+	materialRepo repository.IMaterialRepository,
+	materialLocationRepo repository.IMaterialLocationRepository,
+) IOperationBOMService {
+	return &operationBOMService{
+		operationBOMRepo:     operationBOMRepo,
+		operationRepo:        operationRepo,
+		materialRepo:         materialRepo,
+		materialLocationRepo: materialLocationRepo,
+	}
+}
+
+type IOperationBOMService interface {
+	GetByOperationID(operationID uint) ([]dto.OperationBOMItemView, error)
+	Replace(projectID uint, data dto.OperationBOMReplace) error
+	Requisition(projectID uint, data dto.OperationBOMRequisitionRequest) (dto.OperationBOMRequisition, error)
+	GetDeviations(invoiceObjectID uint) ([]dto.InvoiceObjectBOMDeviationView, error)
+}
+
+func (service *operationBOMService) GetByOperationID(operationID uint) ([]dto.OperationBOMItemView, error) {
+	return service.operationBOMRepo.GetByOperationID(operationID)
+}
+
+func (service *operationBOMService) Replace(projectID uint, data dto.OperationBOMReplace) error {
+	operation, err := service.operationRepo.GetByID(data.OperationID)
+	if err != nil {
+		return err
+	}
+
+	if operation.ID == 0 || operation.ProjectID != projectID {
+		return fmt.Errorf("Услуга с ID %d не найдена", data.OperationID)
+	}
+
+	bomItems := []model.OperationBOMItem{}
+	usedMaterials := map[uint]bool{}
+	for _, item := range data.Items {
+		if item.Amount <= 0 {
+			return fmt.Errorf("Количество материала в спецификации должно быть больше нуля")
+		}
+
+		if usedMaterials[item.MaterialID] {
+			return fmt.Errorf("Материал с ID %d указан в спецификации несколько раз", item.MaterialID)
+		}
+		usedMaterials[item.MaterialID] = true
+
+		material, err := service.materialRepo.GetByID(item.MaterialID)
+		if err != nil {
+			return err
+		}
+
+		if material.ID == 0 || material.ProjectID != projectID {
+			return fmt.Errorf("Материал с ID %d не найден", item.MaterialID)
+		}
+
+		bomItems = append(bomItems, model.OperationBOMItem{
+			ProjectID:   projectID,
+			OperationID: data.OperationID,
+			MaterialID:  item.MaterialID,
+			Amount:      item.Amount,
+		})
+	}
+
+	return service.operationBOMRepo.Replace(data.OperationID, bomItems)
+}
+
+func (service *operationBOMService) Requisition(projectID uint, data dto.OperationBOMRequisitionRequest) (dto.OperationBOMRequisition, error) {
+	for _, operation := range data.Operations {
+		if operation.Amount <= 0 {
+			return dto.OperationBOMRequisition{}, fmt.Errorf("Количество услуги должно быть больше нуля")
+		}
+	}
+
+	materialIDs, expectedAmounts, err := expectedBOMConsumption(service.operationBOMRepo, data.Operations)
+	if err != nil {
+		return dto.OperationBOMRequisition{}, err
+	}
+
+	result := dto.OperationBOMRequisition{
+		Materials:   []dto.OperationBOMRequisitionMaterial{},
+		OutputItems: []dto.InvoiceOutputItem{},
+	}
+	for _, materialID := range materialIDs {
+		material, err := service.materialRepo.GetByID(materialID)
+		if err != nil {
+			return dto.OperationBOMRequisition{}, err
+		}
+
+		amountInWarehouse, err := service.materialLocationRepo.GetTotalAmountInWarehouse(projectID, materialID)
+		if err != nil {
+			return dto.OperationBOMRequisition{}, err
+		}
+
+		amountInTeam := float64(0)
+		if data.TeamID != 0 {
+			amountInTeam, err = service.materialLocationRepo.GetTotalAmountInLocation(projectID, materialID, data.TeamID, "team")
+			if err != nil {
+				return dto.OperationBOMRequisition{}, err
+			}
+		}
+
+		amountToIssue := math.Max(expectedAmounts[materialID]-amountInTeam, 0)
+		result.Materials = append(result.Materials, dto.OperationBOMRequisitionMaterial{
+			MaterialID:        materialID,
+			MaterialName:      material.Name,
+			MaterialUnit:      material.Unit,
+			RequiredAmount:    expectedAmounts[materialID],
+			AmountInTeam:      amountInTeam,
+			AmountInWarehouse: amountInWarehouse,
+			AmountToIssue:     amountToIssue,
+		})
+
+		if amountToIssue > 0 {
+			result.OutputItems = append(result.OutputItems, dto.InvoiceOutputItem{
+				MaterialID:    materialID,
+				Amount:        amountToIssue,
+				SerialNumbers: []string{},
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (service *operationBOMService) GetDeviations(invoiceObjectID uint) ([]dto.InvoiceObjectBOMDeviationView, error) {
+	return service.operationBOMRepo.GetDeviationsByInvoiceObjectID(invoiceObjectID)
+}
+
+// Sums the bills of materials of the operations multiplied by the amount of each operation.
+// Material IDs are returned in the order they first appear in the bills of materials
+func expectedBOMConsumption(
+	operationBOMRepo repository.IOperationBOMRepository,
+	operations []dto.OperationBOMPlannedOperation,
+) ([]uint, map[uint]float64, error) {
+	operationIDs := []uint{}
+	operationAmounts := map[uint]float64{}
+	for _, operation := range operations {
+		if _, exists := operationAmounts[operation.OperationID]; !exists {
+			operationIDs = append(operationIDs, operation.OperationID)
+		}
+		operationAmounts[operation.OperationID] += operation.Amount
+	}
+
+	bomItems, err := operationBOMRepo.GetByOperationIDs(operationIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	materialIDs := []uint{}
+	expectedAmounts := map[uint]float64{}
+	for _, bomItem := range bomItems {
+		if _, exists := expectedAmounts[bomItem.MaterialID]; !exists {
+			materialIDs = append(materialIDs, bomItem.MaterialID)
+		}
+		expectedAmounts[bomItem.MaterialID] += bomItem.Amount * operationAmounts[bomItem.OperationID]
+	}
+
+	return materialIDs, expectedAmounts, nil
+}
+
+// Compares materials of the object invoice with the bills of materials of its operations
+// and returns the materials which differ by more than bomDeviationTolerancePercent.
+// Materials that are not part of any bill of materials are not checked
+func objectInvoiceBOMDeviations(
+	operationBOMRepo repository.IOperationBOMRepository,
+	projectID uint,
+	operations []dto.InvoiceObjectOperation,
+	items []dto.InvoiceObjectItem,
+) ([]model.InvoiceObjectBOMDeviation, error) {
+	plannedOperations := []dto.OperationBOMPlannedOperation{}
+	for _, operation := range operations {
+		plannedOperations = append(plannedOperations, dto.OperationBOMPlannedOperation{
+			OperationID: operation.OperationID,
+			Amount:      operation.Amount,
+		})
+	}
+
+	materialIDs, expectedAmounts, err := expectedBOMConsumption(operationBOMRepo, plannedOperations)
+	if err != nil {
+		return nil, err
+	}
+
+	actualAmounts := map[uint]float64{}
+	for _, item := range items {
+		if len(item.SerialNumbers) != 0 {
+			actualAmounts[item.MaterialID] += float64(len(item.SerialNumbers))
+		} else {
+			actualAmounts[item.MaterialID] += item.Amount
+		}
+	}
+
+	result := []model.InvoiceObjectBOMDeviation{}
+	for _, materialID := range materialIDs {
+		expectedAmount := expectedAmounts[materialID]
+		if expectedAmount == 0 {
+			continue
+		}
+
+		deviationPercent := (actualAmounts[materialID] - expectedAmount) / expectedAmount * 100
+		if math.Abs(deviationPercent) <= bomDeviationTolerancePercent {
+			continue
+		}
+
+		result = append(result, model.InvoiceObjectBOMDeviation{
+			ProjectID:        projectID,
+			MaterialID:       materialID,
+			ExpectedAmount:   expectedAmount,
+			ActualAmount:     actualAmounts[materialID],
+			DeviationPercent: math.Round(deviationPercent*100) / 100,
+		})
+	}
+
+	return result, nil
+}
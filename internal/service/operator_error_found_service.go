@@ -0,0 +1,94 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type operatorErrorFoundService struct {
+	operatorErrorFoundRepo repository.IOperatorErrorFoundRepository
+}
+
+func NewOperatorErrorFoundService(
+	operatorErrorFoundRepo repository.IOperatorErrorFoundRepository,
+) IOperatorErrorFoundService {
+	return &operatorErrorFoundService{
+		operatorErrorFoundRepo: operatorErrorFoundRepo,
+	}
+}
+
+type IOperatorErrorFoundService interface {
+	Report(filter dto.OperatorErrorFoundFilter) (string, error)
+	StatisticsBySupervisor(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+	StatisticsByTeam(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+	StatisticsByMaterial(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error)
+}
+
+func (service *operatorErrorFoundService) Report(filter dto.OperatorErrorFoundFilter) (string, error) {
+	errorsFound, err := service.operatorErrorFoundRepo.GetDataForReport(filter)
+	if err != nil {
+		return "", err
+	}
+
+	templateFilePath := filepath.Join("./pkg/excels/templates/", "Operator Errors Report.xlsx")
+	f, err := excelize.OpenFile(templateFilePath)
+	if err != nil {
+		return "", err
+	}
+	sheetName := "Sheet1"
+
+	rowCount := 2
+	for _, errorFound := range errorsFound {
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), errorFound.DeliveryCode)
+		f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), errorFound.ObjectName)
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), objectTypeName(errorFound.ObjectType))
+		f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), errorFound.SupervisorName)
+		f.SetCellStr(sheetName, "E"+fmt.Sprint(rowCount), errorFound.TeamNumber)
+		f.SetCellStr(sheetName, "F"+fmt.Sprint(rowCount), errorFound.OperatorName)
+		f.SetCellStr(sheetName, "G"+fmt.Sprint(rowCount), errorFound.DateOfInvoice.Format("02.01.2006"))
+		f.SetCellStr(sheetName, "H"+fmt.Sprint(rowCount), errorFound.DateOfCorrection.Format("02.01.2006"))
+		f.SetCellStr(sheetName, "I"+fmt.Sprint(rowCount), errorFound.MaterialName)
+		f.SetCellStr(sheetName, "J"+fmt.Sprint(rowCount), errorFound.MaterialUnit)
+		f.SetCellFloat(sheetName, "K"+fmt.Sprint(rowCount), errorFound.MaterialCostM19, 2, 64)
+		f.SetCellFloat(sheetName, "L"+fmt.Sprint(rowCount), errorFound.ExpectedAmount, 2, 64)
+		f.SetCellFloat(sheetName, "M"+fmt.Sprint(rowCount), errorFound.CorrectedAmount, 2, 64)
+		f.SetCellFloat(sheetName, "N"+fmt.Sprint(rowCount), errorFound.Amount, 2, 64)
+		f.SetCellFloat(sheetName, "O"+fmt.Sprint(rowCount), errorFound.Amount*errorFound.MaterialCostM19, 2, 64)
+		f.SetCellStr(sheetName, "P"+fmt.Sprint(rowCount), errorFound.Notes)
+		rowCount++
+	}
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"Отчет Ошибок Операторов - %s.xlsx",
+		currentTime.Format("02-01-2006"),
+	)
+
+	tempFilePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(tempFilePath); err != nil {
+		return "", err
+	}
+
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+	}
+
+	return fileName, nil
+}
+
+func (service *operatorErrorFoundService) StatisticsBySupervisor(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	return service.operatorErrorFoundRepo.StatisticsBySupervisor(filter)
+}
+
+func (service *operatorErrorFoundService) StatisticsByTeam(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	return service.operatorErrorFoundRepo.StatisticsByTeam(filter)
+}
+
+func (service *operatorErrorFoundService) StatisticsByMaterial(filter dto.OperatorErrorFoundFilter) ([]dto.OperatorErrorFoundStatistics, error) {
+	return service.operatorErrorFoundRepo.StatisticsByMaterial(filter)
+}
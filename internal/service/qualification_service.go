@@ -0,0 +1,377 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const qualificationStorageDirectory = "./pkg/qualifications/"
+
+var workerClearanceRoles = map[string]string{
+	"supervisor":  "супервайзером",
+	"team-leader": "бригадиром",
+}
+
+type qualificationService struct {
+	qualificationRepo repository.IQualificationRepository
+	workerRepo        repository.IWorkerRepository
+}
+
+func NewQualificationService(
+	qualificationRepo repository.IQualificationRepository,
+	workerRepo repository.IWorkerRepository,
+) IQualificationService {
+	return &qualificationService{
+		qualificationRepo: qualificationRepo,
+		workerRepo:        workerRepo,
+	}
+}
+
+type IQualificationService interface {
+	GetTypes(projectID uint) ([]model.QualificationType, error)
+	CreateType(data model.QualificationType) (model.QualificationType, error)
+	UpdateType(data model.QualificationType) (model.QualificationType, error)
+	DeleteType(projectID, id uint) error
+	GetByWorkerID(projectID, workerID uint) ([]dto.WorkerQualificationView, error)
+	Create(data model.WorkerQualification) (model.WorkerQualification, error)
+	Update(data model.WorkerQualification) (model.WorkerQualification, error)
+	Delete(projectID, id uint) error
+	UploadDocument(projectID, id uint, file *multipart.FileHeader) (model.WorkerQualification, error)
+	GetDocument(projectID, id uint) (dto.WorkerQualificationDocument, error)
+	GetExpiring(projectID uint, days int) ([]dto.WorkerQualificationView, error)
+	ExportExpiring(projectID uint, days int) (string, error)
+}
+
+func (service *qualificationService) GetTypes(projectID uint) ([]model.QualificationType, error) {
+	return service.qualificationRepo.GetTypes(projectID)
+}
+
+func (service *qualificationService) CreateType(data model.QualificationType) (model.QualificationType, error) {
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return model.QualificationType{}, fmt.Errorf("Название вида удостоверения не указано")
+	}
+
+	return service.qualificationRepo.CreateType(data)
+}
+
+func (service *qualificationService) UpdateType(data model.QualificationType) (model.QualificationType, error) {
+	qualificationType, err := service.qualificationRepo.GetTypeByID(data.ID)
+	if err != nil {
+		return model.QualificationType{}, err
+	}
+
+	if qualificationType.ID == 0 || qualificationType.ProjectID != data.ProjectID {
+		return model.QualificationType{}, fmt.Errorf("Вид удостоверения с ID %d не найден", data.ID)
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return model.QualificationType{}, fmt.Errorf("Название вида удостоверения не указано")
+	}
+
+	return service.qualificationRepo.UpdateType(data)
+}
+
+func (service *qualificationService) DeleteType(projectID, id uint) error {
+	count, err := service.qualificationRepo.CountByType(id)
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return fmt.Errorf("Вид удостоверения используется в %d удостоверениях работников и не может быть удален", count)
+	}
+
+	return service.qualificationRepo.DeleteType(projectID, id)
+}
+
+func (service *qualificationService) GetByWorkerID(projectID, workerID uint) ([]dto.WorkerQualificationView, error) {
+	if _, err := service.projectWorker(projectID, workerID); err != nil {
+		return []dto.WorkerQualificationView{}, err
+	}
+
+	data, err := service.qualificationRepo.GetByWorkerID(workerID)
+	if err != nil {
+		return []dto.WorkerQualificationView{}, err
+	}
+
+	setQualificationDaysLeft(data)
+	return data, nil
+}
+
+func (service *qualificationService) Create(data model.WorkerQualification) (model.WorkerQualification, error) {
+	if err := service.validate(data); err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	data.DocumentName = ""
+	data.DocumentMimeType = ""
+	data.DocumentPath = ""
+	return service.qualificationRepo.Create(data)
+}
+
+func (service *qualificationService) Update(data model.WorkerQualification) (model.WorkerQualification, error) {
+	qualification, err := service.projectQualification(data.ProjectID, data.ID)
+	if err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	if err := service.validate(data); err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	// Scanned document is changed only through the upload
+	data.DocumentName = qualification.DocumentName
+	data.DocumentMimeType = qualification.DocumentMimeType
+	data.DocumentPath = qualification.DocumentPath
+	return service.qualificationRepo.Update(data)
+}
+
+func (service *qualificationService) Delete(projectID, id uint) error {
+	qualification, err := service.projectQualification(projectID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := service.qualificationRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if qualification.DocumentPath != "" {
+		os.Remove(qualification.DocumentPath)
+	}
+
+	return nil
+}
+
+// Stores the scan of the certificate in place of the previous one,
+// the same size and type limits as for the attachments apply
+func (service *qualificationService) UploadDocument(projectID, id uint, file *multipart.FileHeader) (model.WorkerQualification, error) {
+	qualification, err := service.projectQualification(projectID, id)
+	if err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	upload, err := attachmentUploadFromFile(file)
+	if err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	directory := filepath.Join(qualificationStorageDirectory, fmt.Sprint(projectID))
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return model.WorkerQualification{}, fmt.Errorf("Не удалось создать папку для документов: %v", err)
+	}
+
+	documentPath := filepath.Join(directory, fmt.Sprintf("%d-%d%s", qualification.ID, time.Now().UnixNano(), attachmentMimeTypes[upload.attachment.MimeType]))
+	if err := os.WriteFile(documentPath, upload.content, 0644); err != nil {
+		return model.WorkerQualification{}, fmt.Errorf("Не удалось сохранить документ: %v", err)
+	}
+
+	previousDocumentPath := qualification.DocumentPath
+	qualification.DocumentName = upload.attachment.FileName
+	qualification.DocumentMimeType = upload.attachment.MimeType
+	qualification.DocumentPath = documentPath
+	qualification, err = service.qualificationRepo.Update(qualification)
+	if err != nil {
+		os.Remove(documentPath)
+		return model.WorkerQualification{}, err
+	}
+
+	if previousDocumentPath != "" {
+		os.Remove(previousDocumentPath)
+	}
+
+	return qualification, nil
+}
+
+func (service *qualificationService) GetDocument(projectID, id uint) (dto.WorkerQualificationDocument, error) {
+	qualification, err := service.projectQualification(projectID, id)
+	if err != nil {
+		return dto.WorkerQualificationDocument{}, err
+	}
+
+	if qualification.DocumentPath == "" {
+		return dto.WorkerQualificationDocument{}, fmt.Errorf("Документ удостоверения не загружен")
+	}
+
+	return dto.WorkerQualificationDocument{
+		FilePath: qualification.DocumentPath,
+		FileName: qualification.DocumentName,
+	}, nil
+}
+
+// Latest certificates that expire within the given number of days or have already expired
+func (service *qualificationService) GetExpiring(projectID uint, days int) ([]dto.WorkerQualificationView, error) {
+	if days <= 0 {
+		days = 30
+	}
+
+	data, err := service.qualificationRepo.GetExpiring(projectID, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return []dto.WorkerQualificationView{}, err
+	}
+
+	setQualificationDaysLeft(data)
+	return data, nil
+}
+
+func (service *qualificationService) ExportExpiring(projectID uint, days int) (string, error) {
+	data, err := service.GetExpiring(projectID, days)
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Удостоверения"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	expiredStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	headers := []interface{}{"Табельный номер", "ФИО", "Удостоверение", "Номер", "Группа / категория", "Дата выдачи", "Действительно до", "Осталось дней"}
+	f.SetSheetRow(sheetName, "A1", &headers)
+	f.SetCellStyle(sheetName, "A1", "H1", boldStyle)
+	f.SetColWidth(sheetName, "A", "A", 15)
+	f.SetColWidth(sheetName, "B", "C", 35)
+	f.SetColWidth(sheetName, "D", "H", 17)
+
+	for index, qualification := range data {
+		row := fmt.Sprint(index + 2)
+		values := []interface{}{
+			qualification.CompanyWorkerID,
+			qualification.WorkerName,
+			qualification.QualificationTypeName,
+			qualification.Number,
+			qualification.Grade,
+			qualification.IssuedAt.Format("02.01.2006"),
+			qualification.ExpiresAt.Format("02.01.2006"),
+			qualification.DaysLeft,
+		}
+		f.SetSheetRow(sheetName, "A"+row, &values)
+		if qualification.DaysLeft < 0 {
+			f.SetCellStyle(sheetName, "A"+row, "H"+row, expiredStyle)
+		}
+	}
+
+	fileName := fmt.Sprintf("Истекающие удостоверения - %s.xlsx", time.Now().Format("02-01-2006"))
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func (service *qualificationService) validate(data model.WorkerQualification) error {
+	if _, err := service.projectWorker(data.ProjectID, data.WorkerID); err != nil {
+		return err
+	}
+
+	qualificationType, err := service.qualificationRepo.GetTypeByID(data.QualificationTypeID)
+	if err != nil {
+		return err
+	}
+
+	if qualificationType.ID == 0 || qualificationType.ProjectID != data.ProjectID {
+		return fmt.Errorf("Вид удостоверения с ID %d не найден", data.QualificationTypeID)
+	}
+
+	if data.IssuedAt.IsZero() || data.ExpiresAt.IsZero() {
+		return fmt.Errorf("Даты выдачи и окончания действия удостоверения обязательны")
+	}
+
+	if !data.ExpiresAt.After(data.IssuedAt) {
+		return fmt.Errorf("Дата окончания действия должна быть позже даты выдачи")
+	}
+
+	return nil
+}
+
+func (service *qualificationService) projectWorker(projectID, workerID uint) (model.Worker, error) {
+	worker, err := service.workerRepo.GetByID(workerID)
+	if err != nil {
+		return model.Worker{}, err
+	}
+
+	if worker.ID == 0 || worker.ProjectID != projectID {
+		return model.Worker{}, fmt.Errorf("Работник с ID %d не найден", workerID)
+	}
+
+	return worker, nil
+}
+
+func (service *qualificationService) projectQualification(projectID, id uint) (model.WorkerQualification, error) {
+	qualification, err := service.qualificationRepo.GetByID(id)
+	if err != nil {
+		return model.WorkerQualification{}, err
+	}
+
+	if qualification.ID == 0 || qualification.ProjectID != projectID {
+		return model.WorkerQualification{}, fmt.Errorf("Удостоверение с ID %d не найдено", id)
+	}
+
+	return qualification, nil
+}
+
+func setQualificationDaysLeft(data []dto.WorkerQualificationView) {
+	now := time.Now()
+	for index := range data {
+		data[index].DaysLeft = int(data[index].ExpiresAt.Sub(now).Hours() / 24)
+	}
+}
+
+// Refuses to assign the workers as supervisors or team leaders (role "supervisor"
+// or "team-leader") when they lack a valid qualification required for the role
+func checkWorkerClearance(workerRepo repository.IWorkerRepository, role string, workerIDs []uint) error {
+	ids := []uint{}
+	for _, workerID := range workerIDs {
+		if workerID != 0 {
+			ids = append(ids, workerID)
+		}
+	}
+
+	issues, err := workerRepo.GetClearanceIssues(ids, role, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	descriptions := []string{}
+	for _, issue := range issues {
+		if issue.ExpiresAt.Year() <= 1 {
+			descriptions = append(descriptions, fmt.Sprintf("%s - нет удостоверения '%s'", issue.WorkerName, issue.QualificationTypeName))
+			continue
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%s - удостоверение '%s' истекло %s", issue.WorkerName, issue.QualificationTypeName, issue.ExpiresAt.Format("02.01.2006")))
+	}
+
+	return fmt.Errorf("Работник не может быть назначен %s: %s", workerClearanceRoles[role], strings.Join(descriptions, "; "))
+}
@@ -0,0 +1,643 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Longest period shown in the calendar, in days
+const scheduleCalendarMaxDays = 93
+
+var scheduleCalendarGroups = map[string]bool{
+	"team":     true,
+	"object":   true,
+	"district": true,
+}
+
+type scheduleService struct {
+	scheduleRepo   repository.IScheduleRepository
+	teamRepo       repository.ITeamRepository
+	objectRepo     repository.IObjectRepository
+	districtRepo   repository.IDistrictRepository
+	operationRepo  repository.IOperationRepository
+	teamMemberRepo repository.ITeamMemberRepository
+	projectRepo    repository.IProjectRepository
+}
+
+func NewScheduleService(
+	scheduleRepo repository.IScheduleRepository,
+	teamRepo repository.ITeamRepository,
+	objectRepo repository.IObjectRepository,
+	districtRepo repository.IDistrictRepository,
+	operationRepo repository.IOperationRepository,
+	teamMemberRepo repository.ITeamMemberRepository,
+	projectRepo repository.IProjectRepository,
+) IScheduleService {
+	return &scheduleService{
+		scheduleRepo:   scheduleRepo,
+		teamRepo:       teamRepo,
+		objectRepo:     objectRepo,
+		districtRepo:   districtRepo,
+		operationRepo:  operationRepo,
+		teamMemberRepo: teamMemberRepo,
+		projectRepo:    projectRepo,
+	}
+}
+
+type IScheduleService interface {
+	GetAssignments(filter dto.ScheduleFilter) ([]dto.TeamAssignmentView, error)
+	Create(projectID, userID uint, data dto.TeamAssignmentMutation) (model.TeamAssignment, error)
+	Update(projectID uint, data dto.TeamAssignmentMutation) (model.TeamAssignment, error)
+	Delete(projectID, id uint) error
+	GetCalendar(filter dto.ScheduleFilter, groupBy string) (dto.ScheduleCalendar, error)
+	GetConflicts(filter dto.ScheduleFilter) ([]dto.ScheduleConflict, error)
+	GetComparison(filter dto.ScheduleFilter) ([]dto.ScheduleComparison, error)
+	WorkOrder(projectID, teamID uint, date time.Time, format string) (string, error)
+}
+
+func (service *scheduleService) GetAssignments(filter dto.ScheduleFilter) ([]dto.TeamAssignmentView, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return []dto.TeamAssignmentView{}, fmt.Errorf("Период не указан")
+	}
+
+	assignments, err := service.scheduleRepo.GetAssignments(filter)
+	if err != nil {
+		return []dto.TeamAssignmentView{}, err
+	}
+
+	return service.assignmentViews(assignments)
+}
+
+func (service *scheduleService) Create(projectID, userID uint, data dto.TeamAssignmentMutation) (model.TeamAssignment, error) {
+	assignment, operations, err := service.validate(projectID, data)
+	if err != nil {
+		return model.TeamAssignment{}, err
+	}
+
+	assignment.CreatedByUserID = userID
+	return service.scheduleRepo.Create(assignment, operations)
+}
+
+func (service *scheduleService) Update(projectID uint, data dto.TeamAssignmentMutation) (model.TeamAssignment, error) {
+	existing, err := service.projectAssignment(projectID, data.ID)
+	if err != nil {
+		return model.TeamAssignment{}, err
+	}
+
+	assignment, operations, err := service.validate(projectID, data)
+	if err != nil {
+		return model.TeamAssignment{}, err
+	}
+
+	assignment.ID = existing.ID
+	assignment.CreatedByUserID = existing.CreatedByUserID
+	return service.scheduleRepo.Update(assignment, operations)
+}
+
+func (service *scheduleService) Delete(projectID, id uint) error {
+	if _, err := service.projectAssignment(projectID, id); err != nil {
+		return err
+	}
+
+	return service.scheduleRepo.Delete(id)
+}
+
+// Days of the period for every team, object or district with the assignments of the day.
+// Cell is marked as conflict when a team of the cell has more than one assignment on that day
+func (service *scheduleService) GetCalendar(filter dto.ScheduleFilter, groupBy string) (dto.ScheduleCalendar, error) {
+	if groupBy == "" {
+		groupBy = "team"
+	}
+
+	if !scheduleCalendarGroups[groupBy] {
+		return dto.ScheduleCalendar{}, fmt.Errorf("Неизвестная группировка: %s, допустимы team, object и district", groupBy)
+	}
+
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return dto.ScheduleCalendar{}, fmt.Errorf("Период не указан")
+	}
+
+	dateFrom := calendarDate(filter.DateFrom)
+	dateTo := calendarDate(filter.DateTo)
+	if dateTo.Before(dateFrom) {
+		return dto.ScheduleCalendar{}, fmt.Errorf("Начало периода позже его окончания")
+	}
+
+	if dateTo.Sub(dateFrom).Hours()/24 >= scheduleCalendarMaxDays {
+		return dto.ScheduleCalendar{}, fmt.Errorf("Период календаря не может быть больше %d дней", scheduleCalendarMaxDays)
+	}
+
+	assignments, err := service.GetAssignments(filter)
+	if err != nil {
+		return dto.ScheduleCalendar{}, err
+	}
+
+	dates := []time.Time{}
+	for date := dateFrom; !date.After(dateTo); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+
+	teamDayAssignments := map[string]int{}
+	for _, assignment := range assignments {
+		for date := calendarDate(assignment.DateFrom); !date.After(calendarDate(assignment.DateTo)); date = date.AddDate(0, 0, 1) {
+			teamDayAssignments[fmt.Sprintf("%d-%s", assignment.TeamID, date.Format("2006-01-02"))]++
+		}
+	}
+
+	result := dto.ScheduleCalendar{
+		GroupBy:     groupBy,
+		Dates:       dates,
+		Rows:        []dto.ScheduleCalendarRow{},
+		Assignments: assignments,
+	}
+
+	rowIndexes := map[uint]int{}
+	for _, assignment := range assignments {
+		rowID, rowName := assignment.TeamID, assignment.TeamNumber
+		switch groupBy {
+		case "object":
+			rowID, rowName = assignment.ObjectID, assignment.ObjectName
+		case "district":
+			rowID, rowName = assignment.DistrictID, assignment.DistrictName
+			if rowID == 0 {
+				rowName = "Участок не указан"
+			}
+		}
+
+		index, exists := rowIndexes[rowID]
+		if !exists {
+			cells := make([]dto.ScheduleCalendarCell, len(dates))
+			for cellIndex, date := range dates {
+				cells[cellIndex] = dto.ScheduleCalendarCell{
+					Date:          date,
+					AssignmentIDs: []uint{},
+				}
+			}
+
+			index = len(result.Rows)
+			rowIndexes[rowID] = index
+			result.Rows = append(result.Rows, dto.ScheduleCalendarRow{
+				ID:    rowID,
+				Name:  rowName,
+				Cells: cells,
+			})
+		}
+
+		for cellIndex, date := range dates {
+			if date.Before(calendarDate(assignment.DateFrom)) || date.After(calendarDate(assignment.DateTo)) {
+				continue
+			}
+
+			cell := &result.Rows[index].Cells[cellIndex]
+			cell.AssignmentIDs = append(cell.AssignmentIDs, assignment.ID)
+			if teamDayAssignments[fmt.Sprintf("%d-%s", assignment.TeamID, date.Format("2006-01-02"))] > 1 {
+				cell.Conflict = true
+			}
+		}
+	}
+
+	sort.SliceStable(result.Rows, func(i, j int) bool {
+		return result.Rows[i].Name < result.Rows[j].Name
+	})
+
+	return result, nil
+}
+
+// Pairs of assignments of the same team that share days within the period
+func (service *scheduleService) GetConflicts(filter dto.ScheduleFilter) ([]dto.ScheduleConflict, error) {
+	assignments, err := service.GetAssignments(filter)
+	if err != nil {
+		return []dto.ScheduleConflict{}, err
+	}
+
+	result := []dto.ScheduleConflict{}
+	for i := range assignments {
+		for j := i + 1; j < len(assignments); j++ {
+			first, second := assignments[i], assignments[j]
+			if first.TeamID != second.TeamID {
+				continue
+			}
+
+			dateFrom, dateTo := first.DateFrom, first.DateTo
+			if second.DateFrom.After(dateFrom) {
+				dateFrom = second.DateFrom
+			}
+
+			if second.DateTo.Before(dateTo) {
+				dateTo = second.DateTo
+			}
+
+			if dateFrom.After(dateTo) {
+				continue
+			}
+
+			result = append(result, dto.ScheduleConflict{
+				TeamID:     first.TeamID,
+				TeamNumber: first.TeamNumber,
+				DateFrom:   dateFrom,
+				DateTo:     dateTo,
+				First:      first,
+				Second:     second,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Planned operations of every assignment compared with the object invoices
+// of the team on the object during the days of the assignment
+func (service *scheduleService) GetComparison(filter dto.ScheduleFilter) ([]dto.ScheduleComparison, error) {
+	assignments, err := service.GetAssignments(filter)
+	if err != nil {
+		return []dto.ScheduleComparison{}, err
+	}
+
+	result := []dto.ScheduleComparison{}
+	for _, assignment := range assignments {
+		query := model.TeamAssignment{
+			ProjectID: filter.ProjectID,
+			TeamID:    assignment.TeamID,
+			ObjectID:  assignment.ObjectID,
+			DateFrom:  assignment.DateFrom,
+			DateTo:    assignment.DateTo,
+		}
+
+		actual, err := service.scheduleRepo.GetActualOperations(query)
+		if err != nil {
+			return []dto.ScheduleComparison{}, err
+		}
+
+		invoiceCount, err := service.scheduleRepo.CountInvoices(query)
+		if err != nil {
+			return []dto.ScheduleComparison{}, err
+		}
+
+		rows := []dto.ScheduleComparisonRow{}
+		rowIndexes := map[uint]int{}
+		for _, operation := range assignment.Operations {
+			rowIndexes[operation.OperationID] = len(rows)
+			rows = append(rows, dto.ScheduleComparisonRow{
+				OperationID: operation.OperationID,
+				Code:        operation.Code,
+				Name:        operation.Name,
+				Planned:     operation.Amount,
+			})
+		}
+
+		for _, operation := range actual {
+			index, exists := rowIndexes[operation.OperationID]
+			if !exists {
+				index = len(rows)
+				rowIndexes[operation.OperationID] = index
+				rows = append(rows, dto.ScheduleComparisonRow{
+					OperationID: operation.OperationID,
+					Code:        operation.Code,
+					Name:        operation.Name,
+				})
+			}
+
+			rows[index].Actual += operation.Amount
+		}
+
+		completion := 0.0
+		for index := range rows {
+			rows[index].Difference = rows[index].Actual - rows[index].Planned
+			if rows[index].Planned > 0 {
+				completion += math.Min(rows[index].Actual/rows[index].Planned, 1)
+			}
+		}
+
+		if len(assignment.Operations) != 0 {
+			completion = math.Round(completion/float64(len(assignment.Operations))*10000) / 100
+		}
+
+		result = append(result, dto.ScheduleComparison{
+			Assignment:        assignment,
+			InvoiceCount:      invoiceCount,
+			Rows:              rows,
+			CompletionPercent: completion,
+		})
+	}
+
+	return result, nil
+}
+
+// Work order of the team for one day with the objects and the planned operations
+func (service *scheduleService) WorkOrder(projectID, teamID uint, date time.Time, format string) (string, error) {
+	if format == "" {
+		format = "pdf"
+	}
+
+	if format != "xlsx" && format != "pdf" {
+		return "", fmt.Errorf("Неизвестный формат документа: %s, допустимы xlsx и pdf", format)
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+	date = calendarDate(date)
+
+	team, err := service.teamRepo.GetByID(teamID)
+	if err != nil {
+		return "", err
+	}
+
+	if team.ID == 0 || team.ProjectID != projectID {
+		return "", fmt.Errorf("Бригада с ID %d не найдена", teamID)
+	}
+
+	project, err := service.projectRepo.GetByID(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	assignments, err := service.GetAssignments(dto.ScheduleFilter{
+		ProjectID: projectID,
+		TeamID:    teamID,
+		DateFrom:  date,
+		DateTo:    date,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(assignments) == 0 {
+		return "", fmt.Errorf("У бригады %s нет заданий на %s", team.Number, date.Format("02.01.2006"))
+	}
+
+	leaders, err := service.teamRepo.GetTeamNumberAndTeamLeadersByID(projectID, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	leaderNames := []string{}
+	for _, leader := range leaders {
+		leaderNames = append(leaderNames, leader.TeamLeaderName)
+	}
+
+	members, err := service.teamMemberRepo.GetOnDate(projectID, teamID, date)
+	if err != nil {
+		return "", err
+	}
+
+	memberNames := []string{}
+	for _, member := range members {
+		memberNames = append(memberNames, member.WorkerName)
+	}
+
+	sheetName := "Наряд"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	titleStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 14},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	f.MergeCell(sheetName, "A1", "E1")
+	f.SetCellStr(sheetName, "A1", fmt.Sprintf("Наряд-задание бригады %s на %s", team.Number, date.Format("02.01.2006")))
+	f.SetCellStyle(sheetName, "A1", "E1", titleStyle)
+	f.SetCellStr(sheetName, "A3", "Проект: "+project.Name)
+	f.SetCellStr(sheetName, "A4", "Бригадир: "+strings.Join(leaderNames, ", "))
+	f.SetCellStr(sheetName, "A5", "Состав бригады: "+strings.Join(memberNames, ", "))
+	f.SetColWidth(sheetName, "A", "A", 6)
+	f.SetColWidth(sheetName, "B", "B", 15)
+	f.SetColWidth(sheetName, "C", "C", 50)
+	f.SetColWidth(sheetName, "D", "E", 15)
+
+	rowCount := 7
+	for _, assignment := range assignments {
+		object := "Объект: " + assignment.ObjectName
+		if assignment.DistrictName != "" {
+			object += ", участок: " + assignment.DistrictName
+		}
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), object)
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "A"+fmt.Sprint(rowCount), boldStyle)
+		rowCount++
+
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), fmt.Sprintf("Срок: %s - %s", assignment.DateFrom.Format("02.01.2006"), assignment.DateTo.Format("02.01.2006")))
+		rowCount++
+
+		if assignment.Notes != "" {
+			f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Примечание: "+assignment.Notes)
+			rowCount++
+		}
+
+		headers := []interface{}{"№", "Код", "Работа", "Количество", "Выполнено"}
+		f.SetSheetRow(sheetName, "A"+fmt.Sprint(rowCount), &headers)
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "E"+fmt.Sprint(rowCount), boldStyle)
+		rowCount++
+
+		for index, operation := range assignment.Operations {
+			values := []interface{}{index + 1, operation.Code, operation.Name, operation.Amount, ""}
+			f.SetSheetRow(sheetName, "A"+fmt.Sprint(rowCount), &values)
+			rowCount++
+		}
+
+		rowCount++
+	}
+
+	rowCount++
+	signatures := []string{"Выдал", "Получил (бригадир)"}
+	for _, signature := range signatures {
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), signature)
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), "_______________ / _______________________")
+		rowCount += 2
+	}
+
+	fileName := strings.NewReplacer("/", "-", "\\", "-").Replace(fmt.Sprintf("Наряд бригады %s - %s.xlsx", team.Number, date.Format("02-01-2006")))
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	if format == "xlsx" {
+		return fileName, nil
+	}
+
+	defer os.Remove(filePath)
+	pdfFilePath, err := convertExcelToPDF(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(pdfFilePath), nil
+}
+
+func (service *scheduleService) assignmentViews(assignments []dto.TeamAssignmentQueryResult) ([]dto.TeamAssignmentView, error) {
+	assignmentIDs := []uint{}
+	for _, assignment := range assignments {
+		assignmentIDs = append(assignmentIDs, assignment.ID)
+	}
+
+	operations, err := service.scheduleRepo.GetAssignmentOperations(assignmentIDs)
+	if err != nil {
+		return []dto.TeamAssignmentView{}, err
+	}
+
+	assignmentOperations := map[uint][]dto.TeamAssignmentOperationView{}
+	for _, operation := range operations {
+		assignmentOperations[operation.TeamAssignmentID] = append(assignmentOperations[operation.TeamAssignmentID], dto.TeamAssignmentOperationView{
+			OperationID: operation.OperationID,
+			Code:        operation.Code,
+			Name:        operation.Name,
+			Amount:      operation.Amount,
+		})
+	}
+
+	result := []dto.TeamAssignmentView{}
+	for _, assignment := range assignments {
+		views := assignmentOperations[assignment.ID]
+		if views == nil {
+			views = []dto.TeamAssignmentOperationView{}
+		}
+
+		result = append(result, dto.TeamAssignmentView{
+			ID:           assignment.ID,
+			TeamID:       assignment.TeamID,
+			TeamNumber:   assignment.TeamNumber,
+			ObjectID:     assignment.ObjectID,
+			ObjectName:   assignment.ObjectName,
+			ObjectType:   assignment.ObjectType,
+			DistrictID:   assignment.DistrictID,
+			DistrictName: assignment.DistrictName,
+			DateFrom:     assignment.DateFrom,
+			DateTo:       assignment.DateTo,
+			Notes:        assignment.Notes,
+			Operations:   views,
+		})
+	}
+
+	return result, nil
+}
+
+func (service *scheduleService) validate(projectID uint, data dto.TeamAssignmentMutation) (model.TeamAssignment, []model.TeamAssignmentOperation, error) {
+	team, err := service.teamRepo.GetByID(data.TeamID)
+	if err != nil {
+		return model.TeamAssignment{}, nil, err
+	}
+
+	if team.ID == 0 || team.ProjectID != projectID {
+		return model.TeamAssignment{}, nil, fmt.Errorf("Бригада с ID %d не найдена", data.TeamID)
+	}
+
+	object, err := service.objectRepo.GetByID(data.ObjectID)
+	if err != nil {
+		return model.TeamAssignment{}, nil, err
+	}
+
+	if object.ID == 0 || object.ProjectID != projectID {
+		return model.TeamAssignment{}, nil, fmt.Errorf("Объект с ID %d не найден", data.ObjectID)
+	}
+
+	if data.DistrictID != 0 {
+		district, err := service.districtRepo.GetByID(data.DistrictID)
+		if err != nil {
+			return model.TeamAssignment{}, nil, err
+		}
+
+		if district.ID == 0 || district.ProjectID != projectID {
+			return model.TeamAssignment{}, nil, fmt.Errorf("Участок с ID %d не найден", data.DistrictID)
+		}
+	}
+
+	if data.DateFrom.IsZero() || data.DateTo.IsZero() {
+		return model.TeamAssignment{}, nil, fmt.Errorf("Даты начала и окончания работ обязательны")
+	}
+
+	dateFrom := calendarDate(data.DateFrom)
+	dateTo := calendarDate(data.DateTo)
+	if dateTo.Before(dateFrom) {
+		return model.TeamAssignment{}, nil, fmt.Errorf("Дата окончания работ не может быть раньше даты начала")
+	}
+
+	operations := []model.TeamAssignmentOperation{}
+	added := map[uint]bool{}
+	for _, item := range data.Operations {
+		if added[item.OperationID] {
+			return model.TeamAssignment{}, nil, fmt.Errorf("Работа с ID %d указана несколько раз", item.OperationID)
+		}
+		added[item.OperationID] = true
+
+		operation, err := service.operationRepo.GetByID(item.OperationID)
+		if err != nil {
+			return model.TeamAssignment{}, nil, err
+		}
+
+		if operation.ID == 0 || operation.ProjectID != projectID {
+			return model.TeamAssignment{}, nil, fmt.Errorf("Работа с ID %d не найдена", item.OperationID)
+		}
+
+		if item.Amount <= 0 {
+			return model.TeamAssignment{}, nil, fmt.Errorf("Количество работы '%s' должно быть больше нуля", operation.Name)
+		}
+
+		operations = append(operations, model.TeamAssignmentOperation{
+			OperationID: item.OperationID,
+			Amount:      item.Amount,
+		})
+	}
+
+	if !data.AllowConflict {
+		overlapping, err := service.scheduleRepo.GetOverlapping(data.TeamID, dateFrom, dateTo, data.ID)
+		if err != nil {
+			return model.TeamAssignment{}, nil, err
+		}
+
+		if len(overlapping) != 0 {
+			conflicts := []string{}
+			for _, assignment := range overlapping {
+				conflicts = append(conflicts, fmt.Sprintf("%s (%s - %s)", assignment.ObjectName, assignment.DateFrom.Format("02.01.2006"), assignment.DateTo.Format("02.01.2006")))
+			}
+
+			return model.TeamAssignment{}, nil, fmt.Errorf("Бригада %s уже занята в эти дни: %s", team.Number, strings.Join(conflicts, "; "))
+		}
+	}
+
+	return model.TeamAssignment{
+		ProjectID:  projectID,
+		TeamID:     data.TeamID,
+		ObjectID:   data.ObjectID,
+		DistrictID: data.DistrictID,
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
+		Notes:      data.Notes,
+	}, operations, nil
+}
+
+func (service *scheduleService) projectAssignment(projectID, id uint) (model.TeamAssignment, error) {
+	assignment, err := service.scheduleRepo.GetByID(id)
+	if err != nil {
+		return model.TeamAssignment{}, err
+	}
+
+	if assignment.ID == 0 || assignment.ProjectID != projectID {
+		return model.TeamAssignment{}, fmt.Errorf("Задание с ID %d не найдено", id)
+	}
+
+	return assignment, nil
+}
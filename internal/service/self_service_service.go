@@ -0,0 +1,241 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type selfServiceService struct {
+	selfServiceRepo       repository.ISelfServiceRepository
+	workerRepo            repository.IWorkerRepository
+	teamMaterialAgingRepo repository.ITeamMaterialAgingRepository
+	qualificationRepo     repository.IQualificationRepository
+}
+
+func NewSelfServiceService(
+	selfServiceRepo repository.ISelfServiceRepository,
+	workerRepo repository.IWorkerRepository,
+	teamMaterialAgingRepo repository.ITeamMaterialAgingRepository,
+	qualificationRepo repository.IQualificationRepository,
+) ISelfServiceService {
+	return &selfServiceService{
+		selfServiceRepo:       selfServiceRepo,
+		workerRepo:            workerRepo,
+		teamMaterialAgingRepo: teamMaterialAgingRepo,
+		qualificationRepo:     qualificationRepo,
+	}
+}
+
+type ISelfServiceService interface {
+	GetProfile(projectID, workerID uint) (dto.SelfServiceProfile, error)
+	GetMaterials(projectID, workerID uint) ([]dto.SelfServiceTeamBalance, error)
+	GetInvoices(projectID, workerID uint, dateFrom, dateTo time.Time) ([]dto.SelfServiceInvoice, error)
+	GetAttendance(projectID, workerID uint, dateFrom, dateTo time.Time) (dto.SelfServiceAttendance, error)
+	GetQualifications(projectID, workerID uint, days int) ([]dto.WorkerQualificationView, error)
+}
+
+func (service *selfServiceService) GetProfile(projectID, workerID uint) (dto.SelfServiceProfile, error) {
+	if err := service.checkWorker(projectID, workerID); err != nil {
+		return dto.SelfServiceProfile{}, err
+	}
+
+	worker, err := service.workerRepo.GetByID(workerID)
+	if err != nil {
+		return dto.SelfServiceProfile{}, err
+	}
+
+	teams, err := service.selfServiceRepo.GetTeams(workerID, calendarDate(time.Now()))
+	if err != nil {
+		return dto.SelfServiceProfile{}, err
+	}
+
+	return dto.SelfServiceProfile{
+		WorkerID:          worker.ID,
+		Name:              worker.Name,
+		CompanyWorkerID:   worker.CompanyWorkerID,
+		JobTitleInCompany: worker.JobTitleInCompany,
+		JobTitleInProject: worker.JobTitleInProject,
+		MobileNumber:      worker.MobileNumber,
+		Teams:             teams,
+	}, nil
+}
+
+// Materials held by every team the worker leads or belongs to today,
+// serial numbers are listed under their materials
+func (service *selfServiceService) GetMaterials(projectID, workerID uint) ([]dto.SelfServiceTeamBalance, error) {
+	if err := service.checkWorker(projectID, workerID); err != nil {
+		return []dto.SelfServiceTeamBalance{}, err
+	}
+
+	teams, err := service.selfServiceRepo.GetTeams(workerID, calendarDate(time.Now()))
+	if err != nil {
+		return []dto.SelfServiceTeamBalance{}, err
+	}
+
+	result := []dto.SelfServiceTeamBalance{}
+	added := map[uint]bool{}
+	for _, team := range teams {
+		if added[team.TeamID] {
+			continue
+		}
+		added[team.TeamID] = true
+
+		filter := dto.TeamMaterialAgingFilter{
+			ProjectID: projectID,
+			TeamID:    team.TeamID,
+		}
+
+		held, err := service.teamMaterialAgingRepo.GetHeld(filter)
+		if err != nil {
+			return []dto.SelfServiceTeamBalance{}, err
+		}
+
+		serialNumbers, err := service.teamMaterialAgingRepo.GetSerialNumbers(filter)
+		if err != nil {
+			return []dto.SelfServiceTeamBalance{}, err
+		}
+
+		materialOfCost := map[uint]uint{}
+		materialIndex := map[uint]int{}
+		balance := dto.SelfServiceTeamBalance{
+			TeamID:     team.TeamID,
+			TeamNumber: team.TeamNumber,
+			Materials:  []dto.SelfServiceMaterial{},
+		}
+
+		for _, entry := range held {
+			materialOfCost[entry.MaterialCostID] = entry.MaterialID
+			index, exists := materialIndex[entry.MaterialID]
+			if !exists {
+				index = len(balance.Materials)
+				materialIndex[entry.MaterialID] = index
+				balance.Materials = append(balance.Materials, dto.SelfServiceMaterial{
+					MaterialID:    entry.MaterialID,
+					Code:          entry.Code,
+					Name:          entry.Name,
+					Unit:          entry.Unit,
+					SerialNumbers: []string{},
+				})
+			}
+
+			balance.Materials[index].Amount += entry.Amount
+		}
+
+		for _, serialNumber := range serialNumbers {
+			index, exists := materialIndex[materialOfCost[serialNumber.MaterialCostID]]
+			if !exists {
+				continue
+			}
+
+			balance.Materials[index].SerialNumbers = append(balance.Materials[index].SerialNumbers, serialNumber.Code)
+		}
+
+		result = append(result, balance)
+	}
+
+	return result, nil
+}
+
+func (service *selfServiceService) GetInvoices(projectID, workerID uint, dateFrom, dateTo time.Time) ([]dto.SelfServiceInvoice, error) {
+	if err := service.checkWorker(projectID, workerID); err != nil {
+		return []dto.SelfServiceInvoice{}, err
+	}
+
+	return service.selfServiceRepo.GetInvoices(projectID, workerID, dateFrom, dateTo)
+}
+
+// Attendance of the worker in the period, days without the exit punch are
+// listed with zero hours
+func (service *selfServiceService) GetAttendance(projectID, workerID uint, dateFrom, dateTo time.Time) (dto.SelfServiceAttendance, error) {
+	if err := service.checkWorker(projectID, workerID); err != nil {
+		return dto.SelfServiceAttendance{}, err
+	}
+
+	attendances, err := service.selfServiceRepo.GetAttendance(workerID, dateFrom, dateTo)
+	if err != nil {
+		return dto.SelfServiceAttendance{}, err
+	}
+
+	result := dto.SelfServiceAttendance{
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+		Days:     []dto.SelfServiceAttendanceDay{},
+	}
+
+	for _, attendance := range attendances {
+		hours := 0.0
+		if attendance.End.After(attendance.Start) {
+			hours = attendance.End.Sub(attendance.Start).Hours()
+		}
+
+		result.Days = append(result.Days, dto.SelfServiceAttendanceDay{
+			Start: attendance.Start,
+			End:   attendance.End,
+			Hours: hours,
+		})
+		result.TotalHours += hours
+	}
+
+	result.TotalDays = len(result.Days)
+	return result, nil
+}
+
+// Latest qualification of each type that expires within the given days
+// or has already expired
+func (service *selfServiceService) GetQualifications(projectID, workerID uint, days int) ([]dto.WorkerQualificationView, error) {
+	if err := service.checkWorker(projectID, workerID); err != nil {
+		return []dto.WorkerQualificationView{}, err
+	}
+
+	if days <= 0 {
+		days = 30
+	}
+
+	data, err := service.qualificationRepo.GetByWorkerID(workerID)
+	if err != nil {
+		return []dto.WorkerQualificationView{}, err
+	}
+
+	latest := map[uint]dto.WorkerQualificationView{}
+	for _, qualification := range data {
+		current, exists := latest[qualification.QualificationTypeID]
+		if !exists || qualification.ExpiresAt.After(current.ExpiresAt) {
+			latest[qualification.QualificationTypeID] = qualification
+		}
+	}
+
+	result := []dto.WorkerQualificationView{}
+	for _, qualification := range latest {
+		result = append(result, qualification)
+	}
+
+	setQualificationDaysLeft(result)
+	expiring := []dto.WorkerQualificationView{}
+	for _, qualification := range result {
+		if qualification.DaysLeft <= days {
+			expiring = append(expiring, qualification)
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresAt.Before(expiring[j].ExpiresAt)
+	})
+
+	return expiring, nil
+}
+
+func (service *selfServiceService) checkWorker(projectID, workerID uint) error {
+	if workerID == 0 {
+		return fmt.Errorf("Пользователь не привязан к работнику")
+	}
+
+	worker, err := service.workerRepo.GetByID(workerID)
+	if err != nil || worker.ProjectID != projectID {
+		return fmt.Errorf("Работник пользователя не найден в проекте")
+	}
+
+	return nil
+}
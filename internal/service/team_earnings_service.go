@@ -0,0 +1,551 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+type teamEarningsService struct {
+	teamEarningsRepo repository.ITeamEarningsRepository
+	projectRepo      repository.IProjectRepository
+	teamRepo         repository.ITeamRepository
+	workerRepo       repository.IWorkerRepository
+}
+
+func NewTeamEarningsService(
+	teamEarningsRepo repository.ITeamEarningsRepository,
+	projectRepo repository.IProjectRepository,
+	teamRepo repository.ITeamRepository,
+	workerRepo repository.IWorkerRepository,
+) ITeamEarningsService {
+	return &teamEarningsService{
+		teamEarningsRepo: teamEarningsRepo,
+		projectRepo:      projectRepo,
+		teamRepo:         teamRepo,
+		workerRepo:       workerRepo,
+	}
+}
+
+type ITeamEarningsService interface {
+	GetReport(filter dto.TeamEarningsFilter) (dto.TeamEarningsReport, error)
+	Export(filter dto.TeamEarningsFilter) (string, error)
+	CreateAdjustment(projectID, userID uint, data dto.TeamEarningsAdjustmentCreate) (model.TeamEarningAdjustment, error)
+	ChargeLosses(projectID, userID uint, filter dto.TeamEarningsFilter) ([]model.TeamEarningAdjustment, error)
+	DeleteAdjustment(projectID, id uint) error
+}
+
+func (service *teamEarningsService) GetReport(filter dto.TeamEarningsFilter) (dto.TeamEarningsReport, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return dto.TeamEarningsReport{}, fmt.Errorf("Период не указан")
+	}
+
+	if filter.SplitMode == "" {
+		filter.SplitMode = "equal"
+	}
+
+	if filter.SplitMode != "equal" && filter.SplitMode != "hours" {
+		return dto.TeamEarningsReport{}, fmt.Errorf("Неизвестный способ распределения: %s, допустимы equal и hours", filter.SplitMode)
+	}
+
+	project, err := service.projectRepo.GetByID(filter.ProjectID)
+	if err != nil {
+		return dto.TeamEarningsReport{}, err
+	}
+
+	operations, err := service.teamEarningsRepo.GetOperations(filter)
+	if err != nil {
+		return dto.TeamEarningsReport{}, err
+	}
+
+	adjustments, err := service.teamEarningsRepo.GetAdjustments(filter)
+	if err != nil {
+		return dto.TeamEarningsReport{}, err
+	}
+
+	members, err := service.teamEarningsRepo.GetMembers(filter.ProjectID, filter.TeamID, filter.DateFrom, filter.DateTo)
+	if err != nil {
+		return dto.TeamEarningsReport{}, err
+	}
+
+	// Hours of the worker in each team, a worker transferred during the period
+	// has hours in both teams
+	workerHours := map[uint]map[uint]float64{}
+	if filter.SplitMode == "hours" {
+		hours, err := service.teamEarningsRepo.GetWorkerHours(filter.ProjectID, filter.TeamID, filter.DateFrom, filter.DateTo)
+		if err != nil {
+			return dto.TeamEarningsReport{}, err
+		}
+
+		for _, entry := range hours {
+			if workerHours[entry.TeamID] == nil {
+				workerHours[entry.TeamID] = map[uint]float64{}
+			}
+
+			workerHours[entry.TeamID][entry.WorkerID] = math.Round(entry.Hours*100) / 100
+		}
+	}
+
+	result := dto.TeamEarningsReport{
+		ProjectName: project.Name,
+		DateFrom:    filter.DateFrom,
+		DateTo:      filter.DateTo,
+		SplitMode:   filter.SplitMode,
+		Teams:       []dto.TeamEarnings{},
+		Total:       decimal.Zero,
+		Warnings:    []string{},
+	}
+
+	teamIndexes := map[uint]int{}
+	teamOf := func(teamID uint, teamNumber string) (*dto.TeamEarnings, error) {
+		if index, exists := teamIndexes[teamID]; exists {
+			return &result.Teams[index], nil
+		}
+
+		if teamNumber == "" {
+			team, err := service.teamRepo.GetByID(teamID)
+			if err != nil {
+				return nil, err
+			}
+
+			teamNumber = team.Number
+		}
+
+		teamIndexes[teamID] = len(result.Teams)
+		result.Teams = append(result.Teams, dto.TeamEarnings{
+			TeamID:      teamID,
+			TeamNumber:  teamNumber,
+			Operations:  []dto.TeamEarningsOperation{},
+			Adjustments: []dto.TeamEarningsAdjustment{},
+			Members:     []dto.TeamEarningsMember{},
+			PieceRate:   decimal.Zero,
+			Bonuses:     decimal.Zero,
+			Deductions:  decimal.Zero,
+			Total:       decimal.Zero,
+		})
+
+		return &result.Teams[len(result.Teams)-1], nil
+	}
+
+	for _, operation := range operations {
+		team, err := teamOf(operation.TeamID, operation.TeamNumber)
+		if err != nil {
+			return dto.TeamEarningsReport{}, err
+		}
+
+		team.Operations = append(team.Operations, dto.TeamEarningsOperation{
+			OperationID: operation.OperationID,
+			Code:        operation.Code,
+			Name:        operation.Name,
+			Amount:      operation.Amount,
+			Price:       operation.Price,
+			Sum:         operation.Sum.Round(2),
+		})
+		team.PieceRate = team.PieceRate.Add(operation.Sum.Round(2))
+	}
+
+	teamMembers := map[uint][]dto.TeamEarningsMemberQueryResult{}
+	workerNames := map[uint]string{}
+	companyWorkerIDs := map[uint]string{}
+	for _, member := range members {
+		teamMembers[member.TeamID] = append(teamMembers[member.TeamID], member)
+		workerNames[member.WorkerID] = member.WorkerName
+		companyWorkerIDs[member.WorkerID] = member.CompanyWorkerID
+	}
+
+	for _, adjustment := range adjustments {
+		team, err := teamOf(adjustment.TeamID, "")
+		if err != nil {
+			return dto.TeamEarningsReport{}, err
+		}
+
+		if adjustment.WorkerID != 0 && workerNames[adjustment.WorkerID] == "" {
+			worker, err := service.workerRepo.GetByID(adjustment.WorkerID)
+			if err != nil {
+				return dto.TeamEarningsReport{}, err
+			}
+
+			workerNames[adjustment.WorkerID] = worker.Name
+			companyWorkerIDs[adjustment.WorkerID] = worker.CompanyWorkerID
+		}
+
+		team.Adjustments = append(team.Adjustments, dto.TeamEarningsAdjustment{
+			ID:                adjustment.ID,
+			WorkerID:          adjustment.WorkerID,
+			WorkerName:        workerNames[adjustment.WorkerID],
+			Date:              adjustment.Date,
+			Kind:              adjustment.Kind,
+			Amount:            adjustment.Amount,
+			Reason:            adjustment.Reason,
+			InvoiceWriteOffID: adjustment.InvoiceWriteOffID,
+		})
+
+		if adjustment.Kind == "bonus" {
+			team.Bonuses = team.Bonuses.Add(adjustment.Amount)
+		} else {
+			team.Deductions = team.Deductions.Add(adjustment.Amount)
+		}
+	}
+
+	for index := range result.Teams {
+		team := &result.Teams[index]
+		team.Total = team.PieceRate.Add(team.Bonuses).Sub(team.Deductions)
+		result.Total = result.Total.Add(team.Total)
+
+		teamBonuses := decimal.Zero
+		teamDeductions := decimal.Zero
+		workerBonuses := map[uint]decimal.Decimal{}
+		workerDeductions := map[uint]decimal.Decimal{}
+		for _, adjustment := range team.Adjustments {
+			switch {
+			case adjustment.WorkerID == 0 && adjustment.Kind == "bonus":
+				teamBonuses = teamBonuses.Add(adjustment.Amount)
+			case adjustment.WorkerID == 0:
+				teamDeductions = teamDeductions.Add(adjustment.Amount)
+			case adjustment.Kind == "bonus":
+				workerBonuses[adjustment.WorkerID] = workerBonuses[adjustment.WorkerID].Add(adjustment.Amount)
+			default:
+				workerDeductions[adjustment.WorkerID] = workerDeductions[adjustment.WorkerID].Add(adjustment.Amount)
+			}
+		}
+
+		if len(teamMembers[team.TeamID]) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("У бригады %s нет участников, заработок не распределен", team.TeamNumber))
+		} else {
+			weights := []float64{}
+			totalHours := 0.0
+			for _, member := range teamMembers[team.TeamID] {
+				weights = append(weights, workerHours[team.TeamID][member.WorkerID])
+				totalHours += workerHours[team.TeamID][member.WorkerID]
+			}
+
+			if filter.SplitMode == "equal" || totalHours == 0 {
+				if filter.SplitMode == "hours" {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("У участников бригады %s нет отработанных часов за период, заработок распределен поровну", team.TeamNumber))
+				}
+
+				for weightIndex := range weights {
+					weights[weightIndex] = 1
+				}
+			}
+
+			pieceRates := splitEarnings(team.PieceRate, weights)
+			bonuses := splitEarnings(teamBonuses, weights)
+			deductions := splitEarnings(teamDeductions, weights)
+			totalWeight := 0.0
+			for _, weight := range weights {
+				totalWeight += weight
+			}
+
+			for memberIndex, member := range teamMembers[team.TeamID] {
+				earnings := dto.TeamEarningsMember{
+					WorkerID:        member.WorkerID,
+					WorkerName:      member.WorkerName,
+					CompanyWorkerID: member.CompanyWorkerID,
+					Hours:           workerHours[team.TeamID][member.WorkerID],
+					SharePercent:    math.Round(weights[memberIndex]/totalWeight*10000) / 100,
+					PieceRate:       pieceRates[memberIndex],
+					Bonuses:         bonuses[memberIndex].Add(workerBonuses[member.WorkerID]),
+					Deductions:      deductions[memberIndex].Add(workerDeductions[member.WorkerID]),
+				}
+				earnings.Total = earnings.PieceRate.Add(earnings.Bonuses).Sub(earnings.Deductions)
+				team.Members = append(team.Members, earnings)
+			}
+		}
+
+		// Adjustments of the workers who are not members of the team in the period
+		// are listed in their own rows, so that the rows add up to the team total
+		listed := map[uint]bool{}
+		for _, member := range team.Members {
+			listed[member.WorkerID] = true
+		}
+
+		for _, adjustment := range team.Adjustments {
+			if adjustment.WorkerID == 0 || listed[adjustment.WorkerID] {
+				continue
+			}
+			listed[adjustment.WorkerID] = true
+
+			earnings := dto.TeamEarningsMember{
+				WorkerID:        adjustment.WorkerID,
+				WorkerName:      adjustment.WorkerName,
+				CompanyWorkerID: companyWorkerIDs[adjustment.WorkerID],
+				PieceRate:       decimal.Zero,
+				Bonuses:         workerBonuses[adjustment.WorkerID],
+				Deductions:      workerDeductions[adjustment.WorkerID],
+			}
+			earnings.Total = earnings.PieceRate.Add(earnings.Bonuses).Sub(earnings.Deductions)
+			team.Members = append(team.Members, earnings)
+		}
+	}
+
+	return result, nil
+}
+
+func (service *teamEarningsService) Export(filter dto.TeamEarningsFilter) (string, error) {
+	report, err := service.GetReport(filter)
+	if err != nil {
+		return "", err
+	}
+
+	payrollSheet := "Ведомость"
+	operationsSheet := "Работы"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", payrollSheet); err != nil {
+		return "", err
+	}
+
+	if _, err := f.NewSheet(operationsSheet); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	period := fmt.Sprintf("с %s по %s", report.DateFrom.Format("02.01.2006"), report.DateTo.Format("02.01.2006"))
+	splitModes := map[string]string{
+		"equal": "поровну",
+		"hours": "по отработанным часам",
+	}
+
+	f.SetCellStr(payrollSheet, "A1", "Ведомость сдельной оплаты бригад")
+	f.SetCellStyle(payrollSheet, "A1", "A1", boldStyle)
+	f.SetCellStr(payrollSheet, "A2", "Проект: "+report.ProjectName)
+	f.SetCellStr(payrollSheet, "A3", fmt.Sprintf("Период: %s, распределение %s", period, splitModes[report.SplitMode]))
+
+	headers := []interface{}{"№", "Бригада", "Табельный номер", "ФИО", "Часы", "Доля, %", "Сдельно", "Премии", "Удержания", "К выплате", "Подпись"}
+	f.SetSheetRow(payrollSheet, "A5", &headers)
+	f.SetCellStyle(payrollSheet, "A5", "K5", boldStyle)
+	f.SetColWidth(payrollSheet, "B", "C", 15)
+	f.SetColWidth(payrollSheet, "D", "D", 35)
+	f.SetColWidth(payrollSheet, "E", "J", 13)
+	f.SetColWidth(payrollSheet, "K", "K", 20)
+
+	rowCount := 6
+	number := 1
+	for _, team := range report.Teams {
+		for _, member := range team.Members {
+			values := []interface{}{
+				number,
+				team.TeamNumber,
+				member.CompanyWorkerID,
+				member.WorkerName,
+				member.Hours,
+				member.SharePercent,
+				member.PieceRate.InexactFloat64(),
+				member.Bonuses.InexactFloat64(),
+				member.Deductions.InexactFloat64(),
+				member.Total.InexactFloat64(),
+			}
+			f.SetSheetRow(payrollSheet, "A"+fmt.Sprint(rowCount), &values)
+			number++
+			rowCount++
+		}
+
+		values := []interface{}{
+			"",
+			"Итого по бригаде " + team.TeamNumber,
+			"", "", "", "",
+			team.PieceRate.InexactFloat64(),
+			team.Bonuses.InexactFloat64(),
+			team.Deductions.InexactFloat64(),
+			team.Total.InexactFloat64(),
+		}
+		f.SetSheetRow(payrollSheet, "A"+fmt.Sprint(rowCount), &values)
+		f.SetCellStyle(payrollSheet, "A"+fmt.Sprint(rowCount), "K"+fmt.Sprint(rowCount), boldStyle)
+		rowCount++
+	}
+
+	f.SetCellStr(payrollSheet, "B"+fmt.Sprint(rowCount), "Итого")
+	f.SetCellFloat(payrollSheet, "J"+fmt.Sprint(rowCount), report.Total.InexactFloat64(), 2, 64)
+	f.SetCellStyle(payrollSheet, "A"+fmt.Sprint(rowCount), "K"+fmt.Sprint(rowCount), boldStyle)
+
+	for index, warning := range report.Warnings {
+		f.SetCellStr(payrollSheet, "A"+fmt.Sprint(rowCount+2+index), warning)
+	}
+
+	operationHeaders := []interface{}{"Бригада", "Код", "Наименование", "Количество", "Расценка", "Сумма"}
+	f.SetSheetRow(operationsSheet, "A1", &operationHeaders)
+	f.SetCellStyle(operationsSheet, "A1", "F1", boldStyle)
+	f.SetColWidth(operationsSheet, "C", "C", 50)
+	rowCount = 2
+	for _, team := range report.Teams {
+		for _, operation := range team.Operations {
+			values := []interface{}{
+				team.TeamNumber,
+				operation.Code,
+				operation.Name,
+				operation.Amount,
+				operation.Price.InexactFloat64(),
+				operation.Sum.InexactFloat64(),
+			}
+			f.SetSheetRow(operationsSheet, "A"+fmt.Sprint(rowCount), &values)
+			rowCount++
+		}
+
+		for _, adjustment := range team.Adjustments {
+			kind := "Премия"
+			amount := adjustment.Amount
+			if adjustment.Kind != "bonus" {
+				kind = "Удержание"
+				amount = amount.Neg()
+			}
+
+			description := kind + ": " + adjustment.Reason
+			if adjustment.WorkerName != "" {
+				description += " (" + adjustment.WorkerName + ")"
+			}
+
+			values := []interface{}{team.TeamNumber, "", description, "", "", amount.InexactFloat64()}
+			f.SetSheetRow(operationsSheet, "A"+fmt.Sprint(rowCount), &values)
+			rowCount++
+		}
+	}
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"Сдельная оплата бригад %s - %s.xlsx",
+		report.DateFrom.Format("02-01-2006"),
+		currentTime.Format("02-01-2006"),
+	)
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func (service *teamEarningsService) CreateAdjustment(projectID, userID uint, data dto.TeamEarningsAdjustmentCreate) (model.TeamEarningAdjustment, error) {
+	if data.Kind != "bonus" && data.Kind != "deduction" {
+		return model.TeamEarningAdjustment{}, fmt.Errorf("Неизвестный вид начисления: %s, допустимы bonus и deduction", data.Kind)
+	}
+
+	if !data.Amount.IsPositive() {
+		return model.TeamEarningAdjustment{}, fmt.Errorf("Сумма должна быть больше нуля")
+	}
+
+	if strings.TrimSpace(data.Reason) == "" {
+		return model.TeamEarningAdjustment{}, fmt.Errorf("Основание не указано")
+	}
+
+	team, err := service.teamRepo.GetByID(data.TeamID)
+	if err != nil {
+		return model.TeamEarningAdjustment{}, err
+	}
+
+	if team.ID == 0 || team.ProjectID != projectID {
+		return model.TeamEarningAdjustment{}, fmt.Errorf("Бригада с ID %d не найдена", data.TeamID)
+	}
+
+	if data.WorkerID != 0 {
+		worker, err := service.workerRepo.GetByID(data.WorkerID)
+		if err != nil {
+			return model.TeamEarningAdjustment{}, err
+		}
+
+		if worker.ID == 0 || worker.ProjectID != projectID {
+			return model.TeamEarningAdjustment{}, fmt.Errorf("Работник с ID %d не найден", data.WorkerID)
+		}
+	}
+
+	if data.Date.IsZero() {
+		data.Date = time.Now()
+	}
+
+	created, err := service.teamEarningsRepo.CreateAdjustments([]model.TeamEarningAdjustment{{
+		ProjectID:       projectID,
+		TeamID:          data.TeamID,
+		WorkerID:        data.WorkerID,
+		Date:            data.Date,
+		Kind:            data.Kind,
+		Amount:          data.Amount.Round(2),
+		Reason:          strings.TrimSpace(data.Reason),
+		CreatedByUserID: userID,
+	}})
+	if err != nil {
+		return model.TeamEarningAdjustment{}, err
+	}
+
+	return created[0], nil
+}
+
+// Creates deductions for the confirmed loss-team write-offs of the period, every write-off is charged once
+func (service *teamEarningsService) ChargeLosses(projectID, userID uint, filter dto.TeamEarningsFilter) ([]model.TeamEarningAdjustment, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return []model.TeamEarningAdjustment{}, fmt.Errorf("Период не указан")
+	}
+
+	filter.ProjectID = projectID
+	losses, err := service.teamEarningsRepo.GetUnchargedLosses(filter)
+	if err != nil {
+		return []model.TeamEarningAdjustment{}, err
+	}
+
+	adjustments := []model.TeamEarningAdjustment{}
+	for _, loss := range losses {
+		if !loss.Sum.IsPositive() {
+			continue
+		}
+
+		adjustments = append(adjustments, model.TeamEarningAdjustment{
+			ProjectID:         projectID,
+			TeamID:            loss.TeamID,
+			Date:              loss.DateOfInvoice,
+			Kind:              "deduction",
+			Amount:            loss.Sum.Round(2),
+			Reason:            fmt.Sprintf("Утеря материалов по накладной %s", loss.DeliveryCode),
+			InvoiceWriteOffID: loss.InvoiceWriteOffID,
+			CreatedByUserID:   userID,
+		})
+	}
+
+	return service.teamEarningsRepo.CreateAdjustments(adjustments)
+}
+
+func (service *teamEarningsService) DeleteAdjustment(projectID, id uint) error {
+	return service.teamEarningsRepo.DeleteAdjustment(projectID, id)
+}
+
+// Splits the amount proportionally to the weights, the kopecks left after
+// rounding go to the last share so that the shares add up to the amount
+func splitEarnings(amount decimal.Decimal, weights []float64) []decimal.Decimal {
+	result := make([]decimal.Decimal, len(weights))
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		for index := range result {
+			result[index] = decimal.Zero
+		}
+		return result
+	}
+
+	distributed := decimal.Zero
+	for index, weight := range weights {
+		if index == len(weights)-1 {
+			result[index] = amount.Sub(distributed)
+			break
+		}
+
+		result[index] = amount.Mul(decimal.NewFromFloat(weight / totalWeight)).Round(2)
+		distributed = distributed.Add(result[index])
+	}
+
+	return result
+}
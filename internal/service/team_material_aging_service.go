@@ -0,0 +1,450 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"backend-v2/pkg/utils"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+var teamAccountabilityReasons = map[string]string{
+	"leader-change": "Смена бригадира",
+	"dissolution":   "Расформирование бригады",
+}
+
+type teamMaterialAgingService struct {
+	teamMaterialAgingRepo repository.ITeamMaterialAgingRepository
+	teamRepo              repository.ITeamRepository
+	projectRepo           repository.IProjectRepository
+}
+
+func NewTeamMaterialAgingService(
+	teamMaterialAgingRepo repository.ITeamMaterialAgingRepository,
+	teamRepo repository.ITeamRepository,
+	projectRepo repository.IProjectRepository,
+) ITeamMaterialAgingService {
+	return &teamMaterialAgingService{
+		teamMaterialAgingRepo: teamMaterialAgingRepo,
+		teamRepo:              teamRepo,
+		projectRepo:           projectRepo,
+	}
+}
+
+type ITeamMaterialAgingService interface {
+	GetReport(filter dto.TeamMaterialAgingFilter) (dto.TeamMaterialAgingReport, error)
+	Export(filter dto.TeamMaterialAgingFilter) (string, error)
+	GetStatements(projectID, teamID uint) ([]model.TeamAccountabilityStatement, error)
+	CreateStatement(projectID, userID uint, data dto.TeamAccountabilityStatementCreate) (string, error)
+}
+
+// Materials and serial numbers currently held by the teams and the time they are held.
+// Materials held longer than filter.Days are considered not installed in time
+func (service *teamMaterialAgingService) GetReport(filter dto.TeamMaterialAgingFilter) (dto.TeamMaterialAgingReport, error) {
+	if filter.Days <= 0 {
+		filter.Days = 30
+	}
+
+	held, err := service.teamMaterialAgingRepo.GetHeld(filter)
+	if err != nil {
+		return dto.TeamMaterialAgingReport{}, err
+	}
+
+	issues, err := service.teamMaterialAgingRepo.GetIssues(filter)
+	if err != nil {
+		return dto.TeamMaterialAgingReport{}, err
+	}
+
+	serialNumbers, err := service.teamMaterialAgingRepo.GetSerialNumbers(filter)
+	if err != nil {
+		return dto.TeamMaterialAgingReport{}, err
+	}
+
+	now := time.Now()
+	daysHeld := func(date time.Time) int {
+		if date.IsZero() {
+			return 0
+		}
+
+		return int(now.Sub(date).Hours() / 24)
+	}
+
+	issuesOf := map[[2]uint][]dto.TeamMaterialIssueQueryResult{}
+	for _, issue := range issues {
+		key := [2]uint{issue.TeamID, issue.MaterialCostID}
+		issuesOf[key] = append(issuesOf[key], issue)
+	}
+
+	serialNumbersOf := map[[2]uint][]dto.TeamMaterialSerialNumber{}
+	for _, serialNumber := range serialNumbers {
+		key := [2]uint{serialNumber.TeamID, serialNumber.MaterialCostID}
+		days := daysHeld(serialNumber.DateOfInvoice)
+		serialNumbersOf[key] = append(serialNumbersOf[key], dto.TeamMaterialSerialNumber{
+			Code:          serialNumber.Code,
+			DeliveryCode:  serialNumber.DeliveryCode,
+			DateOfInvoice: serialNumber.DateOfInvoice,
+			DaysHeld:      days,
+			Overdue:       days > filter.Days,
+		})
+	}
+
+	result := dto.TeamMaterialAgingReport{
+		Date:  now,
+		Days:  filter.Days,
+		Teams: []dto.TeamMaterialAging{},
+	}
+
+	teamIndexes := map[uint]int{}
+	for _, material := range held {
+		index, exists := teamIndexes[material.TeamID]
+		if !exists {
+			leaderNames, err := service.teamMaterialAgingRepo.GetLeaderNames(material.TeamID)
+			if err != nil {
+				return dto.TeamMaterialAgingReport{}, err
+			}
+
+			index = len(result.Teams)
+			teamIndexes[material.TeamID] = index
+			result.Teams = append(result.Teams, dto.TeamMaterialAging{
+				TeamID:       material.TeamID,
+				TeamNumber:   material.TeamNumber,
+				LeaderNames:  leaderNames,
+				Rows:         []dto.TeamMaterialAgingRow{},
+				Value:        decimal.Zero,
+				OverdueValue: decimal.Zero,
+			})
+		}
+
+		key := [2]uint{material.TeamID, material.MaterialCostID}
+		row := dto.TeamMaterialAgingRow{
+			MaterialID:    material.MaterialID,
+			Code:          material.Code,
+			Name:          material.Name,
+			Unit:          material.Unit,
+			CostM19:       material.CostM19,
+			Amount:        material.Amount,
+			Value:         material.CostM19.Mul(decimal.NewFromFloat(material.Amount)).Round(2),
+			OverdueValue:  decimal.Zero,
+			Batches:       []dto.TeamMaterialBatch{},
+			SerialNumbers: serialNumbersOf[key],
+		}
+
+		if row.SerialNumbers == nil {
+			row.SerialNumbers = []dto.TeamMaterialSerialNumber{}
+		}
+
+		remaining := material.Amount
+		for _, issue := range issuesOf[key] {
+			if remaining <= 0 {
+				break
+			}
+
+			batch := dto.TeamMaterialBatch{
+				DeliveryCode:  issue.DeliveryCode,
+				DateOfInvoice: issue.DateOfInvoice,
+				Amount:        math.Min(issue.Amount, remaining),
+				DaysHeld:      daysHeld(issue.DateOfInvoice),
+			}
+			batch.Value = material.CostM19.Mul(decimal.NewFromFloat(batch.Amount)).Round(2)
+			batch.Overdue = batch.DaysHeld > filter.Days
+			row.Batches = append(row.Batches, batch)
+			remaining -= batch.Amount
+		}
+
+		// The rest came without the confirmed output invoice, for example with the initial balance
+		if remaining > 0 {
+			row.Batches = append(row.Batches, dto.TeamMaterialBatch{
+				Amount: remaining,
+				Value:  material.CostM19.Mul(decimal.NewFromFloat(remaining)).Round(2),
+			})
+		}
+
+		for _, batch := range row.Batches {
+			if batch.DaysHeld > row.MaxDaysHeld {
+				row.MaxDaysHeld = batch.DaysHeld
+			}
+
+			if batch.Overdue {
+				row.OverdueAmount += batch.Amount
+				row.OverdueValue = row.OverdueValue.Add(batch.Value)
+			}
+		}
+
+		team := &result.Teams[index]
+		team.Rows = append(team.Rows, row)
+		team.Value = team.Value.Add(row.Value)
+		team.OverdueValue = team.OverdueValue.Add(row.OverdueValue)
+	}
+
+	return result, nil
+}
+
+func (service *teamMaterialAgingService) Export(filter dto.TeamMaterialAgingFilter) (string, error) {
+	report, err := service.GetReport(filter)
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Материалы бригад"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	overdueStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	f.SetCellStr(sheetName, "A1", fmt.Sprintf("Материалы на балансе бригад на %s, не установлены более %d дней выделены цветом", report.Date.Format("02.01.2006"), report.Days))
+	f.SetCellStyle(sheetName, "A1", "A1", boldStyle)
+
+	headers := []interface{}{"Бригада", "Код", "Наименование", "Ед. изм.", "Количество", "Цена М19", "Сумма", "Накладная", "Дата выдачи", "Дней на балансе", "Серийные номера"}
+	f.SetSheetRow(sheetName, "A3", &headers)
+	f.SetCellStyle(sheetName, "A3", "K3", boldStyle)
+	f.SetColWidth(sheetName, "C", "C", 45)
+	f.SetColWidth(sheetName, "E", "J", 13)
+	f.SetColWidth(sheetName, "K", "K", 30)
+
+	rowCount := 4
+	for _, team := range report.Teams {
+		for _, row := range team.Rows {
+			codes := []string{}
+			for _, serialNumber := range row.SerialNumbers {
+				codes = append(codes, serialNumber.Code)
+			}
+
+			for batchIndex, batch := range row.Batches {
+				dateOfInvoice := ""
+				if !batch.DateOfInvoice.IsZero() {
+					dateOfInvoice = batch.DateOfInvoice.Format("02.01.2006")
+				}
+
+				serialNumberCodes := ""
+				if batchIndex == 0 {
+					serialNumberCodes = strings.Join(codes, ", ")
+				}
+
+				values := []interface{}{
+					team.TeamNumber,
+					row.Code,
+					row.Name,
+					row.Unit,
+					batch.Amount,
+					row.CostM19.InexactFloat64(),
+					batch.Value.InexactFloat64(),
+					batch.DeliveryCode,
+					dateOfInvoice,
+					batch.DaysHeld,
+					serialNumberCodes,
+				}
+				f.SetSheetRow(sheetName, "A"+fmt.Sprint(rowCount), &values)
+				if batch.Overdue {
+					f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "K"+fmt.Sprint(rowCount), overdueStyle)
+				}
+				rowCount++
+			}
+		}
+
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Итого по бригаде "+team.TeamNumber)
+		f.SetCellFloat(sheetName, "G"+fmt.Sprint(rowCount), team.Value.InexactFloat64(), 2, 64)
+		f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "K"+fmt.Sprint(rowCount), boldStyle)
+		rowCount++
+	}
+
+	fileName := fmt.Sprintf("Материалы бригад - %s.xlsx", report.Date.Format("02-01-2006"))
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func (service *teamMaterialAgingService) GetStatements(projectID, teamID uint) ([]model.TeamAccountabilityStatement, error) {
+	return service.teamMaterialAgingRepo.GetStatements(projectID, teamID)
+}
+
+// Registers the statement and fills its document with the materials
+// and serial numbers held by the team with the lines for signatures
+func (service *teamMaterialAgingService) CreateStatement(projectID, userID uint, data dto.TeamAccountabilityStatementCreate) (string, error) {
+	reason, exists := teamAccountabilityReasons[data.Reason]
+	if !exists {
+		return "", fmt.Errorf("Неизвестное основание: %s, допустимы leader-change и dissolution", data.Reason)
+	}
+
+	if data.Format == "" {
+		data.Format = "xlsx"
+	}
+
+	if data.Format != "xlsx" && data.Format != "pdf" {
+		return "", fmt.Errorf("Неизвестный формат документа: %s, допустимы xlsx и pdf", data.Format)
+	}
+
+	team, err := service.teamRepo.GetByID(data.TeamID)
+	if err != nil {
+		return "", err
+	}
+
+	if team.ID == 0 || team.ProjectID != projectID {
+		return "", fmt.Errorf("Бригада с ID %d не найдена", data.TeamID)
+	}
+
+	project, err := service.projectRepo.GetByID(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := service.GetReport(dto.TeamMaterialAgingFilter{
+		ProjectID: projectID,
+		TeamID:    data.TeamID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	teamAging := dto.TeamMaterialAging{
+		TeamNumber: team.Number,
+		Rows:       []dto.TeamMaterialAgingRow{},
+		Value:      decimal.Zero,
+	}
+	if len(report.Teams) != 0 {
+		teamAging = report.Teams[0]
+	}
+
+	if len(teamAging.LeaderNames) == 0 {
+		teamAging.LeaderNames, err = service.teamMaterialAgingRepo.GetLeaderNames(data.TeamID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	count, err := service.teamMaterialAgingRepo.CountStatements(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	statement, err := service.teamMaterialAgingRepo.CreateStatement(model.TeamAccountabilityStatement{
+		ProjectID:       projectID,
+		TeamID:          data.TeamID,
+		Number:          utils.UniqueCodeGeneration("АС", count+1, projectID),
+		Date:            time.Now(),
+		Reason:          data.Reason,
+		CreatedByUserID: userID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Акт"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	titleStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 14},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	f.MergeCell(sheetName, "A1", "I1")
+	f.SetCellStr(sheetName, "A1", fmt.Sprintf("Акт сверки подотчетных материалов № %s от %s", statement.Number, statement.Date.Format("02.01.2006")))
+	f.SetCellStyle(sheetName, "A1", "I1", titleStyle)
+	f.SetCellStr(sheetName, "A3", "Проект: "+project.Name)
+	f.SetCellStr(sheetName, "A4", "Бригада: "+teamAging.TeamNumber)
+	f.SetCellStr(sheetName, "A5", "Бригадир: "+strings.Join(teamAging.LeaderNames, ", "))
+	f.SetCellStr(sheetName, "A6", "Основание: "+reason)
+
+	headers := []interface{}{"№", "Код", "Наименование", "Ед. изм.", "Количество", "Цена М19", "Сумма", "Дата выдачи", "Серийные номера"}
+	f.SetSheetRow(sheetName, "A8", &headers)
+	f.SetCellStyle(sheetName, "A8", "I8", boldStyle)
+	f.SetColWidth(sheetName, "C", "C", 45)
+	f.SetColWidth(sheetName, "E", "H", 13)
+	f.SetColWidth(sheetName, "I", "I", 30)
+
+	rowCount := 9
+	for index, row := range teamAging.Rows {
+		earliestIssue := ""
+		if len(row.Batches) != 0 && !row.Batches[len(row.Batches)-1].DateOfInvoice.IsZero() {
+			earliestIssue = row.Batches[len(row.Batches)-1].DateOfInvoice.Format("02.01.2006")
+		}
+
+		codes := []string{}
+		for _, serialNumber := range row.SerialNumbers {
+			codes = append(codes, serialNumber.Code)
+		}
+
+		values := []interface{}{
+			index + 1,
+			row.Code,
+			row.Name,
+			row.Unit,
+			row.Amount,
+			row.CostM19.InexactFloat64(),
+			row.Value.InexactFloat64(),
+			earliestIssue,
+			strings.Join(codes, ", "),
+		}
+		f.SetSheetRow(sheetName, "A"+fmt.Sprint(rowCount), &values)
+		rowCount++
+	}
+
+	f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), "Итого")
+	f.SetCellFloat(sheetName, "G"+fmt.Sprint(rowCount), teamAging.Value.InexactFloat64(), 2, 64)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), "I"+fmt.Sprint(rowCount), boldStyle)
+
+	rowCount += 3
+	signatures := []string{"Сдал (бригадир)", "Принял", "Заведующий складом", "Утверждаю"}
+	for _, signature := range signatures {
+		f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), signature)
+		f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), "_______________ / _______________________")
+		rowCount += 2
+	}
+
+	fileName := strings.NewReplacer("/", "-", "\\", "-").Replace(fmt.Sprintf("Акт сверки %s - бригада %s.xlsx", statement.Number, teamAging.TeamNumber))
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	if data.Format == "xlsx" {
+		return fileName, nil
+	}
+
+	defer os.Remove(filePath)
+	pdfFilePath, err := convertExcelToPDF(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(pdfFilePath), nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"time"
+)
+
+type teamMemberService struct {
+	teamMemberRepo repository.ITeamMemberRepository
+	teamRepo       repository.ITeamRepository
+	workerRepo     repository.IWorkerRepository
+}
+
+func NewTeamMemberService(
+	teamMemberRepo repository.ITeamMemberRepository,
+	teamRepo repository.ITeamRepository,
+	workerRepo repository.IWorkerRepository,
+) ITeamMemberService {
+	return &teamMemberService{
+		teamMemberRepo: teamMemberRepo,
+		teamRepo:       teamRepo,
+		workerRepo:     workerRepo,
+	}
+}
+
+type ITeamMemberService interface {
+	GetOnDate(projectID, teamID uint, date time.Time) ([]dto.TeamMemberView, error)
+	GetTeamHistory(projectID, teamID uint) ([]dto.TeamMemberView, error)
+	GetWorkerHistory(projectID, workerID uint) ([]dto.TeamMemberView, error)
+	Add(projectID uint, data dto.TeamMemberAdd) ([]model.TeamMember, error)
+	Transfer(projectID uint, data dto.TeamMemberTransfer) (model.TeamMember, error)
+	End(projectID uint, data dto.TeamMemberEnd) (model.TeamMember, error)
+	Delete(projectID, id uint) error
+}
+
+func (service *teamMemberService) GetOnDate(projectID, teamID uint, date time.Time) ([]dto.TeamMemberView, error) {
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	return service.teamMemberRepo.GetOnDate(projectID, teamID, calendarDate(date))
+}
+
+func (service *teamMemberService) GetTeamHistory(projectID, teamID uint) ([]dto.TeamMemberView, error) {
+	if _, err := service.projectTeam(projectID, teamID); err != nil {
+		return []dto.TeamMemberView{}, err
+	}
+
+	return service.teamMemberRepo.GetByTeamID(teamID)
+}
+
+func (service *teamMemberService) GetWorkerHistory(projectID, workerID uint) ([]dto.TeamMemberView, error) {
+	if _, err := service.projectWorker(projectID, workerID); err != nil {
+		return []dto.TeamMemberView{}, err
+	}
+
+	return service.teamMemberRepo.GetByWorkerID(workerID)
+}
+
+// Worker can be in one team at a time, so the worker that is already in a team
+// on the date has to be transferred instead
+func (service *teamMemberService) Add(projectID uint, data dto.TeamMemberAdd) ([]model.TeamMember, error) {
+	if _, err := service.projectTeam(projectID, data.TeamID); err != nil {
+		return []model.TeamMember{}, err
+	}
+
+	if data.EffectiveFrom.IsZero() {
+		return []model.TeamMember{}, fmt.Errorf("Дата включения в бригаду не указана")
+	}
+
+	if len(data.WorkerIDs) == 0 {
+		return []model.TeamMember{}, fmt.Errorf("Работники не выбраны")
+	}
+
+	data.EffectiveFrom = calendarDate(data.EffectiveFrom)
+	members := []model.TeamMember{}
+	added := map[uint]bool{}
+	for _, workerID := range data.WorkerIDs {
+		if added[workerID] {
+			continue
+		}
+		added[workerID] = true
+
+		worker, err := service.projectWorker(projectID, workerID)
+		if err != nil {
+			return []model.TeamMember{}, err
+		}
+
+		count, err := service.teamMemberRepo.CountOverlapping(workerID, data.EffectiveFrom, time.Time{}, 0)
+		if err != nil {
+			return []model.TeamMember{}, err
+		}
+
+		if count != 0 {
+			return []model.TeamMember{}, fmt.Errorf("Работник %s уже состоит в бригаде с %s или позже, используйте перевод", worker.Name, data.EffectiveFrom.Format("02.01.2006"))
+		}
+
+		members = append(members, model.TeamMember{
+			ProjectID:     projectID,
+			TeamID:        data.TeamID,
+			WorkerID:      workerID,
+			EffectiveFrom: data.EffectiveFrom,
+		})
+	}
+
+	return service.teamMemberRepo.Create(members)
+}
+
+func (service *teamMemberService) Transfer(projectID uint, data dto.TeamMemberTransfer) (model.TeamMember, error) {
+	team, err := service.projectTeam(projectID, data.ToTeamID)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	worker, err := service.projectWorker(projectID, data.WorkerID)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if data.Date.IsZero() {
+		return model.TeamMember{}, fmt.Errorf("Дата перевода не указана")
+	}
+
+	data.Date = calendarDate(data.Date)
+	current, err := service.teamMemberRepo.GetByWorkerOnDate(data.WorkerID, data.Date)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if current.ID == 0 {
+		return model.TeamMember{}, fmt.Errorf("Работник %s не состоит ни в одной бригаде на %s", worker.Name, data.Date.Format("02.01.2006"))
+	}
+
+	if current.TeamID == data.ToTeamID {
+		return model.TeamMember{}, fmt.Errorf("Работник %s уже состоит в бригаде %s", worker.Name, team.Number)
+	}
+
+	if !data.Date.After(calendarDate(current.EffectiveFrom)) {
+		return model.TeamMember{}, fmt.Errorf("Дата перевода должна быть позже даты включения в текущую бригаду (%s)", current.EffectiveFrom.Format("02.01.2006"))
+	}
+
+	next := model.TeamMember{
+		ProjectID:     projectID,
+		TeamID:        data.ToTeamID,
+		WorkerID:      data.WorkerID,
+		EffectiveFrom: data.Date,
+		EffectiveTo:   current.EffectiveTo,
+	}
+
+	count, err := service.teamMemberRepo.CountOverlapping(data.WorkerID, next.EffectiveFrom, next.EffectiveTo, current.ID)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if count != 0 {
+		return model.TeamMember{}, fmt.Errorf("У работника %s есть другие периоды в бригадах после %s", worker.Name, data.Date.Format("02.01.2006"))
+	}
+
+	current.EffectiveTo = data.Date.AddDate(0, 0, -1)
+	return service.teamMemberRepo.Transfer(current, next)
+}
+
+func (service *teamMemberService) End(projectID uint, data dto.TeamMemberEnd) (model.TeamMember, error) {
+	member, err := service.projectMember(projectID, data.ID)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if data.EffectiveTo.IsZero() {
+		return model.TeamMember{}, fmt.Errorf("Дата исключения из бригады не указана")
+	}
+
+	data.EffectiveTo = calendarDate(data.EffectiveTo)
+	if data.EffectiveTo.Before(calendarDate(member.EffectiveFrom)) {
+		return model.TeamMember{}, fmt.Errorf("Дата исключения не может быть раньше даты включения в бригаду (%s)", member.EffectiveFrom.Format("02.01.2006"))
+	}
+
+	count, err := service.teamMemberRepo.CountOverlapping(member.WorkerID, member.EffectiveFrom, data.EffectiveTo, member.ID)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if count != 0 {
+		return model.TeamMember{}, fmt.Errorf("Период пересекается с другим периодом работника в бригаде")
+	}
+
+	member.EffectiveTo = data.EffectiveTo
+	return service.teamMemberRepo.Update(member)
+}
+
+func (service *teamMemberService) Delete(projectID, id uint) error {
+	if _, err := service.projectMember(projectID, id); err != nil {
+		return err
+	}
+
+	return service.teamMemberRepo.Delete(id)
+}
+
+func (service *teamMemberService) projectTeam(projectID, teamID uint) (model.Team, error) {
+	team, err := service.teamRepo.GetByID(teamID)
+	if err != nil {
+		return model.Team{}, err
+	}
+
+	if team.ID == 0 || team.ProjectID != projectID {
+		return model.Team{}, fmt.Errorf("Бригада с ID %d не найдена", teamID)
+	}
+
+	return team, nil
+}
+
+func (service *teamMemberService) projectWorker(projectID, workerID uint) (model.Worker, error) {
+	worker, err := service.workerRepo.GetByID(workerID)
+	if err != nil {
+		return model.Worker{}, err
+	}
+
+	if worker.ID == 0 || worker.ProjectID != projectID {
+		return model.Worker{}, fmt.Errorf("Работник с ID %d не найден", workerID)
+	}
+
+	return worker, nil
+}
+
+func (service *teamMemberService) projectMember(projectID, id uint) (model.TeamMember, error) {
+	member, err := service.teamMemberRepo.GetByID(id)
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+
+	if member.ID == 0 || member.ProjectID != projectID {
+		return model.TeamMember{}, fmt.Errorf("Запись о составе бригады с ID %d не найдена", id)
+	}
+
+	return member, nil
+}
+
+// Membership periods are kept in whole days
+func calendarDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -14,20 +14,23 @@ import (
 )
 
 type teamService struct {
-	teamRepo   repository.ITeamRepository
-	workerRepo repository.IWorkerRepository
-	objectRepo repository.IObjectRepository
+	teamRepo       repository.ITeamRepository
+	teamMemberRepo repository.ITeamMemberRepository
+	workerRepo     repository.IWorkerRepository
+	objectRepo     repository.IObjectRepository
 }
 
 func InitTeamService(
 	teamRepo repository.ITeamRepository,
+	teamMemberRepo repository.ITeamMemberRepository,
 	workerRepo repository.IWorkerRepository,
 	objectRepo repository.IObjectRepository,
 ) ITeamService {
 	return &teamService{
-		teamRepo:   teamRepo,
-		workerRepo: workerRepo,
-		objectRepo: objectRepo,
+		teamRepo:       teamRepo,
+		teamMemberRepo: teamMemberRepo,
+		workerRepo:     workerRepo,
+		objectRepo:     objectRepo,
 	}
 }
 
@@ -100,14 +103,33 @@ func (service *teamService) GetByID(id uint) (model.Team, error) {
 }
 
 func (service *teamService) Create(data dto.TeamMutation) (model.Team, error) {
+	if err := checkWorkerClearance(service.workerRepo, "team-leader", data.LeaderWorkerIDs); err != nil {
+		return model.Team{}, err
+	}
+
 	return service.teamRepo.Create(data)
 }
 
 func (service *teamService) Update(data dto.TeamMutation) (model.Team, error) {
+	if err := checkWorkerClearance(service.workerRepo, "team-leader", data.LeaderWorkerIDs); err != nil {
+		return model.Team{}, err
+	}
+
 	return service.teamRepo.Update(data)
 }
 
+// Team with the membership history is kept, because the past attendance and
+// earnings of its workers are attributed to it
 func (service *teamService) Delete(id uint) error {
+	members, err := service.teamMemberRepo.GetByTeamID(id)
+	if err != nil {
+		return err
+	}
+
+	if len(members) != 0 {
+		return fmt.Errorf("Бригада имеет историю состава и не может быть удалена")
+	}
+
 	return service.teamRepo.Delete(id)
 }
 
@@ -226,6 +248,14 @@ func (service *teamService) Import(projectID uint, filepath string) error {
 		return fmt.Errorf("Ошибка при удалении временного файла: %v", err)
 	}
 
+	leaderWorkerIDs := []uint{}
+	for _, team := range mutationData {
+		leaderWorkerIDs = append(leaderWorkerIDs, team.LeaderWorkerIDs...)
+	}
+	if err := checkWorkerClearance(service.workerRepo, "team-leader", leaderWorkerIDs); err != nil {
+		return err
+	}
+
 	_, err = service.teamRepo.CreateInBatches(mutationData)
 	if err != nil {
 		return err
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/xuri/excelize/v2"
+)
+
+type timesheetService struct {
+	workerAttendanceRepo repository.IWorkerAttendanceRepository
+	projectCalendarRepo  repository.IProjectCalendarRepository
+	projectRepo          repository.IProjectRepository
+}
+
+func NewTimesheetService(
+	workerAttendanceRepo repository.IWorkerAttendanceRepository,
+	projectCalendarRepo repository.IProjectCalendarRepository,
+	projectRepo repository.IProjectRepository,
+) ITimesheetService {
+	return &timesheetService{
+		workerAttendanceRepo: workerAttendanceRepo,
+		projectCalendarRepo:  projectCalendarRepo,
+		projectRepo:          projectRepo,
+	}
+}
+
+type ITimesheetService interface {
+	GetTimesheet(filter dto.TimesheetFilter) (dto.Timesheet, error)
+	Export(filter dto.TimesheetFilter) (string, error)
+	GetCalendar(projectID uint, year int) ([]model.ProjectCalendarDay, error)
+	CreateCalendarDay(data model.ProjectCalendarDay) (model.ProjectCalendarDay, error)
+	DeleteCalendarDay(projectID, id uint) error
+}
+
+var timesheetWeekdays = []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
+
+func (service *timesheetService) GetTimesheet(filter dto.TimesheetFilter) (dto.Timesheet, error) {
+	if filter.DateFrom.IsZero() || filter.DateTo.IsZero() {
+		return dto.Timesheet{}, fmt.Errorf("Отчетный месяц не указан")
+	}
+
+	if filter.ShiftHours == 0 {
+		filter.ShiftHours = timesheetDefaultShiftHours()
+	}
+
+	if filter.ShiftHours < 0 || filter.ShiftHours > 24 {
+		return dto.Timesheet{}, fmt.Errorf("Продолжительность смены должна быть от 0 до 24 часов")
+	}
+
+	project, err := service.projectRepo.GetByID(filter.ProjectID)
+	if err != nil {
+		return dto.Timesheet{}, err
+	}
+
+	days, err := service.days(filter)
+	if err != nil {
+		return dto.Timesheet{}, err
+	}
+
+	attendance, err := service.workerAttendanceRepo.GetForTimesheet(filter)
+	if err != nil {
+		return dto.Timesheet{}, err
+	}
+
+	result := dto.Timesheet{
+		ProjectName: project.Name,
+		DateFrom:    filter.DateFrom,
+		DateTo:      filter.DateTo,
+		ShiftHours:  filter.ShiftHours,
+		Days:        days,
+		Rows:        []dto.TimesheetRow{},
+	}
+
+	rowIndexes := map[uint]int{}
+	for _, entry := range attendance {
+		index, exists := rowIndexes[entry.WorkerID]
+		if !exists {
+			index = len(result.Rows)
+			rowIndexes[entry.WorkerID] = index
+			result.Rows = append(result.Rows, dto.TimesheetRow{
+				WorkerID:        entry.WorkerID,
+				WorkerName:      entry.WorkerName,
+				CompanyWorkerID: entry.CompanyWorkerID,
+				JobTitle:        entry.JobTitle,
+				Cells:           make([]dto.TimesheetCell, len(days)),
+			})
+		}
+
+		start := entry.Start.In(time.UTC)
+		dayIndex := int(start.Sub(filter.DateFrom).Hours() / 24)
+		if dayIndex < 0 || dayIndex >= len(days) {
+			continue
+		}
+
+		cell := &result.Rows[index].Cells[dayIndex]
+		if entry.End.IsZero() || !entry.End.After(entry.Start) {
+			cell.MissingPunch = true
+			continue
+		}
+
+		cell.Hours += entry.End.Sub(entry.Start).Hours()
+	}
+
+	for rowIndex := range result.Rows {
+		row := &result.Rows[rowIndex]
+		for dayIndex := range row.Cells {
+			cell := &row.Cells[dayIndex]
+			cell.Hours = roundHours(cell.Hours)
+			if cell.MissingPunch {
+				row.MissingPunches++
+			}
+
+			if cell.Hours == 0 && !cell.MissingPunch {
+				continue
+			}
+
+			row.DaysWorked++
+			row.TotalHours += cell.Hours
+			if days[dayIndex].IsDayOff {
+				row.DayOffHours += cell.Hours
+				continue
+			}
+
+			cell.Overtime = roundHours(math.Max(0, cell.Hours-filter.ShiftHours))
+			row.OvertimeHours += cell.Overtime
+		}
+
+		row.TotalHours = roundHours(row.TotalHours)
+		row.OvertimeHours = roundHours(row.OvertimeHours)
+		row.DayOffHours = roundHours(row.DayOffHours)
+
+		result.TotalHours += row.TotalHours
+		result.OvertimeHours += row.OvertimeHours
+		result.DayOffHours += row.DayOffHours
+	}
+
+	result.TotalHours = roundHours(result.TotalHours)
+	result.OvertimeHours = roundHours(result.OvertimeHours)
+	result.DayOffHours = roundHours(result.DayOffHours)
+
+	return result, nil
+}
+
+func (service *timesheetService) Export(filter dto.TimesheetFilter) (string, error) {
+	timesheet, err := service.GetTimesheet(filter)
+	if err != nil {
+		return "", err
+	}
+
+	sheetName := "Табель"
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return "", err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	dayOffStyle, err := f.NewStyle(&excelize.Style{
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#D9D9D9"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	missingPunchStyle, err := f.NewStyle(&excelize.Style{
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	overtimeStyle, err := f.NewStyle(&excelize.Style{
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#FFEB9C"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	f.SetCellStr(sheetName, "A1", "Табель учета рабочего времени")
+	f.SetCellStyle(sheetName, "A1", "A1", boldStyle)
+	f.SetCellStr(sheetName, "A2", "Проект: "+timesheet.ProjectName)
+	f.SetCellStr(sheetName, "A3", fmt.Sprintf("Период: с %s по %s, продолжительность смены %g ч.", timesheet.DateFrom.Format("02.01.2006"), timesheet.DateTo.Format("02.01.2006"), timesheet.ShiftHours))
+
+	headerRow := 5
+	headers := []string{"№", "Табельный номер", "ФИО", "Должность"}
+	for _, day := range timesheet.Days {
+		headers = append(headers, fmt.Sprintf("%d\n%s", day.Date.Day(), timesheetWeekdays[day.Date.Weekday()]))
+	}
+	headers = append(headers, "Дней", "Часов", "Сверхурочно", "В выходные", "Без отметки ухода")
+
+	for index, header := range headers {
+		cellName, _ := excelize.CoordinatesToCellName(index+1, headerRow)
+		f.SetCellStr(sheetName, cellName, header)
+		f.SetCellStyle(sheetName, cellName, cellName, headerStyle)
+	}
+
+	for index, day := range timesheet.Days {
+		column, _ := excelize.ColumnNumberToName(index + 5)
+		f.SetColWidth(sheetName, column, column, 5)
+		if day.IsDayOff {
+			cellName := column + fmt.Sprint(headerRow)
+			f.SetCellStyle(sheetName, cellName, cellName, dayOffStyle)
+		}
+	}
+	f.SetColWidth(sheetName, "B", "B", 15)
+	f.SetColWidth(sheetName, "C", "C", 35)
+	f.SetColWidth(sheetName, "D", "D", 25)
+
+	rowCount := headerRow + 1
+	for index, row := range timesheet.Rows {
+		f.SetCellInt(sheetName, "A"+fmt.Sprint(rowCount), index+1)
+		f.SetCellStr(sheetName, "B"+fmt.Sprint(rowCount), row.CompanyWorkerID)
+		f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), row.WorkerName)
+		f.SetCellStr(sheetName, "D"+fmt.Sprint(rowCount), row.JobTitle)
+
+		for dayIndex, cell := range row.Cells {
+			cellName, _ := excelize.CoordinatesToCellName(dayIndex+5, rowCount)
+			switch {
+			case cell.MissingPunch:
+				f.SetCellStr(sheetName, cellName, "Н/О")
+				f.SetCellStyle(sheetName, cellName, cellName, missingPunchStyle)
+				continue
+			case cell.Hours != 0:
+				f.SetCellFloat(sheetName, cellName, cell.Hours, 2, 64)
+			}
+
+			if timesheet.Days[dayIndex].IsDayOff {
+				f.SetCellStyle(sheetName, cellName, cellName, dayOffStyle)
+			} else if cell.Overtime > 0 {
+				f.SetCellStyle(sheetName, cellName, cellName, overtimeStyle)
+			}
+		}
+
+		totals := []float64{float64(row.DaysWorked), row.TotalHours, row.OvertimeHours, row.DayOffHours, float64(row.MissingPunches)}
+		for totalIndex, total := range totals {
+			cellName, _ := excelize.CoordinatesToCellName(len(row.Cells)+5+totalIndex, rowCount)
+			f.SetCellFloat(sheetName, cellName, total, 2, 64)
+		}
+
+		rowCount++
+	}
+
+	f.SetCellStr(sheetName, "C"+fmt.Sprint(rowCount), "Итого")
+	for totalIndex, total := range []float64{timesheet.TotalHours, timesheet.OvertimeHours, timesheet.DayOffHours} {
+		cellName, _ := excelize.CoordinatesToCellName(len(timesheet.Days)+6+totalIndex, rowCount)
+		f.SetCellFloat(sheetName, cellName, total, 2, 64)
+	}
+	lastCell, _ := excelize.CoordinatesToCellName(len(headers), rowCount)
+	f.SetCellStyle(sheetName, "A"+fmt.Sprint(rowCount), lastCell, boldStyle)
+
+	rowCount += 2
+	f.SetCellStr(sheetName, "A"+fmt.Sprint(rowCount), "Н/О - нет отметки ухода, серым выделены выходные и праздничные дни, желтым - сверхурочная работа")
+
+	currentTime := time.Now()
+	fileName := fmt.Sprintf(
+		"Табель %s - %s.xlsx",
+		timesheet.DateFrom.Format("01-2006"),
+		currentTime.Format("02-01-2006"),
+	)
+	filePath := filepath.Join("./pkg/excels/temp/", fileName)
+	if err := f.SaveAs(filePath); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func (service *timesheetService) GetCalendar(projectID uint, year int) ([]model.ProjectCalendarDay, error) {
+	dateFrom := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return service.projectCalendarRepo.GetInRange(projectID, dateFrom, dateFrom.AddDate(1, 0, -1))
+}
+
+func (service *timesheetService) CreateCalendarDay(data model.ProjectCalendarDay) (model.ProjectCalendarDay, error) {
+	if data.DayType != "holiday" && data.DayType != "workday" {
+		return model.ProjectCalendarDay{}, fmt.Errorf("Неизвестный тип дня: %s, допустимы holiday и workday", data.DayType)
+	}
+
+	if data.Date.IsZero() {
+		return model.ProjectCalendarDay{}, fmt.Errorf("Дата не указана")
+	}
+
+	data.Date = time.Date(data.Date.Year(), data.Date.Month(), data.Date.Day(), 0, 0, 0, 0, time.UTC)
+	data.Name = strings.TrimSpace(data.Name)
+
+	existing, err := service.projectCalendarRepo.GetByDate(data.ProjectID, data.Date)
+	if err != nil {
+		return model.ProjectCalendarDay{}, err
+	}
+
+	if existing.ID != 0 {
+		return model.ProjectCalendarDay{}, fmt.Errorf("День %s уже есть в календаре проекта", data.Date.Format("02.01.2006"))
+	}
+
+	return service.projectCalendarRepo.Create(data)
+}
+
+func (service *timesheetService) DeleteCalendarDay(projectID, id uint) error {
+	return service.projectCalendarRepo.Delete(projectID, id)
+}
+
+// Days of the period, saturday and sunday are days off unless
+// the project calendar says otherwise
+func (service *timesheetService) days(filter dto.TimesheetFilter) ([]dto.TimesheetDay, error) {
+	calendar, err := service.projectCalendarRepo.GetInRange(filter.ProjectID, filter.DateFrom, filter.DateTo)
+	if err != nil {
+		return []dto.TimesheetDay{}, err
+	}
+
+	exceptions := map[string]model.ProjectCalendarDay{}
+	for _, day := range calendar {
+		exceptions[day.Date.Format("2006-01-02")] = day
+	}
+
+	days := []dto.TimesheetDay{}
+	for date := filter.DateFrom; !date.After(filter.DateTo); date = date.AddDate(0, 0, 1) {
+		day := dto.TimesheetDay{
+			Date:     date,
+			IsDayOff: date.Weekday() == time.Saturday || date.Weekday() == time.Sunday,
+		}
+
+		if exception, exists := exceptions[date.Format("2006-01-02")]; exists {
+			day.IsDayOff = exception.DayType == "holiday"
+			if day.IsDayOff {
+				day.Holiday = exception.Name
+			}
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func timesheetDefaultShiftHours() float64 {
+	shiftHours := viper.GetFloat64("Timesheet.ShiftHours")
+	if shiftHours == 0 {
+		return 8
+	}
+
+	return shiftHours
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
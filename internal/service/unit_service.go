@@ -0,0 +1,249 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+)
+
+type unitService struct {
+	unitRepo         repository.IUnitRepository
+	materialUnitRepo repository.IMaterialUnitRepository
+	materialRepo     repository.IMaterialRepository
+}
+
+func NewUnitService(
+	unitRepo repository.IUnitRepository,
+	materialUnitRepo repository.IMaterialUnitRepository,
+	materialRepo repository.IMaterialRepository,
+) IUnitService {
+	return &unitService{
+		unitRepo:         unitRepo,
+		materialUnitRepo: materialUnitRepo,
+		materialRepo:     materialRepo,
+	}
+}
+
+type IUnitService interface {
+	GetAll(projectID uint) ([]model.Unit, error)
+	Create(data model.Unit) (model.Unit, error)
+	Update(data model.Unit) (model.Unit, error)
+	Delete(id uint) error
+	GetMaterialUnits(materialID uint) ([]dto.MaterialUnitView, error)
+	CreateMaterialUnit(data model.MaterialUnit) (model.MaterialUnit, error)
+	UpdateMaterialUnit(data model.MaterialUnit) (model.MaterialUnit, error)
+	DeleteMaterialUnit(id uint) error
+}
+
+func (service *unitService) GetAll(projectID uint) ([]model.Unit, error) {
+	return service.unitRepo.GetAll(projectID)
+}
+
+func (service *unitService) Create(data model.Unit) (model.Unit, error) {
+	if data.Name == "" {
+		return model.Unit{}, fmt.Errorf("Наименование единицы измерения не может быть пустым")
+	}
+
+	return service.unitRepo.Create(data)
+}
+
+func (service *unitService) Update(data model.Unit) (model.Unit, error) {
+	if data.Name == "" {
+		return model.Unit{}, fmt.Errorf("Наименование единицы измерения не может быть пустым")
+	}
+
+	return service.unitRepo.Update(data)
+}
+
+func (service *unitService) Delete(id uint) error {
+	isUsed, err := service.unitRepo.IsUsedByMaterials(id)
+	if err != nil {
+		return err
+	}
+
+	if isUsed {
+		return fmt.Errorf("Единица измерения используется в коэффициентах пересчета материалов")
+	}
+
+	return service.unitRepo.Delete(id)
+}
+
+func (service *unitService) GetMaterialUnits(materialID uint) ([]dto.MaterialUnitView, error) {
+	return service.materialUnitRepo.GetByMaterialID(materialID)
+}
+
+func (service *unitService) CreateMaterialUnit(data model.MaterialUnit) (model.MaterialUnit, error) {
+	if err := service.validateMaterialUnit(data); err != nil {
+		return model.MaterialUnit{}, err
+	}
+
+	exists, err := service.materialUnitRepo.DoesExist(data.MaterialID, data.UnitID)
+	if err != nil {
+		return model.MaterialUnit{}, err
+	}
+
+	if exists {
+		return model.MaterialUnit{}, fmt.Errorf("Коэффициент пересчета для данной единицы измерения уже существует")
+	}
+
+	return service.materialUnitRepo.Create(data)
+}
+
+func (service *unitService) UpdateMaterialUnit(data model.MaterialUnit) (model.MaterialUnit, error) {
+	if err := service.validateMaterialUnit(data); err != nil {
+		return model.MaterialUnit{}, err
+	}
+
+	return service.materialUnitRepo.Update(data)
+}
+
+func (service *unitService) DeleteMaterialUnit(id uint) error {
+	return service.materialUnitRepo.Delete(id)
+}
+
+func (service *unitService) validateMaterialUnit(data model.MaterialUnit) error {
+	if data.Factor <= 0 {
+		return fmt.Errorf("Коэффициент пересчета должен быть больше нуля")
+	}
+
+	material, err := service.materialRepo.GetByID(data.MaterialID)
+	if err != nil {
+		return err
+	}
+
+	unit, err := service.unitRepo.GetByID(data.UnitID)
+	if err != nil {
+		return err
+	}
+
+	if material.ID == 0 || unit.ID == 0 {
+		return fmt.Errorf("Материал или единица измерения не найдены")
+	}
+
+	if material.ProjectID != unit.ProjectID {
+		return fmt.Errorf("Материал и единица измерения принадлежат разным проектам")
+	}
+
+	return nil
+}
+
+// Converts the amount entered in the given material unit into the base unit of the material.
+// materialUnitID equal to 0 means that amount is already in the base unit.
+// Returns the amount in base unit and the factor that was used
+func convertToBaseUnit(
+	materialUnitRepo repository.IMaterialUnitRepository,
+	materialID, materialUnitID uint,
+	amount float64,
+) (float64, float64, error) {
+	if materialUnitID == 0 {
+		return amount, 1, nil
+	}
+
+	if amount <= 0 {
+		return 0, 0, fmt.Errorf("Количество в выбранной единице измерения должно быть больше нуля")
+	}
+
+	materialUnit, err := materialUnitRepo.GetByID(materialUnitID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Не найден коэффициент пересчета единицы измерения: %v", err)
+	}
+
+	if materialUnit.MaterialID != materialID {
+		return 0, 0, fmt.Errorf("Выбранная единица измерения не относится к материалу")
+	}
+
+	return amount * materialUnit.Factor, materialUnit.Factor, nil
+}
+
+// Fields of the invoice line that hold the amount entered by user
+type enteredUnitLine struct {
+	materialID     uint
+	materialUnitID *uint
+	amount         *float64
+	unitAmount     *float64
+	serialNumbers  []string
+}
+
+// Converts amounts of materials without serial numbers into the base unit of material
+// and keeps the entered amount of every line. Materials with serial numbers are always
+// counted in pieces
+func normalizeUnits[T any](
+	materialUnitRepo repository.IMaterialUnitRepository,
+	items []T,
+	lineOf func(item *T) enteredUnitLine,
+) error {
+	for index := range items {
+		line := lineOf(&items[index])
+		*line.unitAmount = *line.amount
+		if len(line.serialNumbers) != 0 {
+			*line.materialUnitID = 0
+			continue
+		}
+
+		amount, _, err := convertToBaseUnit(materialUnitRepo, line.materialID, *line.materialUnitID, *line.amount)
+		if err != nil {
+			return err
+		}
+
+		*line.amount = amount
+	}
+
+	return nil
+}
+
+// Number of base units in one entered unit of the invoice line, lines entered
+// in the base unit have the factor 1
+func enteredUnitFactor(materialUnitID uint, amount, unitAmount float64) float64 {
+	if materialUnitID == 0 || amount <= 0 || unitAmount <= 0 {
+		return 1
+	}
+
+	return amount / unitAmount
+}
+
+// Text that is printed next to the base amount in documents when the amount
+// was originally entered in another unit
+func enteredUnitNote(
+	materialUnitRepo repository.IMaterialUnitRepository,
+	materialUnitID uint,
+	unitAmount float64,
+) string {
+	if materialUnitID == 0 {
+		return ""
+	}
+
+	materialUnit, err := materialUnitRepo.GetViewByID(materialUnitID)
+	if err != nil || materialUnit.ID == 0 {
+		return ""
+	}
+
+	unitName := materialUnit.UnitShortName
+	if unitName == "" {
+		unitName = materialUnit.UnitName
+	}
+
+	return fmt.Sprintf("%g %s", unitAmount, unitName)
+}
+
+func notesWithEnteredUnit(notes, unitNote string) string {
+	if unitNote == "" {
+		return notes
+	}
+
+	if notes == "" {
+		return unitNote
+	}
+
+	return fmt.Sprintf("%s (%s)", notes, unitNote)
+}
+
+// Unit and amount as they were entered into the invoice, invoices that were
+// entered in the base unit of the material are printed in the base unit
+func enteredUnitForReport(baseUnit string, amount float64, enteredUnitName string, unitAmount float64) (string, float64) {
+	if enteredUnitName == "" {
+		return baseUnit, amount
+	}
+
+	return enteredUnitName, unitAmount
+}
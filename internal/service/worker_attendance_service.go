@@ -6,132 +6,227 @@ import (
 	"backend-v2/model"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
-
-	"github.com/xuri/excelize/v2"
 )
 
 type workerAttendanceService struct {
-	workerAttendanceRepo repository.IWorkerAttendanceRepository
-	workerRepo           repository.IWorkerRepository
+	workerAttendanceRepo       repository.IWorkerAttendanceRepository
+	workerRepo                 repository.IWorkerRepository
+	attendanceImportFormatRepo repository.IAttendanceImportFormatRepository
 }
 
 type IWorkerAttendanceService interface {
-	Import(projectID uint, filePath string) error
+	Import(projectID uint, formatCode, filePath string) (dto.WorkerAttendanceImportResult, error)
 	GetPaginated(projectID uint) ([]dto.WorkerAttendancePaginated, error)
 	Count(projectID uint) (int64, error)
+	GetFormats(projectID uint) ([]model.AttendanceImportFormat, error)
+	CreateFormat(data model.AttendanceImportFormat) (model.AttendanceImportFormat, error)
+	DeleteFormat(projectID uint, code string) error
 }
 
 func InitWorkerAttendanceService(
 	workerAttendanceRepo repository.IWorkerAttendanceRepository,
 	workerRepo repository.IWorkerRepository,
+	attendanceImportFormatRepo repository.IAttendanceImportFormatRepository,
 ) IWorkerAttendanceService {
 	return &workerAttendanceService{
-		workerAttendanceRepo: workerAttendanceRepo,
-		workerRepo:           workerRepo,
+		workerAttendanceRepo:       workerAttendanceRepo,
+		workerRepo:                 workerRepo,
+		attendanceImportFormatRepo: attendanceImportFormatRepo,
 	}
 }
 
-func (service *workerAttendanceService) Import(projectID uint, filepath string) error {
+// Reads the punches from the file in the chosen format and groups them into working days.
+// Workers unknown to the project are skipped with a warning, punches already covered
+// by the imported working days are counted as duplicates
+func (service *workerAttendanceService) Import(projectID uint, formatCode, filePath string) (dto.WorkerAttendanceImportResult, error) {
+	defer os.Remove(filePath)
 
-	f, err := excelize.OpenFile(filepath)
+	format, err := attendanceImportFormatByCode(service.attendanceImportFormatRepo, projectID, formatCode)
 	if err != nil {
-		f.Close()
-		os.Remove(filepath)
-		return fmt.Errorf("Не смог открыть файл: %v", err)
+		return dto.WorkerAttendanceImportResult{}, err
 	}
 
-	sheetName := "морфо"
-	rows, err := f.GetRows(sheetName)
+	punches, err := readAttendancePunches(format, filePath)
 	if err != nil {
-		f.Close()
-		os.Remove(filepath)
-		return fmt.Errorf("Не смог найти таблицу 'Импорт': %v", err)
+		return dto.WorkerAttendanceImportResult{}, err
 	}
 
-	if len(rows) == 1 {
-		f.Close()
-		os.Remove(filepath)
-		return fmt.Errorf("Файл не имеет данных")
+	workers, err := service.workerRepo.GetAll(projectID)
+	if err != nil {
+		return dto.WorkerAttendanceImportResult{}, err
 	}
 
-	type workerAttendanceRaw struct {
-		CompanyWorkerID string
-		Date            time.Time
+	workerIDs := map[string]uint{}
+	for _, worker := range workers {
+		if worker.CompanyWorkerID != "" {
+			workerIDs[worker.CompanyWorkerID] = worker.ID
+		}
 	}
 
-	excelData := []workerAttendanceRaw{}
-	index := 1
-	dateTimeLayout := "01-02-06 3:04:05 PM"
-	for len(rows) > index {
-		dateExcel, err := f.GetCellValue(sheetName, "A"+fmt.Sprint(index+1))
-		if err != nil {
-			f.Close()
-			os.Remove(filepath)
-			return fmt.Errorf("Ошибка в файле, неправильный формат данных в ячейке А%d: %v", index+1, err)
-		}
+	result := dto.WorkerAttendanceImportResult{
+		UnknownWorkerIDs: []string{},
+		Warnings:         []string{},
+	}
 
-		timeExcel, err := f.GetCellValue(sheetName, "B"+fmt.Sprint(index+1))
-		if err != nil {
-			f.Close()
-			os.Remove(filepath)
-			return fmt.Errorf("Ошибка в файле, неправильный формат данных в ячейке B%d: %v", index+1, err)
+	knownPunches := []dto.WorkerAttendancePunch{}
+	unknownWorkerIDs := map[string]bool{}
+	for _, punch := range punches {
+		if _, exists := workerIDs[punch.CompanyWorkerID]; !exists {
+			if !unknownWorkerIDs[punch.CompanyWorkerID] {
+				unknownWorkerIDs[punch.CompanyWorkerID] = true
+				result.UnknownWorkerIDs = append(result.UnknownWorkerIDs, punch.CompanyWorkerID)
+				result.Warnings = append(result.Warnings, fmt.Sprintf("Работник с табельным номером '%s' не найден, его отметки пропущены", punch.CompanyWorkerID))
+			}
+			continue
 		}
 
-		companyWorkerID, err := f.GetCellValue(sheetName, "E"+fmt.Sprint(index+1))
-		if err != nil {
-			f.Close()
-			os.Remove(filepath)
-			return fmt.Errorf("Ошибка в файле, неправильный формат данных в ячейке B%d: %v", index+1, err)
-		}
+		knownPunches = append(knownPunches, punch)
+	}
 
-		dateTime, err := time.Parse(dateTimeLayout, dateExcel+" "+timeExcel)
-		if err != nil {
-			f.Close()
-			os.Remove(filepath)
-			return err
-		}
+	if len(knownPunches) == 0 {
+		return result, nil
+	}
 
-		excelData = append(excelData, workerAttendanceRaw{
-			CompanyWorkerID: companyWorkerID,
-			Date:            dateTime,
-		})
+	sort.Slice(knownPunches, func(i, j int) bool {
+		return knownPunches[i].Date.Before(knownPunches[j].Date)
+	})
 
-		index++
+	dayOf := func(date time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	}
-	f.Close()
-	os.Remove(filepath)
 
-	workerAttendance := []model.WorkerAttendance{}
-	for _, entry := range excelData {
-		worker, err := service.workerRepo.GetByCompanyID(entry.CompanyWorkerID)
-		if err != nil {
-			return err
-		}
+	existing, err := service.workerAttendanceRepo.GetInRange(
+		projectID,
+		dayOf(knownPunches[0].Date),
+		dayOf(knownPunches[len(knownPunches)-1].Date).AddDate(0, 0, 1),
+	)
+	if err != nil {
+		return dto.WorkerAttendanceImportResult{}, err
+	}
+
+	type workerDay struct {
+		workerID uint
+		day      string
+	}
+
+	keyOf := func(workerID uint, date time.Time) workerDay {
+		return workerDay{workerID: workerID, day: date.Format("2006-01-02")}
+	}
+
+	existingDays := map[workerDay]*model.WorkerAttendance{}
+	for index := range existing {
+		existing[index].Start = existing[index].Start.In(time.UTC)
+		existing[index].End = existing[index].End.In(time.UTC)
+		existingDays[keyOf(existing[index].WorkerID, existing[index].Start)] = &existing[index]
+	}
+
+	newDays := map[workerDay]*model.WorkerAttendance{}
+	newDaysOrder := []workerDay{}
+	updatedDays := map[workerDay]bool{}
+	for _, punch := range knownPunches {
+		workerID := workerIDs[punch.CompanyWorkerID]
+		key := keyOf(workerID, punch.Date)
+
+		if attendance, exists := existingDays[key]; exists {
+			end := attendance.End
+			if end.IsZero() {
+				end = attendance.Start
+			}
 
-		isNewWorkerAttendance := true
-		for index, attendance := range workerAttendance {
-			if attendance.WorkerID == worker.ID {
-				if attendance.Start.Day() == entry.Date.Day() {
-					workerAttendance[index].End = entry.Date
-					isNewWorkerAttendance = false
-					break
+			switch {
+			case !punch.Date.Before(attendance.Start) && !punch.Date.After(end):
+				result.Duplicates++
+			case punch.Date.Before(attendance.Start):
+				if attendance.End.IsZero() {
+					attendance.End = attendance.Start
 				}
+				attendance.Start = punch.Date
+				updatedDays[key] = true
+				result.Imported++
+			default:
+				attendance.End = punch.Date
+				updatedDays[key] = true
+				result.Imported++
 			}
+			continue
 		}
 
-		if isNewWorkerAttendance {
-			workerAttendance = append(workerAttendance, model.WorkerAttendance{
-				WorkerID:  worker.ID,
-				ProjectID: projectID,
-				Start:     entry.Date,
-			})
+		if attendance, exists := newDays[key]; exists {
+			if punch.Date.Equal(attendance.Start) || punch.Date.Equal(attendance.End) {
+				result.Duplicates++
+				continue
+			}
+
+			attendance.End = punch.Date
+			result.Imported++
+			continue
 		}
+
+		newDays[key] = &model.WorkerAttendance{
+			WorkerID:  workerID,
+			ProjectID: projectID,
+			Start:     punch.Date,
+		}
+		newDaysOrder = append(newDaysOrder, key)
+		result.Imported++
+	}
+
+	created := []model.WorkerAttendance{}
+	for _, key := range newDaysOrder {
+		created = append(created, *newDays[key])
+	}
+
+	updated := []model.WorkerAttendance{}
+	for key := range updatedDays {
+		updated = append(updated, *existingDays[key])
+	}
+
+	if err := service.workerAttendanceRepo.SaveImport(created, updated); err != nil {
+		return dto.WorkerAttendanceImportResult{}, err
 	}
 
-	fmt.Println(workerAttendance)
+	return result, nil
+}
+
+func (service *workerAttendanceService) GetFormats(projectID uint) ([]model.AttendanceImportFormat, error) {
+	return attendanceImportFormats(service.attendanceImportFormatRepo, projectID)
+}
+
+func (service *workerAttendanceService) CreateFormat(data model.AttendanceImportFormat) (model.AttendanceImportFormat, error) {
+	data.Code = strings.TrimSpace(data.Code)
+	data.FileType = strings.ToLower(strings.TrimSpace(data.FileType))
+	data.WorkerIDColumn = strings.ToUpper(strings.TrimSpace(data.WorkerIDColumn))
+	data.DateColumn = strings.ToUpper(strings.TrimSpace(data.DateColumn))
+	data.TimeColumn = strings.ToUpper(strings.TrimSpace(data.TimeColumn))
+	if err := validateAttendanceImportFormat(data); err != nil {
+		return model.AttendanceImportFormat{}, err
+	}
+
+	if isBuiltinAttendanceImportFormat(data.Code) {
+		return model.AttendanceImportFormat{}, fmt.Errorf("Код '%s' занят встроенным форматом", data.Code)
+	}
+
+	existing, err := service.attendanceImportFormatRepo.GetByCode(data.ProjectID, data.Code)
+	if err != nil {
+		return model.AttendanceImportFormat{}, err
+	}
+
+	if existing.ID != 0 {
+		return model.AttendanceImportFormat{}, fmt.Errorf("Формат с кодом '%s' уже существует", data.Code)
+	}
+
+	return service.attendanceImportFormatRepo.Create(data)
+}
+
+func (service *workerAttendanceService) DeleteFormat(projectID uint, code string) error {
+	if isBuiltinAttendanceImportFormat(code) {
+		return fmt.Errorf("Встроенный формат '%s' нельзя удалить", code)
+	}
 
-	return service.workerAttendanceRepo.CreateBatch(workerAttendance)
+	return service.attendanceImportFormatRepo.Delete(projectID, code)
 }
 
 func (service *workerAttendanceService) GetPaginated(projectID uint) ([]dto.WorkerAttendancePaginated, error) {
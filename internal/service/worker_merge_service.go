@@ -0,0 +1,262 @@
+package service
+
+import (
+	"backend-v2/internal/dto"
+	"backend-v2/internal/repository"
+	"backend-v2/model"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type workerMergeService struct {
+	workerMergeRepo repository.IWorkerMergeRepository
+	workerRepo      repository.IWorkerRepository
+	permissionRepo  repository.IPermissionRepository
+}
+
+func NewWorkerMergeService(
+	workerMergeRepo repository.IWorkerMergeRepository,
+	workerRepo repository.IWorkerRepository,
+	permissionRepo repository.IPermissionRepository,
+) IWorkerMergeService {
+	return &workerMergeService{
+		workerMergeRepo: workerMergeRepo,
+		workerRepo:      workerRepo,
+		permissionRepo:  permissionRepo,
+	}
+}
+
+type IWorkerMergeService interface {
+	GetDuplicates(roleID, projectID uint) ([]dto.WorkerDuplicateGroup, error)
+	GetAll(roleID, projectID uint) ([]dto.WorkerMergeView, error)
+	Merge(roleID, projectID, userID uint, data dto.WorkerMergeRequest) ([]model.WorkerMerge, error)
+	Undo(roleID, projectID, userID, id uint) (model.WorkerMerge, error)
+}
+
+// Groups of workers of the project with the same CompanyWorkerID, normalised name
+// or phone, a worker can be found in several groups
+func (service *workerMergeService) GetDuplicates(roleID, projectID uint) ([]dto.WorkerDuplicateGroup, error) {
+	permission, err := service.permissionRepo.GetByResourceURL("/worker-merge", roleID)
+	if err != nil {
+		return []dto.WorkerDuplicateGroup{}, err
+	}
+
+	if !permission.R {
+		return []dto.WorkerDuplicateGroup{}, fmt.Errorf("Доступ запрещен: у вашей роли нет прав на просмотр дубликатов работников")
+	}
+
+	workers, err := service.workerMergeRepo.GetCandidates(projectID)
+	if err != nil {
+		return []dto.WorkerDuplicateGroup{}, err
+	}
+
+	matchers := []struct {
+		matchedBy string
+		key       func(dto.WorkerDuplicateCandidate) string
+	}{
+		{"companyWorkerID", func(worker dto.WorkerDuplicateCandidate) string {
+			return strings.ToLower(strings.TrimSpace(worker.CompanyWorkerID))
+		}},
+		{"name", func(worker dto.WorkerDuplicateCandidate) string { return normaliseWorkerName(worker.Name) }},
+		{"phone", func(worker dto.WorkerDuplicateCandidate) string { return normaliseWorkerPhone(worker.MobileNumber) }},
+	}
+
+	groups := []dto.WorkerDuplicateGroup{}
+	for _, matcher := range matchers {
+		keys := []string{}
+		workersByKey := map[string][]dto.WorkerDuplicateCandidate{}
+		for _, worker := range workers {
+			key := matcher.key(worker)
+			if key == "" {
+				continue
+			}
+
+			if _, exists := workersByKey[key]; !exists {
+				keys = append(keys, key)
+			}
+			workersByKey[key] = append(workersByKey[key], worker)
+		}
+
+		sort.Strings(keys)
+		for _, key := range keys {
+			if len(workersByKey[key]) < 2 {
+				continue
+			}
+
+			groups = append(groups, dto.WorkerDuplicateGroup{
+				MatchedBy: matcher.matchedBy,
+				Value:     key,
+				Workers:   workersByKey[key],
+			})
+		}
+	}
+
+	workerIDs := []uint{}
+	for _, group := range groups {
+		for _, worker := range group.Workers {
+			workerIDs = append(workerIDs, worker.ID)
+		}
+	}
+
+	referenceCounts, err := service.workerMergeRepo.GetReferenceCounts(workerIDs)
+	if err != nil {
+		return []dto.WorkerDuplicateGroup{}, err
+	}
+
+	references := map[uint]int64{}
+	for _, count := range referenceCounts {
+		references[count.WorkerID] = count.Amount
+	}
+
+	for groupIndex := range groups {
+		for workerIndex := range groups[groupIndex].Workers {
+			groups[groupIndex].Workers[workerIndex].References = references[groups[groupIndex].Workers[workerIndex].ID]
+		}
+	}
+
+	return groups, nil
+}
+
+func (service *workerMergeService) GetAll(roleID, projectID uint) ([]dto.WorkerMergeView, error) {
+	permission, err := service.permissionRepo.GetByResourceURL("/worker-merge", roleID)
+	if err != nil {
+		return []dto.WorkerMergeView{}, err
+	}
+
+	if !permission.R {
+		return []dto.WorkerMergeView{}, fmt.Errorf("Доступ запрещен: у вашей роли нет прав на просмотр объединений работников")
+	}
+
+	return service.workerMergeRepo.GetAll(projectID)
+}
+
+// Workers are looked up by their project in attendance import, team membership and
+// the other project screens, so only the workers of the same project are merged
+func (service *workerMergeService) Merge(roleID, projectID, userID uint, data dto.WorkerMergeRequest) ([]model.WorkerMerge, error) {
+	permission, err := service.permissionRepo.GetByResourceURL("/worker-merge", roleID)
+	if err != nil {
+		return []model.WorkerMerge{}, err
+	}
+
+	if !permission.W {
+		return []model.WorkerMerge{}, fmt.Errorf("Доступ запрещен: у вашей роли нет прав на объединение работников")
+	}
+
+	if len(data.MergedWorkerIDs) == 0 {
+		return []model.WorkerMerge{}, fmt.Errorf("Не указаны работники для объединения")
+	}
+
+	kept, err := service.mergeWorker(projectID, data.KeptWorkerID)
+	if err != nil {
+		return []model.WorkerMerge{}, err
+	}
+
+	merged := []model.Worker{}
+	added := map[uint]bool{}
+	for _, workerID := range data.MergedWorkerIDs {
+		if workerID == data.KeptWorkerID {
+			return []model.WorkerMerge{}, fmt.Errorf("Работник не может быть объединён сам с собой")
+		}
+
+		if added[workerID] {
+			continue
+		}
+		added[workerID] = true
+
+		worker, err := service.mergeWorker(projectID, workerID)
+		if err != nil {
+			return []model.WorkerMerge{}, err
+		}
+
+		// Memberships of the workers merged earlier become memberships of the kept worker
+		for _, other := range append([]model.Worker{kept}, merged...) {
+			overlaps, err := service.workerMergeRepo.CountMembershipOverlaps(other.ID, worker.ID)
+			if err != nil {
+				return []model.WorkerMerge{}, err
+			}
+
+			if overlaps != 0 {
+				return []model.WorkerMerge{}, fmt.Errorf("Периоды работы в бригадах работника %s (ID %d) пересекаются с периодами работника %s (ID %d), исправьте их перед объединением", worker.Name, worker.ID, other.Name, other.ID)
+			}
+		}
+
+		merged = append(merged, worker)
+	}
+
+	return service.workerMergeRepo.Merge(projectID, userID, kept, merged)
+}
+
+func (service *workerMergeService) Undo(roleID, projectID, userID, id uint) (model.WorkerMerge, error) {
+	permission, err := service.permissionRepo.GetByResourceURL("/worker-merge", roleID)
+	if err != nil {
+		return model.WorkerMerge{}, err
+	}
+
+	if !permission.D {
+		return model.WorkerMerge{}, fmt.Errorf("Доступ запрещен: у вашей роли нет прав на отмену объединения работников")
+	}
+
+	data, err := service.workerMergeRepo.GetByID(id)
+	if err != nil || (data.ProjectID != projectID) {
+		return model.WorkerMerge{}, fmt.Errorf("Объединение с ID %d не найдено", id)
+	}
+
+	if !data.UndoneAt.IsZero() {
+		return model.WorkerMerge{}, fmt.Errorf("Объединение с ID %d уже отменено", id)
+	}
+
+	kept, err := service.workerRepo.GetByID(data.KeptWorkerID)
+	if err != nil {
+		return model.WorkerMerge{}, err
+	}
+
+	if kept.ID == 0 {
+		return model.WorkerMerge{}, fmt.Errorf("Оставленный работник с ID %d был объединён с другим работником, сначала отмените более позднее объединение", data.KeptWorkerID)
+	}
+
+	return service.workerMergeRepo.Undo(data, userID)
+}
+
+// Worker with ID 1 is the system worker and never takes part in the merge
+func (service *workerMergeService) mergeWorker(projectID, workerID uint) (model.Worker, error) {
+	worker, err := service.workerRepo.GetByID(workerID)
+	if err != nil || worker.ID == 0 || worker.ID == 1 {
+		return model.Worker{}, fmt.Errorf("Работник с ID %d не найден", workerID)
+	}
+
+	if worker.ProjectID != projectID {
+		return model.Worker{}, fmt.Errorf("Работник %s (ID %d) относится к другому проекту, объединяются только работники текущего проекта", worker.Name, worker.ID)
+	}
+
+	return worker, nil
+}
+
+// Lower case name with single spaces, ё is written as е
+func normaliseWorkerName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Last 9 digits of the phone so that numbers with and without the country code match,
+// numbers shorter than 7 digits are ignored
+func normaliseWorkerPhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, phone)
+
+	if len(digits) < 7 {
+		return ""
+	}
+
+	if len(digits) > 9 {
+		digits = digits[len(digits)-9:]
+	}
+
+	return digits
+}
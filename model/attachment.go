@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Photo or document uploaded for the object or the object invoice.
+// AttachedTo is either "object" or "invoice-object", AttachedToID is the id of that record.
+// Files are kept on the disk, only their paths are stored here
+type Attachment struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	ProjectID        uint      `json:"projectID"`
+	AttachedTo       string    `json:"attachedTo" gorm:"tinyText"`
+	AttachedToID     uint      `json:"attachedToID"`
+	FileName         string    `json:"fileName"`
+	MimeType         string    `json:"mimeType" gorm:"tinyText"`
+	Size             int64     `json:"size"`
+	FilePath         string    `json:"-"`
+	ThumbnailPath    string    `json:"-"`
+	TakenAt          time.Time `json:"takenAt"`
+	HasLocation      bool      `json:"hasLocation"`
+	Latitude         float64   `json:"latitude"`
+	Longitude        float64   `json:"longitude"`
+	UploadedByUserID uint      `json:"uploadedByUserID"`
+	UploadedAt       time.Time `json:"uploadedAt"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// Layout of the file exported by the attendance terminal of some vendor.
+// Columns are given as Excel column letters for both Excel and CSV files,
+// DateTimeLayout uses YYYY, YY, MM, DD, HH, hh, h, mm, ss and A tokens
+type AttendanceImportFormat struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	ProjectID      uint   `json:"projectID"`
+	Code           string `json:"code" gorm:"tinyText"`
+	Name           string `json:"name" gorm:"tinyText"`
+	FileType       string `json:"fileType" gorm:"tinyText"`
+	SheetName      string `json:"sheetName" gorm:"tinyText"`
+	CSVDelimiter   string `json:"csvDelimiter" gorm:"tinyText"`
+	HeaderRows     uint   `json:"headerRows"`
+	WorkerIDColumn string `json:"workerIDColumn" gorm:"tinyText"`
+	DateColumn     string `json:"dateColumn" gorm:"tinyText"`
+	TimeColumn     string `json:"timeColumn" gorm:"tinyText"`
+	DateTimeLayout string `json:"dateTimeLayout" gorm:"tinyText"`
+}
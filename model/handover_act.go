@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Act of handing the finished object over to the customer, an object has at most one act
+// and keeps its number and date when the document is generated again
+type HandoverAct struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ProjectID       uint      `json:"projectID"`
+	ObjectID        uint      `json:"objectID"`
+	Number          string    `json:"number" gorm:"tinyText"`
+	Date            time.Time `json:"date"`
+	CreatedByUserID uint      `json:"createdByUserID"`
+}
@@ -9,4 +9,8 @@ type InvoiceMaterials struct {
 	IsDefected     bool    `json:"isDefected"`
 	Amount         float64 `json:"amount"`
 	Notes          string  `json:"notes"`
+
+	//Unit in which the amount was entered, Amount is always stored in the base unit of material
+	MaterialUnitID uint    `json:"materialUnitID"`
+	UnitAmount     float64 `json:"unitAmount"`
 }
@@ -14,5 +14,7 @@ type InvoiceObject struct {
 	ConfirmedByOperator bool      `json:"confirmedByOperator"`
 	DateOfCorrection    time.Time `json:"dateOfCorrection"`
 
-	InvoiceObjectOperators []InvoiceObjectOperator `json:"-" gorm:"foreignKey:InvoiceObjectID"`
+	InvoiceObjectOperators []InvoiceObjectOperator     `json:"-" gorm:"foreignKey:InvoiceObjectID"`
+	OperatorErrorsFound    []OperatorErrorFound        `json:"-" gorm:"foreignKey:InvoiceObjectID"`
+	BOMDeviations          []InvoiceObjectBOMDeviation `json:"-" gorm:"foreignKey:InvoiceObjectID"`
 }
@@ -0,0 +1,13 @@
+package model
+
+// Material of the object invoice which amount differs from the bill of materials
+// of the invoice operations by more than the allowed tolerance
+type InvoiceObjectBOMDeviation struct {
+	ID               uint    `json:"id" gorm:"primaryKey"`
+	ProjectID        uint    `json:"projectID"`
+	InvoiceObjectID  uint    `json:"invoiceObjectID"`
+	MaterialID       uint    `json:"materialID"`
+	ExpectedAmount   float64 `json:"expectedAmount"`
+	ActualAmount     float64 `json:"actualAmount"`
+	DeviationPercent float64 `json:"deviationPercent"`
+}
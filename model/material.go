@@ -8,6 +8,7 @@ type Material struct {
 	Unit                      string  `json:"unit" gorm:"tinyText"`
 	Notes                     string  `json:"notes"`
 	HasSerialNumber           bool    `json:"hasSerialNumber"`
+	HasLot                    bool    `json:"hasLot"`
 	Article                   string  `json:"article"`
 	ProjectID                 uint    `json:"projectID"`
 	PlannedAmountForProject   float64 `json:"plannedAmountForProject"`
@@ -15,4 +16,8 @@ type Material struct {
 
 	MaterialCosts      []MaterialCost      `json:"-" gorm:"foreignKey:MaterialID"`
 	OperationMaterials []OperationMaterial `json:"-" gorm:"foreignKey:MaterialID"`
+	MaterialUnits      []MaterialUnit      `json:"-" gorm:"foreignKey:MaterialID"`
+	OperationBOMItems  []OperationBOMItem  `json:"-" gorm:"foreignKey:MaterialID"`
+
+	ObjectPlannedMaterials []ObjectPlannedMaterial `json:"-" gorm:"foreignKey:MaterialID"`
 }
@@ -12,4 +12,5 @@ type MaterialCost struct {
 	InvoiceMaterials           []InvoiceMaterials         `json:"-" gorm:"foreignKey:MaterialCostID"`
 	MaterialLocations          []MaterialLocation         `json:"-" gorm:"foreignKey:MaterialCostID"`
 	ProjectProgressesMaterials []ProjectProgressMaterials `json:"-" gorm:"foreignKey:MaterialCostID"`
+	MaterialLots               []MaterialLot              `json:"-" gorm:"foreignKey:MaterialCostID"`
 }
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Batch of a material with shelf life, amounts of the batch are tracked per location
+// the same way as serial numbers are tracked for materials with serial numbers
+type MaterialLot struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ProjectID       uint      `json:"projectID"`
+	MaterialCostID  uint      `json:"materialCostID"`
+	Code            string    `json:"code" gorm:"text"`
+	ManufactureDate time.Time `json:"manufactureDate"`
+	ExpiryDate      time.Time `json:"expiryDate"`
+
+	MaterialLotLocations []MaterialLotLocation `json:"-" gorm:"foreignKey:MaterialLotID"`
+	MaterialLotMovements []MaterialLotMovement `json:"-" gorm:"foreignKey:MaterialLotID"`
+}
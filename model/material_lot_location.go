@@ -0,0 +1,10 @@
+package model
+
+type MaterialLotLocation struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	MaterialLotID uint    `json:"materialLotID"`
+	ProjectID     uint    `json:"projectID"`
+	LocationID    uint    `json:"locationID"`
+	LocationType  string  `json:"locationType"`
+	Amount        float64 `json:"amount"`
+}
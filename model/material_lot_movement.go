@@ -0,0 +1,12 @@
+package model
+
+type MaterialLotMovement struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	MaterialLotID uint    `json:"materialLotID"`
+	ProjectID     uint    `json:"projectID"`
+	InvoiceID     uint    `json:"invoiceID"`
+	InvoiceType   string  `json:"invoiceType"`
+	Amount        float64 `json:"amount"`
+	IsDefected    bool    `json:"isDefected"`
+	Confirmation  bool    `json:"confirmation"`
+}
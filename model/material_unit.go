@@ -0,0 +1,10 @@
+package model
+
+// MaterialUnit describes how many base units (Material.Unit) are inside one Unit
+// for a specific material, e.g. 1 drum of cable = 500 meters
+type MaterialUnit struct {
+	ID         uint    `json:"id" gorm:"primaryKey"`
+	MaterialID uint    `json:"materialID"`
+	UnitID     uint    `json:"unitID"`
+	Factor     float64 `json:"factor"`
+}
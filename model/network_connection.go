@@ -0,0 +1,11 @@
+package model
+
+// Directed edge of the electrical network, the source object feeds the target object.
+// Together with TPNourashesObjects and SubstationCellNourashesSubstationObject it forms
+// the network topology of the project
+type NetworkConnection struct {
+	ID             uint `json:"id" gorm:"primaryKey"`
+	ProjectID      uint `json:"projectID"`
+	SourceObjectID uint `json:"sourceObjectID"`
+	TargetObjectID uint `json:"targetObjectID"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// Amount of the material planned to be installed on the object by the design estimate
+type ObjectPlannedMaterial struct {
+	ID         uint    `json:"id" gorm:"primaryKey"`
+	ProjectID  uint    `json:"projectID"`
+	ObjectID   uint    `json:"objectID"`
+	MaterialID uint    `json:"materialID"`
+	Amount     float64 `json:"amount"`
+}
+
+// Amount of the operation planned to be done on the object by the design estimate
+type ObjectPlannedOperation struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	ProjectID   uint    `json:"projectID"`
+	ObjectID    uint    `json:"objectID"`
+	OperationID uint    `json:"operationID"`
+	Amount      float64 `json:"amount"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// History of the lifecycle of the object, every change of Object.Status is stored here
+type ObjectStatusTransition struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ProjectID  uint      `json:"projectID"`
+	ObjectID   uint      `json:"objectID"`
+	FromStatus string    `json:"fromStatus" gorm:"tinyText"`
+	ToStatus   string    `json:"toStatus" gorm:"tinyText"`
+	UserID     uint      `json:"userID"`
+	Date       time.Time `json:"date"`
+	Comment    string    `json:"comment"`
+}
@@ -0,0 +1,43 @@
+package model
+
+// Object type defined by the administrator in addition to the builtin ones.
+// Objects of this type are stored in objects table with Type equal to Code
+// and their details are kept as attribute values of CustomObject
+type ObjectType struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Code string `json:"code" gorm:"tinyText"`
+	Name string `json:"name" gorm:"tinyText"`
+
+	// Position of the type in the electrical network, 0 means the type is not part of the network
+	NetworkLevel uint `json:"networkLevel"`
+
+	ObjectTypeAttributes []ObjectTypeAttribute `json:"-" gorm:"foreignKey:ObjectTypeID"`
+	CustomObjects        []CustomObject        `json:"-" gorm:"foreignKey:ObjectTypeID"`
+}
+
+type ObjectTypeAttribute struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	ObjectTypeID uint   `json:"objectTypeID"`
+	Code         string `json:"code" gorm:"tinyText"`
+	Name         string `json:"name" gorm:"tinyText"`
+	DataType     string `json:"dataType" gorm:"tinyText"`
+	Required     bool   `json:"required"`
+	OrderNumber  uint   `json:"orderNumber"`
+
+	CustomObjectAttributeValues []CustomObjectAttributeValue `json:"-" gorm:"foreignKey:ObjectTypeAttributeID"`
+}
+
+// Details of the object of the custom type, referenced by Object.ObjectDetailedID
+type CustomObject struct {
+	ID           uint `json:"id" gorm:"primaryKey"`
+	ObjectTypeID uint `json:"objectTypeID"`
+
+	CustomObjectAttributeValues []CustomObjectAttributeValue `json:"-" gorm:"foreignKey:CustomObjectID"`
+}
+
+type CustomObjectAttributeValue struct {
+	ID                    uint   `json:"id" gorm:"primaryKey"`
+	CustomObjectID        uint   `json:"customObjectID"`
+	ObjectTypeAttributeID uint   `json:"objectTypeAttributeID"`
+	Value                 string `json:"value"`
+}
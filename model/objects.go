@@ -16,6 +16,16 @@ type Object struct {
 
 	InvoiceObject []InvoiceObject `json:"-" gorm:"foreignKey:ObjectID"`
 
+	ObjectStatusTransitions []ObjectStatusTransition `json:"-" gorm:"foreignKey:ObjectID"`
+
+	HandoverActs []HandoverAct `json:"-" gorm:"foreignKey:ObjectID"`
+
+	ObjectPlannedMaterials  []ObjectPlannedMaterial  `json:"-" gorm:"foreignKey:ObjectID"`
+	ObjectPlannedOperations []ObjectPlannedOperation `json:"-" gorm:"foreignKey:ObjectID"`
+
+	SourceNetworkConnections []NetworkConnection `json:"-" gorm:"foreignKey:SourceObjectID"`
+	TargetNetworkConnections []NetworkConnection `json:"-" gorm:"foreignKey:TargetObjectID"`
+
 	TPNourashesObjects []TPNourashesObjects `json:"-" gorm:"foreignKey:TP_ObjectID"`
 
   SubstationObjects     []SubstationCellNourashesSubstationObject `json:"-" gorm:"foreignKey:SubstationObjectID"`
@@ -23,17 +33,21 @@ type Object struct {
 }
 
 type MJD_Object struct {
-	ID              uint   `json:"id" gorm:"primaryKey"`
-	Model           string `json:"model" gorm:"tinyText"`
-	AmountStores    uint   `json:"amountStores"`
-	AmountEntrances uint   `json:"amountEntrances"`
-	HasBasement     bool   `json:"hasBasement"`
+	ID              uint    `json:"id" gorm:"primaryKey"`
+	Model           string  `json:"model" gorm:"tinyText"`
+	AmountStores    uint    `json:"amountStores"`
+	AmountEntrances uint    `json:"amountEntrances"`
+	HasBasement     bool    `json:"hasBasement"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
 }
 
 type TP_Object struct {
-	ID           uint   `json:"id" gorm:"primaryKey"`
-	Model        string `json:"model" gorm:"tinyText"`
-	VoltageClass string `json:"voltageClass" gorm:"tinyText"`
+	ID           uint    `json:"id" gorm:"primaryKey"`
+	Model        string  `json:"model" gorm:"tinyText"`
+	VoltageClass string  `json:"voltageClass" gorm:"tinyText"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
 }
 
 type TPNourashesObjects struct {
@@ -50,20 +64,24 @@ type STVT_Object struct {
 }
 
 type SIP_Object struct {
-	ID            uint `json:"id" gorm:"primaryKey"`
-	AmountFeeders uint `json:"amountFeeders"`
+	ID            uint   `json:"id" gorm:"primaryKey"`
+	AmountFeeders uint   `json:"amountFeeders"`
+	Route         string `json:"route"`
 }
 
 type KL04KV_Object struct {
 	ID        uint    `json:"id" gorm:"primaryKey"`
 	Length    float64 `json:"length"`
 	Nourashes string  `json:"nourashes" gorm:"tinyText"`
+	Route     string  `json:"route"`
 }
 
 type Substation_Object struct {
-	ID                   uint   `json:"id" gorm:"primaryKey"`
-	VoltageClass         string `json:"voltageClass"`
-	NumberOfTransformers uint   `json:"numberOfTransformers"`
+	ID                   uint    `json:"id" gorm:"primaryKey"`
+	VoltageClass         string  `json:"voltageClass"`
+	NumberOfTransformers uint    `json:"numberOfTransformers"`
+	Latitude             float64 `json:"latitude"`
+	Longitude            float64 `json:"longitude"`
 }
 
 type SubstationCellObject struct {
@@ -15,4 +15,6 @@ type Operation struct {
 	InvoiceOperations         []InvoiceOperations         `json:"-" gorm:"foreignKey:OperationID"`
 	OperationMaterials        []OperationMaterial         `json:"-" gorm:"foreignKey:OperationID"`
 	ProjectProgressOperations []ProjectProgressOperations `json:"-" gorm:"foreignKey:OperationID"`
+	OperationBOMItems         []OperationBOMItem          `json:"-" gorm:"foreignKey:OperationID"`
+	ObjectPlannedOperations   []ObjectPlannedOperation    `json:"-" gorm:"foreignKey:OperationID"`
 }
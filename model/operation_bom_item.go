@@ -0,0 +1,11 @@
+package model
+
+// Bill of materials of the operation: Amount of the material that is consumed
+// when one unit of the operation is done
+type OperationBOMItem struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	ProjectID   uint    `json:"projectID"`
+	OperationID uint    `json:"operationID"`
+	MaterialID  uint    `json:"materialID"`
+	Amount      float64 `json:"amount"`
+}
@@ -1,9 +1,16 @@
 package model
 
+import "time"
+
 type OperatorErrorFound struct {
-	ID                 uint    `gorm:"primaryKey" json:"id"`
-	InvoiceMaterialsID uint    `json:"invoiceMaterialID"`
-	MaterialCostID     uint    `json:"materialCostID"`
-	Amount             float64 `json:"amount"`
-	Notes              string  `json:"notes"`
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	ProjectID          uint      `json:"projectID"`
+	InvoiceObjectID    uint      `json:"invoiceObjectID"`
+	InvoiceMaterialsID uint      `json:"invoiceMaterialID"`
+	MaterialCostID     uint      `json:"materialCostID"`
+	ExpectedAmount     float64   `json:"expectedAmount"`
+	CorrectedAmount    float64   `json:"correctedAmount"`
+	Amount             float64   `json:"amount"`
+	Notes              string    `json:"notes"`
+	DateOfCorrection   time.Time `json:"dateOfCorrection"`
 }
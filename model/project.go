@@ -7,16 +7,17 @@ import (
 )
 
 type Project struct {
-	ID                   uint            `json:"id" gorm:"primaryKey"`
-	Name                 string          `json:"name" gorm:"tinyText"`
-	Client               string          `json:"client" gorm:"tinyText"`
-	Budget               decimal.Decimal `json:"budget" gorm:"type:decimal(20,2)"`
-	BudgetCurrency       string          `json:"budgetCurrency"`
-	Description          string          `json:"description"`
-	SignedDateOfContract time.Time       `json:"signedDateOfContract"`
-	DateStart            time.Time       `json:"dateStart"`
-	DateEnd              time.Time       `json:"dateEnd"`
-	ProjectManager       string          `json:"projectManager"`
+	ID                     uint            `json:"id" gorm:"primaryKey"`
+	Name                   string          `json:"name" gorm:"tinyText"`
+	Client                 string          `json:"client" gorm:"tinyText"`
+	Budget                 decimal.Decimal `json:"budget" gorm:"type:decimal(20,2)"`
+	BudgetCurrency         string          `json:"budgetCurrency"`
+	Description            string          `json:"description"`
+	SignedDateOfContract   time.Time       `json:"signedDateOfContract"`
+	DateStart              time.Time       `json:"dateStart"`
+	DateEnd                time.Time       `json:"dateEnd"`
+	ProjectManager         string          `json:"projectManager"`
+	CostAllocationStrategy string          `json:"costAllocationStrategy"`
 
 	Districts                  []District                  `json:"-" gorm:"foreignKey:ProjectID"`
 	UserActions                []UserAction                `json:"-" gorm:"foreignKey:ProjectID"`
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Exception from the usual week of the project calendar: a holiday that falls
+// on a weekday or a working day moved to a weekend
+type ProjectCalendarDay struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProjectID uint      `json:"projectID"`
+	Date      time.Time `json:"date" gorm:"type:date"`
+	DayType   string    `json:"dayType" gorm:"tinyText"`
+	Name      string    `json:"name" gorm:"tinyText"`
+}
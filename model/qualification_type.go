@@ -0,0 +1,14 @@
+package model
+
+// Kind of certificate held by the workers of the project, for example electrical safety
+// group, medical check or work-at-height permit. Required flags block assigning the workers
+// without the valid certificate of this kind as supervisors or team leaders
+type QualificationType struct {
+	ID                    uint   `json:"id" gorm:"primaryKey"`
+	ProjectID             uint   `json:"projectID"`
+	Name                  string `json:"name" gorm:"tinyText"`
+	RequiredForSupervisor bool   `json:"requiredForSupervisor"`
+	RequiredForTeamLeader bool   `json:"requiredForTeamLeader"`
+
+	WorkerQualifications []WorkerQualification `json:"-" gorm:"foreignKey:QualificationTypeID"`
+}
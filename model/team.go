@@ -11,4 +11,6 @@ type Team struct {
 	InvoiceOutputs   []InvoiceOutput   `json:"-" gorm:"foreignKey:TeamID"`
 	InvoiceObject    []InvoiceObject   `json:"-" gorm:"foreignKey:TeamID"`
 	ObjectTeams      []ObjectTeams     `json:"-" gorm:"foreignKey:TeamID"`
+	TeamMembers      []TeamMember      `json:"-" gorm:"foreignKey:TeamID"`
+	TeamAssignments  []TeamAssignment  `json:"-" gorm:"foreignKey:TeamID"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Statement of the materials held by the team, drawn up when the team leader
+// changes or the team is dissolved
+type TeamAccountabilityStatement struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ProjectID       uint      `json:"projectID"`
+	TeamID          uint      `json:"teamID"`
+	Number          string    `json:"number" gorm:"tinyText"`
+	Date            time.Time `json:"date"`
+	Reason          string    `json:"reason" gorm:"tinyText"`
+	CreatedByUserID uint      `json:"createdByUserID"`
+}
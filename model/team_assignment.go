@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Team planned to work on the object during the days from DateFrom to DateTo inclusive
+type TeamAssignment struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ProjectID       uint      `json:"projectID"`
+	TeamID          uint      `json:"teamID"`
+	ObjectID        uint      `json:"objectID"`
+	DistrictID      uint      `json:"districtID"`
+	DateFrom        time.Time `json:"dateFrom" gorm:"type:date"`
+	DateTo          time.Time `json:"dateTo" gorm:"type:date"`
+	Notes           string    `json:"notes"`
+	CreatedByUserID uint      `json:"createdByUserID"`
+
+	TeamAssignmentOperations []TeamAssignmentOperation `json:"-" gorm:"foreignKey:TeamAssignmentID"`
+}
+
+// Amount of the operation the team is planned to do during the assignment
+type TeamAssignmentOperation struct {
+	ID               uint    `json:"id" gorm:"primaryKey"`
+	TeamAssignmentID uint    `json:"teamAssignmentID"`
+	OperationID      uint    `json:"operationID"`
+	Amount           float64 `json:"amount"`
+}
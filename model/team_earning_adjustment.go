@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Bonus or deduction added to the piece-rate earnings of the team. Adjustment without
+// WorkerID is split among the team members, deductions for the materials lost by
+// the team keep the write-off invoice they were charged from
+type TeamEarningAdjustment struct {
+	ID                uint            `json:"id" gorm:"primaryKey"`
+	ProjectID         uint            `json:"projectID"`
+	TeamID            uint            `json:"teamID"`
+	WorkerID          uint            `json:"workerID"`
+	Date              time.Time       `json:"date"`
+	Kind              string          `json:"kind" gorm:"tinyText"`
+	Amount            decimal.Decimal `json:"amount" gorm:"type:decimal(20,2)"`
+	Reason            string          `json:"reason"`
+	InvoiceWriteOffID uint            `json:"invoiceWriteOffID"`
+	CreatedByUserID   uint            `json:"createdByUserID"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Period of the worker in the team, zero EffectiveTo means the worker is still in the team
+type TeamMember struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ProjectID     uint      `json:"projectID"`
+	TeamID        uint      `json:"teamID"`
+	WorkerID      uint      `json:"workerID"`
+	EffectiveFrom time.Time `json:"effectiveFrom" gorm:"type:date"`
+	EffectiveTo   time.Time `json:"effectiveTo" gorm:"type:date"`
+}
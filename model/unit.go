@@ -0,0 +1,10 @@
+package model
+
+type Unit struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	ProjectID uint   `json:"projectID"`
+	Name      string `json:"name" gorm:"tinyText"`
+	ShortName string `json:"shortName" gorm:"tinyText"`
+
+	MaterialUnits []MaterialUnit `json:"-" gorm:"foreignKey:UnitID"`
+}
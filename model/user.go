@@ -10,4 +10,5 @@ type User struct {
 	UserActions    []UserAction    `json:"-" gorm:"foreignKey:UserID"`
 	UserInProjects []UserInProject `json:"-" gorm:"foreignKey:UserID"`
   AuctionParticipantPrices []AuctionParticipantPrice `json:"-" gorm:"foreignKey:UserID"`
+  ObjectStatusTransitions []ObjectStatusTransition `json:"-" gorm:"foreignKey:UserID"`
 }
@@ -17,6 +17,9 @@ type Worker struct {
 	//Team Leaders
 	TeamLeaderss []TeamLeaders `json:"-" gorm:"foreignKey:LeaderWorkerID"`
 
+	//Team Members
+	TeamMembers []TeamMember `json:"-" gorm:"foreignKey:WorkerID"`
+
 	//Invoice Input Workers
 	InvoiceInputsWarehouseManager []InvoiceInput `json:"-" gorm:"foreignKey:WarehouseManagerWorkerID"`
 	InvoiceInputsReleased         []InvoiceInput `json:"-" gorm:"foreignKey:ReleasedWorkerID"`
@@ -41,5 +44,6 @@ type Worker struct {
 	//Invoice WriteOff Relased
 	InvoiceWriteOffReleaseds []InvoiceWriteOff `json:"-" gorm:"foreignKey:ReleasedWorkerID"`
 
-	WorkerAttendances []WorkerAttendance `json:"-" gorm:"foreignKey:WorkerID"`
+	WorkerAttendances    []WorkerAttendance    `json:"-" gorm:"foreignKey:WorkerID"`
+	WorkerQualifications []WorkerQualification `json:"-" gorm:"foreignKey:WorkerID"`
 }
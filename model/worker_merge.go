@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Duplicate worker merged into the kept worker of the same project. The merged worker
+// is deleted and its fields are kept here so that the merge can be undone
+type WorkerMerge struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	ProjectID         uint      `json:"projectID"`
+	KeptWorkerID      uint      `json:"keptWorkerID"`
+	MergedWorkerID    uint      `json:"mergedWorkerID"`
+	Name              string    `json:"name" gorm:"tinyText"`
+	CompanyWorkerID   string    `json:"companyWorkerID"`
+	JobTitleInCompany string    `json:"jobTitleInCompany"`
+	JobTitleInProject string    `json:"jobTitleInProject" gorm:"tinyText"`
+	MobileNumber      string    `json:"mobileNumber" gorm:"tinyText"`
+	MergedByUserID    uint      `json:"mergedByUserID"`
+	MergedAt          time.Time `json:"mergedAt"`
+	UndoneByUserID    uint      `json:"undoneByUserID"`
+	UndoneAt          time.Time `json:"undoneAt"`
+
+	WorkerMergeReferences []WorkerMergeReference `json:"-" gorm:"foreignKey:WorkerMergeID"`
+}
+
+// Row that pointed to the merged worker. Rows that would repeat a row of the kept
+// worker are removed, RowData keeps the removed row as JSON
+type WorkerMergeReference struct {
+	ID              uint   `json:"id" gorm:"primaryKey"`
+	WorkerMergeID   uint   `json:"workerMergeID"`
+	ReferenceTable  string `json:"referenceTable" gorm:"tinyText"`
+	ReferenceColumn string `json:"referenceColumn" gorm:"tinyText"`
+	RowID           uint   `json:"rowID"`
+	Removed         bool   `json:"removed"`
+	RowData         string `json:"rowData"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type WorkerQualification struct {
+	ID                  uint      `json:"id" gorm:"primaryKey"`
+	ProjectID           uint      `json:"projectID"`
+	WorkerID            uint      `json:"workerID"`
+	QualificationTypeID uint      `json:"qualificationTypeID"`
+	Number              string    `json:"number" gorm:"tinyText"`
+	Grade               string    `json:"grade" gorm:"tinyText"`
+	IssuedAt            time.Time `json:"issuedAt"`
+	ExpiresAt           time.Time `json:"expiresAt"`
+	DocumentName        string    `json:"documentName"`
+	DocumentMimeType    string    `json:"documentMimeType" gorm:"tinyText"`
+	DocumentPath        string    `json:"-"`
+}
@@ -55,6 +55,8 @@ func AutoMigrate(db *gorm.DB) error {
 		model.UserAction{},
 		model.UserInProject{},
 		model.Material{},
+		model.Unit{},
+		model.MaterialUnit{},
 		model.MaterialCost{},
 		model.MaterialLocation{},
 		model.MaterialDefect{},
@@ -63,10 +65,14 @@ func AutoMigrate(db *gorm.DB) error {
 		model.ObjectSupervisors{},
 		model.Operation{},
 		model.OperationMaterial{},
+		model.OperationBOMItem{},
 		model.Permission{},
 		model.SerialNumber{},
 		model.SerialNumberLocation{},
 		model.SerialNumberMovement{},
+		model.MaterialLot{},
+		model.MaterialLotLocation{},
+		model.MaterialLotMovement{},
 		model.Team{},
 		model.TeamLeaders{},
 		model.InvoiceMaterials{},
@@ -80,6 +86,28 @@ func AutoMigrate(db *gorm.DB) error {
 		model.InvoiceObjectOperator{},
 		model.InvoiceWriteOff{},
 		model.OperatorErrorFound{},
+		model.InvoiceObjectBOMDeviation{},
+		model.ObjectStatusTransition{},
+		model.NetworkConnection{},
+		model.ObjectPlannedMaterial{},
+		model.ObjectPlannedOperation{},
+		model.ObjectType{},
+		model.ObjectTypeAttribute{},
+		model.CustomObject{},
+		model.CustomObjectAttributeValue{},
+		model.Attachment{},
+		model.HandoverAct{},
+		model.AttendanceImportFormat{},
+		model.ProjectCalendarDay{},
+		model.TeamEarningAdjustment{},
+		model.TeamAccountabilityStatement{},
+		model.QualificationType{},
+		model.WorkerQualification{},
+		model.TeamMember{},
+		model.TeamAssignment{},
+		model.TeamAssignmentOperation{},
+		model.WorkerMerge{},
+		model.WorkerMergeReference{},
 		model.KL04KV_Object{},
 		model.MJD_Object{},
 		model.SIP_Object{},
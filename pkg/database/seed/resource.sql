@@ -14,6 +14,8 @@ FROM (VALUES
   ('Администратирование', 'Администрирование ресурсами', '/resource'),
   ('Администратирование', 'Администрирование ролями', '/role'),
   ('Администратирование', 'Администрирование доступами', '/permission'),
+  ('Администратирование', 'Администрирование типов объектов', '/object-type'),
+  ('Администратирование', 'Объединение дубликатов работников', '/worker-merge'),
   ('Справочник', 'Справочник материалов', '/kl04kv'),
   ('Справочник', 'Справочник материалов', '/mjd'),
   ('Справочник', 'Справочник материалов', '/sip'),
@@ -25,6 +27,9 @@ FROM (VALUES
   ('Справочник', 'Справочник районов', '/district'),
   ('Справочник', 'Справочник сервисов', '/operation'),
   ('Справочник', 'Справочник объектов', '/object'),
+  ('Справочник', 'Статусы объектов', '/object-status'),
+  ('Справочник', 'Сдача объектов заказчику', '/object-status/handover'),
+  ('Справочник', 'Возврат статуса объекта', '/object-status/rollback'),
   ('Справочник', 'Справочник бригад', '/team'),
   ('Справочник', 'Справочник сотрудников', '/worker'),
   ('Справочник', 'Справочник серийных номеров', '/serial-number'),